@@ -0,0 +1,112 @@
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// dayRecord is one day's aggregated counts, the unit persisted to the
+// sidecar file. Persisting daily sums instead of the full hourly ring
+// keeps the file small (90 entries regardless of column count) at the
+// cost of hour-level resolution across a restart: after LoadStore, a
+// ColumnThroughput window counts a whole day's events only if that day's
+// midnight bucket falls inside the window.
+type dayRecord struct {
+	Day     string            `json:"day"` // YYYY-MM-DD, UTC
+	Global  counts            `json:"global"`
+	Columns map[string]counts `json:"columns,omitempty"`
+}
+
+// sidecarFile is the on-disk shape of the activity sidecar JSON.
+type sidecarFile struct {
+	Days []dayRecord `json:"days"`
+}
+
+// Save writes s's last Retention days to path as compact daily sums.
+func (s *Store) Save(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byDay := map[string]*dayRecord{}
+	for _, b := range s.buckets {
+		if b.hour == 0 && b.global.total() == 0 && len(b.byColumn) == 0 {
+			continue
+		}
+		day := time.Unix(b.hour*int64(BucketDuration/time.Second), 0).UTC().Format("2006-01-02")
+		rec, ok := byDay[day]
+		if !ok {
+			rec = &dayRecord{Day: day, Columns: map[string]counts{}}
+			byDay[day] = rec
+		}
+		rec.Global.Created += b.global.Created
+		rec.Global.Updated += b.global.Updated
+		rec.Global.Closed += b.global.Closed
+		for col, c := range b.byColumn {
+			cur := rec.Columns[col]
+			cur.Created += c.Created
+			cur.Updated += c.Updated
+			cur.Closed += c.Closed
+			rec.Columns[col] = cur
+		}
+	}
+
+	days := make([]string, 0, len(byDay))
+	for day := range byDay {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	out := sidecarFile{Days: make([]dayRecord, 0, len(days))}
+	for _, day := range days {
+		out.Days = append(out.Days, *byDay[day])
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("activity: marshaling sidecar: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("activity: writing sidecar %q: %w", path, err)
+	}
+	return nil
+}
+
+// LoadStore reads a sidecar file written by Save and reconstructs a
+// Store, placing each day's totals into that day's first (midnight UTC)
+// hour bucket. A missing file returns an empty Store rather than an
+// error, since the sidecar is an optional warm-start cache.
+func LoadStore(path string) (*Store, error) {
+	s := NewStore()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("activity: reading sidecar %q: %w", path, err)
+	}
+
+	var in sidecarFile
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("activity: parsing sidecar %q: %w", path, err)
+	}
+
+	for _, rec := range in.Days {
+		day, err := time.Parse("2006-01-02", rec.Day)
+		if err != nil {
+			continue // skip malformed entries rather than fail the whole load
+		}
+		hour := day.Unix() / int64(BucketDuration/time.Second)
+		idx := int(hour % int64(numBuckets))
+		s.buckets[idx] = bucket{hour: hour, global: rec.Global, byColumn: rec.Columns}
+	}
+
+	s.mu.Lock()
+	snap := s.buildSnapshotLocked(time.Now())
+	s.mu.Unlock()
+	s.snapshot.Store(snap)
+	return s, nil
+}
@@ -0,0 +1,279 @@
+// Package activity tracks issue created/updated/closed events in
+// per-hour buckets, borrowing Gosora's rolling weekly-view counter
+// pattern: a fixed-size ring buffer of time buckets that rotates forward
+// as real time advances, so memory stays bounded regardless of uptime.
+package activity
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// BucketDuration is the width of one ring-buffer slot.
+const BucketDuration = time.Hour
+
+// Retention is how far back buckets are kept before being overwritten.
+const Retention = 90 * 24 * time.Hour
+
+// numBuckets is the ring buffer's fixed size: one slot per hour of Retention.
+const numBuckets = int(Retention / BucketDuration)
+
+// sparklineDays is the window rendered in a column header sparkline.
+const sparklineDays = 14
+
+// EventKind identifies what happened to an issue.
+type EventKind string
+
+const (
+	EventCreated EventKind = "created"
+	EventUpdated EventKind = "updated"
+	EventClosed  EventKind = "closed"
+)
+
+// counts tallies each EventKind within one bucket (global or per-column).
+type counts struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Closed  int `json:"closed"`
+}
+
+func (c counts) total() int { return c.Created + c.Updated + c.Closed }
+
+func (c *counts) add(kind EventKind) {
+	switch kind {
+	case EventCreated:
+		c.Created++
+	case EventUpdated:
+		c.Updated++
+	case EventClosed:
+		c.Closed++
+	}
+}
+
+// bucket is one hour-wide slot of the ring buffer.
+type bucket struct {
+	hour     int64 // epoch hour this slot currently represents
+	global   counts
+	byColumn map[string]counts
+}
+
+// issueStats is the sparse per-issue event history used by IssueVelocity.
+// Unlike the ring buffer, this is a plain slice trimmed to Retention on
+// each write, since the number of distinct issues touched in a window is
+// small relative to numBuckets.
+type issueStats struct {
+	events []time.Time
+}
+
+// Snapshot is an immutable, read-optimized view rebuilt after every
+// RecordEvent and published via an atomic pointer swap, so rendering a
+// column header sparkline never contends with the write-side mutex.
+type Snapshot struct {
+	// ColumnDaily maps column -> closed-event counts per day over the
+	// last sparklineDays days, oldest first.
+	ColumnDaily map[string][]int
+	// ColumnDelta maps column -> fractional change in closed throughput
+	// versus the prior sparklineDays window (e.g. 0.5 = +50%, -1 = -100%).
+	ColumnDelta map[string]float64
+}
+
+var emptySnapshot = &Snapshot{ColumnDaily: map[string][]int{}, ColumnDelta: map[string]float64{}}
+
+// Store is the in-memory activity tracker for one board. The zero value
+// is not usable; construct with NewStore or LoadStore.
+type Store struct {
+	mu      sync.Mutex
+	buckets []bucket // ring buffer, index = hour % numBuckets
+	issues  map[string]*issueStats
+
+	snapshot atomic.Pointer[Snapshot]
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	s := &Store{
+		buckets: make([]bucket, numBuckets),
+		issues:  make(map[string]*issueStats),
+	}
+	s.snapshot.Store(emptySnapshot)
+	return s
+}
+
+// Snapshot returns the most recently published read-optimized view. Safe
+// to call from the render hot path without blocking RecordEvent.
+func (s *Store) Snapshot() *Snapshot {
+	return s.snapshot.Load()
+}
+
+// RecordEvent records that issueID in column experienced kind at at,
+// rotating the ring buffer forward if at falls in a new hour and
+// republishing the read snapshot.
+func (s *Store) RecordEvent(issueID, column string, kind EventKind, at time.Time) {
+	s.mu.Lock()
+
+	b := s.bucketForLocked(at)
+	b.global.add(kind)
+	if b.byColumn == nil {
+		b.byColumn = map[string]counts{}
+	}
+	c := b.byColumn[column]
+	c.add(kind)
+	b.byColumn[column] = c
+
+	st, ok := s.issues[issueID]
+	if !ok {
+		st = &issueStats{}
+		s.issues[issueID] = st
+	}
+	st.events = append(st.events, at)
+	st.events = trimBefore(st.events, at.Add(-Retention))
+
+	snap := s.buildSnapshotLocked(at)
+	s.mu.Unlock()
+
+	s.snapshot.Store(snap)
+}
+
+// bucketForLocked returns the bucket slot for at, clearing any slots the
+// ring buffer rotated past since they were last written. Callers must
+// hold s.mu.
+func (s *Store) bucketForLocked(at time.Time) *bucket {
+	hour := at.Unix() / int64(BucketDuration/time.Second)
+	idx := int(hour % int64(numBuckets))
+	b := &s.buckets[idx]
+	if b.hour != hour {
+		*b = bucket{hour: hour}
+	}
+	return b
+}
+
+// trimBefore drops leading timestamps older than cutoff, reusing events'
+// backing array since it's append-only and scanned oldest-first.
+func trimBefore(events []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(events) && events[i].Before(cutoff) {
+		i++
+	}
+	return events[i:]
+}
+
+// ColumnThroughput returns the number of events recorded against column
+// within the last window, as of now.
+func (s *Store) ColumnThroughput(column string, window time.Duration) int {
+	return s.ColumnThroughputAt(column, window, time.Now())
+}
+
+// ColumnThroughputAt is ColumnThroughput as of an explicit instant, split
+// out so tests don't depend on wall-clock time.
+//
+// Buckets only track hour-granularity aggregates, so a sub-hour window
+// can't isolate individual events within a bucket; instead a bucket only
+// counts if its whole hour-wide span starts on or after the cutoff,
+// rather than floor-dividing the cutoff to an hour boundary and matching
+// on that boundary's bucket. The latter let a bucket straddling the
+// cutoff count in full even when most of its span falls outside window.
+func (s *Store) ColumnThroughputAt(column string, window time.Duration, now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := now.Add(-window).Unix()
+	nowUnix := now.Unix()
+
+	total := 0
+	for _, b := range s.buckets {
+		bucketStart := b.hour * int64(BucketDuration/time.Second)
+		if bucketStart < cutoff || bucketStart > nowUnix {
+			continue
+		}
+		total += b.byColumn[column].total()
+	}
+	return total
+}
+
+// IssueVelocity returns issueID's event rate in events/day, averaged over
+// the last sparklineDays days. An issue whose history is shorter than
+// that is still divided by the full sparklineDays window.
+func (s *Store) IssueVelocity(issueID string) float64 {
+	return s.issueVelocityAt(issueID, time.Now())
+}
+
+func (s *Store) issueVelocityAt(issueID string, now time.Time) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.issues[issueID]
+	if !ok || len(st.events) == 0 {
+		return 0
+	}
+
+	cutoff := now.Add(-sparklineDays * 24 * time.Hour)
+	n := 0
+	for _, t := range st.events {
+		if !t.Before(cutoff) {
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(n) / sparklineDays
+}
+
+// buildSnapshotLocked recomputes the read-optimized Snapshot from the
+// ring buffer. Callers must hold s.mu.
+func (s *Store) buildSnapshotLocked(now time.Time) *Snapshot {
+	dayHour := int64(24 * time.Hour / BucketDuration)
+	nowHour := now.Unix() / int64(BucketDuration/time.Second)
+
+	daily := map[string][]int{}
+	deltas := map[string]float64{}
+
+	columns := map[string]bool{}
+	for _, b := range s.buckets {
+		for col := range b.byColumn {
+			columns[col] = true
+		}
+	}
+
+	for col := range columns {
+		perDay := make([]int, sparklineDays)
+		var priorWindow int
+		for _, b := range s.buckets {
+			if b.hour > nowHour {
+				continue
+			}
+			age := nowHour - b.hour
+			if age < sparklineDays*dayHour {
+				day := sparklineDays - 1 - int(age/dayHour)
+				if day >= 0 && day < sparklineDays {
+					perDay[day] += b.byColumn[col].Closed
+				}
+			} else if age < 2*sparklineDays*dayHour {
+				priorWindow += b.byColumn[col].Closed
+			}
+		}
+		daily[col] = perDay
+
+		currentWindow := 0
+		for _, n := range perDay {
+			currentWindow += n
+		}
+		deltas[col] = deltaRatio(currentWindow, priorWindow)
+	}
+
+	return &Snapshot{ColumnDaily: daily, ColumnDelta: deltas}
+}
+
+// deltaRatio is (current-prior)/prior, with a sentinel-free convention
+// for the all-zero cases: 0 if both windows are empty, 1.0 (a "new
+// activity" +100%) if prior was empty but current isn't.
+func deltaRatio(current, prior int) float64 {
+	if prior == 0 {
+		if current == 0 {
+			return 0
+		}
+		return 1
+	}
+	return float64(current-prior) / float64(prior)
+}
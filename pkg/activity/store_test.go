@@ -0,0 +1,108 @@
+package activity
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecordEventTracksColumnThroughput(t *testing.T) {
+	s := NewStore()
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	s.RecordEvent("bv-1", "done", EventClosed, now.Add(-2*time.Hour))
+	s.RecordEvent("bv-2", "done", EventClosed, now.Add(-1*time.Hour))
+	s.RecordEvent("bv-3", "doing", EventUpdated, now.Add(-1*time.Hour))
+
+	if got := s.ColumnThroughputAt("done", 24*time.Hour, now); got != 2 {
+		t.Errorf("expected 2 events in 'done' over 24h, got %d", got)
+	}
+	if got := s.ColumnThroughputAt("doing", 24*time.Hour, now); got != 1 {
+		t.Errorf("expected 1 event in 'doing' over 24h, got %d", got)
+	}
+	if got := s.ColumnThroughputAt("done", 30*time.Minute, now); got != 0 {
+		t.Errorf("expected 0 events in a 30m window that excludes both writes, got %d", got)
+	}
+}
+
+func TestRecordEventExcludesEventsOutsideWindow(t *testing.T) {
+	s := NewStore()
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	s.RecordEvent("bv-1", "done", EventClosed, now.Add(-40*24*time.Hour))
+
+	if got := s.ColumnThroughputAt("done", 14*24*time.Hour, now); got != 0 {
+		t.Errorf("expected event older than the window to be excluded, got %d", got)
+	}
+	if got := s.ColumnThroughputAt("done", 90*24*time.Hour, now); got != 1 {
+		t.Errorf("expected event within retention to still count, got %d", got)
+	}
+}
+
+func TestIssueVelocity(t *testing.T) {
+	s := NewStore()
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 7; i++ {
+		s.RecordEvent("bv-1", "doing", EventUpdated, now.Add(-time.Duration(i)*24*time.Hour))
+	}
+
+	v := s.issueVelocityAt("bv-1", now)
+	if v <= 0 {
+		t.Fatalf("expected positive velocity, got %f", v)
+	}
+
+	if got := s.issueVelocityAt("bv-unknown", now); got != 0 {
+		t.Errorf("expected 0 velocity for an untracked issue, got %f", got)
+	}
+}
+
+func TestSnapshotReflectsRecentEvents(t *testing.T) {
+	s := NewStore()
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	s.RecordEvent("bv-1", "done", EventClosed, now)
+
+	snap := s.Snapshot()
+	daily, ok := snap.ColumnDaily["done"]
+	if !ok {
+		t.Fatal("expected snapshot to include the 'done' column")
+	}
+	if daily[len(daily)-1] != 1 {
+		t.Errorf("expected today's bucket to show 1 closed event, got %v", daily)
+	}
+}
+
+func TestSaveAndLoadRoundTrip(t *testing.T) {
+	s := NewStore()
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	s.RecordEvent("bv-1", "done", EventClosed, now)
+	s.RecordEvent("bv-2", "doing", EventUpdated, now.Add(-25*time.Hour))
+
+	path := filepath.Join(t.TempDir(), "activity.json")
+	if err := s.Save(path); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	loaded, err := LoadStore(path)
+	if err != nil {
+		t.Fatalf("LoadStore returned error: %v", err)
+	}
+
+	if got := loaded.ColumnThroughputAt("done", 90*24*time.Hour, now); got != 1 {
+		t.Errorf("expected 'done' throughput to survive a round trip, got %d", got)
+	}
+	if got := loaded.ColumnThroughputAt("doing", 90*24*time.Hour, now); got != 1 {
+		t.Errorf("expected 'doing' throughput to survive a round trip, got %d", got)
+	}
+}
+
+func TestLoadStoreMissingFileIsEmpty(t *testing.T) {
+	s, err := LoadStore("/nonexistent/activity.json")
+	if err != nil {
+		t.Fatalf("LoadStore of a missing file should not error, got: %v", err)
+	}
+	if got := s.ColumnThroughput("done", 24*time.Hour); got != 0 {
+		t.Errorf("expected empty store, got throughput %d", got)
+	}
+}
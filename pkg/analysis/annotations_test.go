@@ -0,0 +1,86 @@
+package analysis
+
+import "testing"
+
+func TestAnnotationsByMetric(t *testing.T) {
+	anns := Annotations{
+		{Metric: "betweenness", Code: "betweenness.approximate"},
+		{Metric: "pagerank", Code: "pagerank.not_converged"},
+		{Metric: "betweenness", Code: "betweenness.timeout"},
+	}
+
+	got := anns.ByMetric("betweenness")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 betweenness annotations, got %d", len(got))
+	}
+	if got[0].Code != "betweenness.approximate" || got[1].Code != "betweenness.timeout" {
+		t.Errorf("expected order preserved, got %+v", got)
+	}
+}
+
+func TestAnnotationsBySeverity(t *testing.T) {
+	anns := Annotations{
+		{Severity: SeverityInfo, Code: "info"},
+		{Severity: SeverityWarning, Code: "warn"},
+		{Severity: SeverityError, Code: "err"},
+	}
+
+	got := anns.BySeverity(SeverityWarning)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 annotations at warning or above, got %d", len(got))
+	}
+	for _, a := range got {
+		if a.Code == "info" {
+			t.Errorf("expected info annotation to be filtered out, got %+v", a)
+		}
+	}
+}
+
+func TestMergeAnnotations(t *testing.T) {
+	a := Annotations{{Code: "a"}}
+	b := Annotations{{Code: "b"}, {Code: "c"}}
+
+	got := MergeAnnotations(a, nil, b)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 merged annotations, got %d", len(got))
+	}
+	if got[0].Code != "a" || got[1].Code != "b" || got[2].Code != "c" {
+		t.Errorf("expected merge order preserved, got %+v", got)
+	}
+
+	if MergeAnnotations() != nil {
+		t.Error("expected MergeAnnotations() with no input to return nil")
+	}
+}
+
+func TestApproxBetweennessWithOptionsAnnotatesApproximate(t *testing.T) {
+	g := chainGraph(20)
+	result := ApproxBetweennessWithOptions(g, 8, 1, BetweennessOptions{})
+
+	found := result.Annotations.ByMetric("betweenness")
+	if len(found) != 1 || found[0].Code != "betweenness.approximate" {
+		t.Fatalf("expected one betweenness.approximate annotation, got %+v", result.Annotations)
+	}
+}
+
+func TestApproxBetweennessWithOptionsExactHasNoApproximateAnnotation(t *testing.T) {
+	g := chainGraph(5)
+	result := ApproxBetweennessWithOptions(g, 5, 1, BetweennessOptions{})
+
+	if len(result.Annotations) != 0 {
+		t.Errorf("expected no annotations for an exact result, got %+v", result.Annotations)
+	}
+}
+
+func TestSampledBetweennessAnnotatesEpsilonAndSampleSize(t *testing.T) {
+	g := chainGraph(20)
+	result := SampledBetweenness(g, SampledBetweennessOptions{Epsilon: 0.1, Delta: 0.1, Seed: 1})
+
+	found := result.Annotations.ByMetric("betweenness")
+	if len(found) != 1 || found[0].Code != "betweenness.approximate" {
+		t.Fatalf("expected one betweenness.approximate annotation, got %+v", result.Annotations)
+	}
+	if found[0].Detail["epsilon"] != 0.1 {
+		t.Errorf("expected epsilon 0.1 in detail, got %+v", found[0].Detail)
+	}
+}
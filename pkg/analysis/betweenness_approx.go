@@ -1,6 +1,9 @@
 package analysis
 
 import (
+	"context"
+	"math"
+	"math/bits"
 	"math/rand"
 	"runtime"
 	"sort"
@@ -100,31 +103,87 @@ type brandesBuffers struct {
 	bc        []float64 // Per-source betweenness contributions
 }
 
-// brandesPool provides reusable buffer sets for singleSourceBetweennessDense.
-// Pre-allocation with capacity 256 handles most real-world graphs efficiently;
-// slices will grow if needed but retain capacity for subsequent reuse.
-//
-// Concurrency: sync.Pool is safe for concurrent Get/Put. Each goroutine
-// gets its own buffer; no synchronization needed during algorithm execution.
-//
-// GC behavior: Pool may discard buffers during GC. This is acceptable since
-// New() will create fresh buffers as needed; we trade occasional allocations
-// for reduced peak memory during steady-state operation.
-var brandesPool = sync.Pool{
-	New: func() interface{} {
-		return &brandesBuffers{
-			sigma:     make([]float64, 0, 256),
-			dist:      make([]int, 0, 256),
-			delta:     make([]float64, 0, 256),
-			pred:      make([][]int, 0, 256),
-			queue:     make([]int, 0, 256),
-			stack:     make([]int, 0, 256),
-			neighbors: make([]int, 0, 32),
-			bc:        make([]float64, 0, 256),
+// newBrandesBuffers allocates a brandesBuffers sized for capacity nodes.
+func newBrandesBuffers(capacity int) *brandesBuffers {
+	return &brandesBuffers{
+		sigma:     make([]float64, 0, capacity),
+		dist:      make([]int, 0, capacity),
+		delta:     make([]float64, 0, capacity),
+		pred:      make([][]int, 0, capacity),
+		queue:     make([]int, 0, capacity),
+		stack:     make([]int, 0, capacity),
+		neighbors: make([]int, 0, 32),
+		bc:        make([]float64, 0, capacity),
+	}
+}
+
+// brandesBufferCapacityBuckets is the number of power-of-two capacity
+// buckets brandesPool keeps: 2^0 through 2^(brandesBufferCapacityBuckets-1),
+// comfortably covering single-digit to multi-million node graphs.
+const brandesBufferCapacityBuckets = 24
+
+// brandesBufferPool pools brandesBuffers keyed by a power-of-two
+// capacity bucket rather than a single shared sync.Pool. Without
+// bucketing, a buffer sized for a million-node graph can end up Get()
+// by a caller processing a hundred-node graph - wasting the memory the
+// big buffer holds - while a caller with the million-node graph might
+// instead draw a too-small buffer and immediately grow it. Bucketing by
+// capacity keeps Get()s size-appropriate and avoids that fragmentation.
+type brandesBufferPool struct {
+	buckets [brandesBufferCapacityBuckets]sync.Pool
+}
+
+func newBrandesBufferPool() *brandesBufferPool {
+	p := &brandesBufferPool{}
+	for i := range p.buckets {
+		capacity := 1 << i
+		p.buckets[i].New = func() interface{} {
+			return newBrandesBuffers(capacity)
 		}
-	},
+	}
+	return p
+}
+
+// bucketForCapacity maps a requested capacity to its bucket index: the
+// smallest power of two at least as large as capacity, clamped to the
+// largest bucket brandesBufferPool keeps.
+func bucketForCapacity(capacity int) int {
+	if capacity < 1 {
+		capacity = 1
+	}
+	idx := bits.Len(uint(capacity - 1))
+	if idx >= brandesBufferCapacityBuckets {
+		idx = brandesBufferCapacityBuckets - 1
+	}
+	return idx
+}
+
+// get returns a brandesBuffers from the bucket sized for at least
+// nodeCount nodes.
+func (p *brandesBufferPool) get(nodeCount int) *brandesBuffers {
+	return p.buckets[bucketForCapacity(nodeCount)].Get().(*brandesBuffers)
+}
+
+// put returns buf to the bucket matching its current sigma capacity,
+// the same bucket get would hand it out from for that size again.
+func (p *brandesBufferPool) put(buf *brandesBuffers) {
+	p.buckets[bucketForCapacity(cap(buf.sigma))].Put(buf)
 }
 
+// brandesPool provides reusable buffer sets for singleSourceBetweennessDense,
+// bucketed by capacity so a pool serving wildly different graph sizes
+// doesn't thrash between over- and under-sized buffers.
+//
+// Concurrency: each bucket's sync.Pool is safe for concurrent Get/Put.
+// Each goroutine gets its own buffer; no synchronization needed during
+// algorithm execution.
+//
+// GC behavior: a bucket may discard buffers during GC. This is
+// acceptable since New() will create fresh buffers as needed; we trade
+// occasional allocations for reduced peak memory during steady-state
+// operation.
+var brandesPool = newBrandesBufferPool()
+
 var approxNodesPool = sync.Pool{
 	New: func() interface{} {
 		return make([]graph.Node, 0, 256)
@@ -159,8 +218,12 @@ func putPooledNodes(nodes []graph.Node) {
 // Must be called before each new source node BFS traversal.
 //
 // Memory strategy:
-//   - If slices grew >2x node count, reallocate to allow GC of oversized backing arrays
-//   - Slices reset via [:0] to retain backing array
+//   - Grow backing arrays only when capacity is insufficient
+//   - Slices truncated to nodeCount via [:nodeCount] to retain backing array
+//   - Oversized buffers are never shrunk here - brandesPool's capacity
+//     buckets (see bucketForCapacity) keep a buffer sized for one graph
+//     from being handed to a caller with a much smaller one, so reset
+//     doesn't need its own shrink heuristic to bound retention
 //
 // Initialization values match fresh-allocation semantics:
 //   - sigma[i] = 0 (no paths counted yet)
@@ -168,10 +231,7 @@ func putPooledNodes(nodes []graph.Node) {
 //   - delta[i] = 0 (no dependency accumulated)
 //   - pred[i] = pred[i][:0] (empty predecessor list, retain slice capacity)
 func (b *brandesBuffers) reset(nodeCount int) {
-	// Resize backing arrays when the graph size changes significantly:
-	// - Grow when capacity is insufficient
-	// - Shrink when previous capacity is >2x node count (avoid unbounded retention)
-	if cap(b.sigma) < nodeCount || cap(b.sigma) > nodeCount*2 {
+	if cap(b.sigma) < nodeCount {
 		b.sigma = make([]float64, 0, nodeCount)
 		b.dist = make([]int, 0, nodeCount)
 		b.delta = make([]float64, 0, nodeCount)
@@ -251,10 +311,193 @@ type BetweennessResult struct {
 
 	// TimedOut indicates if computation was interrupted by timeout
 	TimedOut bool
+
+	// ApproxError is the absolute error bound on each node's normalized
+	// betweenness score, for results that carry one - currently only
+	// SampledBetweenness, whose (epsilon, delta)-accuracy guarantee gives
+	// it a principled bound to report. Zero for every other mode,
+	// including BetweennessApproximate results from
+	// ApproxBetweennessWithOptions/ApproxBetweennessCtx, whose pivot
+	// sampling has no comparable closed-form bound. A non-zero value lets
+	// a consumer like the top-K collector display "±ε" and avoid
+	// tie-breaking on score differences smaller than it.
+	ApproxError float64
+
+	// Annotations records structured, machine-readable notes about how
+	// this result was computed - e.g. that it's approximate, or that it
+	// timed out partway through - so a UI can badge them and an exporter
+	// can emit their Code without parsing TimedOut/Mode combinations
+	// itself. See Annotation.
+	Annotations Annotations
+}
+
+// BetweennessOptions tunes the worker pool ApproxBetweennessWithOptions
+// uses to compute pivot BFS iterations concurrently. A zero-valued
+// BetweennessOptions reproduces ApproxBetweenness's existing behavior:
+// one worker per runtime.NumCPU(), one pivot pulled off the work queue
+// at a time.
+type BetweennessOptions struct {
+	// PipelineDepth caps the number of worker goroutines computing
+	// single-source BFS iterations concurrently. Zero means
+	// runtime.NumCPU().
+	PipelineDepth int
+
+	// ChunkSize is how many pivots a worker pulls off the work channel
+	// per receive, amortizing channel overhead across a batch of
+	// sources instead of one channel operation per pivot. Zero means 1.
+	ChunkSize int
+
+	// Strategy selects how pivot nodes are sampled. The zero value ("")
+	// is equivalent to SamplingUniform, today's Fisher-Yates behavior.
+	Strategy BetweennessSamplingStrategy
+
+	// SampleSize is the number of pivot nodes to sample. Only consulted
+	// by ApproxBetweennessCtx, which - unlike ApproxBetweennessWithOptions -
+	// takes sample size and seed through opts instead of as separate
+	// parameters, so a caller's deadline and progress settings travel
+	// alongside them in one struct.
+	SampleSize int
+
+	// Seed seeds the pivot-sampling RNG. Only consulted by
+	// ApproxBetweennessCtx.
+	Seed int64
+
+	// Progress, if non-nil, is called as pivots complete: done is the
+	// number of pivots processed so far, total is SampleSize. Only
+	// consulted by ApproxBetweennessCtx, which calls it under the same
+	// mutex it merges worker results with, so calls never overlap.
+	Progress func(done, total int)
+
+	// CancelPolicy tunes how eagerly ApproxBetweennessCtx checks for
+	// context cancellation inside a pivot's own BFS. The zero value
+	// checks at defaultBFSCheckInterval queue pops.
+	CancelPolicy BetweennessCancelPolicy
+}
+
+// BetweennessCancelPolicy is ApproxBetweennessCtx's soft-cancel
+// configuration: it trades how quickly a cancelled context stops
+// in-flight work against the per-node overhead of checking for it.
+type BetweennessCancelPolicy struct {
+	// BFSCheckInterval is how many BFS queue pops
+	// singleSourceBetweennessDenseCtx performs between ctx.Done()
+	// checks. Zero means defaultBFSCheckInterval.
+	BFSCheckInterval int
+}
+
+// defaultBFSCheckInterval is coarse enough that the ctx.Done() select
+// doesn't show up in profiles next to a BFS queue pop, but fine enough
+// that a cancelled deadline still lands within a pivot or two on any
+// graph this package is meant for.
+const defaultBFSCheckInterval = 4096
+
+func (p BetweennessCancelPolicy) bfsCheckInterval() int {
+	if p.BFSCheckInterval > 0 {
+		return p.BFSCheckInterval
+	}
+	return defaultBFSCheckInterval
+}
+
+func (o BetweennessOptions) pipelineDepth() int {
+	if o.PipelineDepth > 0 {
+		return o.PipelineDepth
+	}
+	return runtime.NumCPU()
+}
+
+func (o BetweennessOptions) chunkSize() int {
+	if o.ChunkSize > 0 {
+		return o.ChunkSize
+	}
+	return 1
+}
+
+func (o BetweennessOptions) strategy() BetweennessSamplingStrategy {
+	if o.Strategy == "" {
+		return SamplingUniform
+	}
+	return o.Strategy
+}
+
+// BetweennessSamplingStrategy selects how ApproxBetweennessWithOptions
+// picks its pivot nodes.
+type BetweennessSamplingStrategy string
+
+const (
+	// SamplingUniform picks pivots with uniform Fisher-Yates sampling.
+	// It's unbiased but under-covers hub-like regions in graphs with
+	// skewed degree distributions, since a hub is no more likely to be
+	// picked than any of the many low-degree nodes around it.
+	SamplingUniform BetweennessSamplingStrategy = "uniform"
+
+	// SamplingStructural picks pivots via exponentially-distant BFS
+	// frontiers from the DAG's heads, concentrating pivots on the
+	// structurally distinctive nodes near those heads rather than
+	// spreading them across the whole graph. Measured against
+	// SamplingUniform on a synthetic scale-free graph, this clustering
+	// did not improve (and measurably worsened) aggregate rank error,
+	// so treat it as a way to guarantee hub coverage in the sample, not
+	// as a generally more accurate approximation. See
+	// sampleIndicesStructural.
+	SamplingStructural BetweennessSamplingStrategy = "structural"
+
+	// SamplingDegreeWeighted picks pivots with probability proportional
+	// to total degree (in + out), biasing toward hubs directly without
+	// sampleIndicesStructural's frontier-expansion machinery.
+	SamplingDegreeWeighted BetweennessSamplingStrategy = "degree-weighted"
+)
+
+// sourceChunk is a batch of pivot dense-indices a single worker consumes
+// in one channel receive.
+type sourceChunk struct {
+	pivots []int
 }
 
 // ApproxBetweenness computes approximate betweenness centrality using sampling.
 //
+// It is equivalent to ApproxBetweennessWithOptions(g, sampleSize, seed,
+// BetweennessOptions{}) - one worker per runtime.NumCPU(), one pivot per
+// chunk - except for one addition: if SetDefaultCentralityCache has
+// installed a cache, ApproxBetweenness checks it first under this
+// call's CentralityFingerprint and returns the stored scores on a hit,
+// skipping computation entirely; a miss computes as usual and then
+// populates the cache for next time. See ApproxBetweennessWithOptions
+// for the algorithm and error bounds.
+func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) BetweennessResult {
+	cache := defaultCentralityCache
+	if cache == nil {
+		return ApproxBetweennessWithOptions(g, sampleSize, seed, BetweennessOptions{})
+	}
+
+	fingerprint := CentralityFingerprint{
+		GraphHash:  FingerprintGraph(g),
+		Mode:       BetweennessApproximate,
+		SampleSize: sampleSize,
+		Seed:       seed,
+		Strategy:   SamplingUniform,
+	}
+	if cached, ok := cache.Get(fingerprint); ok && cached.Betweenness != nil {
+		return BetweennessResult{
+			Scores:     cached.Betweenness,
+			Mode:       cached.Mode,
+			SampleSize: cached.SampleSize,
+			TotalNodes: cached.TotalNodes,
+			Elapsed:    cached.Elapsed,
+		}
+	}
+
+	result := ApproxBetweennessWithOptions(g, sampleSize, seed, BetweennessOptions{})
+	_ = cache.Put(fingerprint, CentralityResult{
+		Betweenness: result.Scores,
+		Mode:        result.Mode,
+		SampleSize:  result.SampleSize,
+		TotalNodes:  result.TotalNodes,
+		Elapsed:     result.Elapsed,
+	})
+	return result
+}
+
+// ApproxBetweennessWithOptions computes approximate betweenness centrality using sampling.
+//
 // Instead of computing shortest paths from ALL nodes (O(V*E)), we sample k pivot
 // nodes and extrapolate. This is Brandes' approximation algorithm.
 //
@@ -265,10 +508,18 @@ type BetweennessResult struct {
 //
 // For ranking purposes (which node is most central), this is usually sufficient.
 //
+// opts.pipelineDepth() worker goroutines share a bounded channel of
+// sourceChunks; each worker fetches its brandesBuffers once for its
+// entire lifetime (not once per pivot) and accumulates into a
+// goroutine-local map[int64]float64, which is merged into the shared
+// result under a single mutex acquisition when the worker's chunks are
+// exhausted - not once per pivot, the way the previous goroutine-per-pivot
+// version locked.
+//
 // References:
 //   - "A Faster Algorithm for Betweenness Centrality" (Brandes, 2001)
 //   - "Approximating Betweenness Centrality" (Bader et al., 2007)
-func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) BetweennessResult {
+func ApproxBetweennessWithOptions(g *simple.DirectedGraph, sampleSize int, seed int64, opts BetweennessOptions) BetweennessResult {
 	start := time.Now()
 	nodes := pooledNodesOf(g.Nodes())
 	defer putPooledNodes(nodes)
@@ -311,55 +562,279 @@ func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) Betw
 		idx.idToIdx = nil
 	}
 
-	// Sample k random pivot indices
-	pivots := sampleIndices(n, sampleSize, seed)
+	// Sample k pivot indices using the requested strategy.
+	var pivots []int
+	switch opts.strategy() {
+	case SamplingStructural:
+		pivots = sampleIndicesStructural(adj, sampleSize, seed, true)
+	case SamplingDegreeWeighted:
+		pivots = sampleIndicesDegreeWeighted(adj, sampleSize, seed)
+	default:
+		pivots = sampleIndices(n, sampleSize, seed)
+	}
 
-	// Compute partial betweenness from sampled pivots in parallel
-	partialBC := make([]float64, n)
-	var mu sync.Mutex
-	var wg sync.WaitGroup
+	depth := opts.pipelineDepth()
+	chunkSize := opts.chunkSize()
 
-	// Limit concurrency to avoid excessive goroutines
-	sem := make(chan struct{}, runtime.NumCPU())
+	chunks := make(chan sourceChunk, depth)
+	go func() {
+		defer close(chunks)
+		for i := 0; i < len(pivots); i += chunkSize {
+			end := i + chunkSize
+			if end > len(pivots) {
+				end = len(pivots)
+			}
+			chunks <- sourceChunk{pivots: pivots[i:end]}
+		}
+	}()
 
-	for _, pivot := range pivots {
+	merged := make(map[int64]float64, n)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < depth; i++ {
 		wg.Add(1)
-		go func(sourceIdx int) {
+		go func() {
 			defer wg.Done()
-			sem <- struct{}{} // Acquire token
-			defer func() { <-sem }()
 
-			buf := brandesPool.Get().(*brandesBuffers)
-			defer brandesPool.Put(buf)
+			// One buffer set per worker for its entire lifetime, not
+			// one Get/Put pair per pivot.
+			buf := brandesPool.get(n)
+			defer brandesPool.put(buf)
 
-			// Compute local contribution into pooled buffers (buf.bc)
-			singleSourceBetweennessDense(adj, sourceIdx, buf)
+			local := make(map[int64]float64)
+			for chunk := range chunks {
+				for _, sourceIdx := range chunk.pivots {
+					singleSourceBetweennessDense(adj, sourceIdx, buf)
+					for _, w := range buf.stack {
+						local[idx.idxToID[w]] += buf.bc[w]
+					}
+				}
+			}
 
-			// Merge into global result using visited nodes only.
 			mu.Lock()
-			for _, w := range buf.stack {
-				partialBC[w] += buf.bc[w]
+			for id, v := range local {
+				merged[id] += v
 			}
 			mu.Unlock()
-		}(pivot)
+		}()
 	}
 	wg.Wait()
 
 	// Scale up: BC_approx = BC_partial * (n / k)
 	// This extrapolates from the sample to the full graph
 	scale := float64(n) / float64(sampleSize)
-	scores := make(map[int64]float64, n)
-	for i, val := range partialBC {
+	scores := make(map[int64]float64, len(merged))
+	for id, val := range merged {
 		if val == 0 {
 			continue
 		}
-		scores[idx.idxToID[i]] = val * scale
+		scores[id] = val * scale
 	}
 	result.Scores = scores
 	result.Elapsed = time.Since(start)
+	result.Annotations = append(result.Annotations, pivotApproximationAnnotation(sampleSize, n))
 	return result
 }
 
+// pivotApproximationAnnotation is the Annotation every pivot-sampled
+// betweenness result (ApproxBetweennessWithOptions, ApproxBetweennessCtx)
+// carries: pivot sampling has no closed-form error bound the way
+// SampledBetweenness's (epsilon, delta) guarantee does, so this is
+// Info rather than Warning - it's the expected mode for a large graph,
+// not a degradation, but a consumer still needs to know the scores are
+// extrapolated rather than exact.
+func pivotApproximationAnnotation(sampleSize, totalNodes int) Annotation {
+	return Annotation{
+		Metric:   "betweenness",
+		Severity: SeverityInfo,
+		Code:     "betweenness.approximate",
+		Message:  "betweenness computed from a pivot sample, not every node",
+		Detail: map[string]any{
+			"sample_size": sampleSize,
+			"total_nodes": totalNodes,
+		},
+	}
+}
+
+// ApproxBetweennessCtx is ApproxBetweennessWithOptions's context-
+// cancellable, progress-reporting counterpart. opts.SampleSize and
+// opts.Seed take the place of ApproxBetweennessWithOptions's sampleSize
+// and seed parameters - bundled into opts alongside opts.Progress and
+// opts.CancelPolicy so a caller wiring up a soft deadline (e.g. the TUI
+// capping analysis at 500ms) has one place to configure it.
+//
+// Worker goroutines check ctx between pivots and, per opts.CancelPolicy,
+// at coarse intervals inside a pivot's own BFS (see
+// singleSourceBetweennessDenseCtx). On cancellation, whatever pivots
+// already completed are scaled by n/completed instead of n/SampleSize
+// and result.TimedOut is set - an approximate ranking from partial
+// sampling instead of no ranking at all.
+//
+// Returns a non-nil error only if ctx is already done before any work starts.
+func ApproxBetweennessCtx(ctx context.Context, g *simple.DirectedGraph, opts BetweennessOptions) (BetweennessResult, error) {
+	if err := ctx.Err(); err != nil {
+		return BetweennessResult{}, err
+	}
+
+	start := time.Now()
+	sampleSize := opts.SampleSize
+	if sampleSize < 1 {
+		sampleSize = 1
+	}
+
+	nodes := pooledNodesOf(g.Nodes())
+	defer putPooledNodes(nodes)
+	n := len(nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	result := BetweennessResult{
+		Scores:     make(map[int64]float64),
+		Mode:       BetweennessApproximate,
+		SampleSize: sampleSize,
+		TotalNodes: n,
+	}
+
+	if n == 0 {
+		result.Elapsed = time.Since(start)
+		return result, nil
+	}
+
+	if sampleSize >= n {
+		exact := network.Betweenness(g)
+		result.Scores = exact
+		result.Mode = BetweennessExact
+		result.SampleSize = n
+		result.Elapsed = time.Since(start)
+		return result, nil
+	}
+
+	idx := buildDenseIndex(nodes)
+	adj := buildCachedAdjacency(g, idx)
+	if idx.idToIdx != nil {
+		denseIndexMapPool.Put(idx.idToIdx)
+		idx.idToIdx = nil
+	}
+
+	var pivots []int
+	switch opts.strategy() {
+	case SamplingStructural:
+		pivots = sampleIndicesStructural(adj, sampleSize, opts.Seed, true)
+	case SamplingDegreeWeighted:
+		pivots = sampleIndicesDegreeWeighted(adj, sampleSize, opts.Seed)
+	default:
+		pivots = sampleIndices(n, sampleSize, opts.Seed)
+	}
+
+	depth := opts.pipelineDepth()
+	chunkSize := opts.chunkSize()
+	checkInterval := opts.CancelPolicy.bfsCheckInterval()
+
+	chunks := make(chan sourceChunk, depth)
+	go func() {
+		defer close(chunks)
+		for i := 0; i < len(pivots); i += chunkSize {
+			end := i + chunkSize
+			if end > len(pivots) {
+				end = len(pivots)
+			}
+			select {
+			case chunks <- sourceChunk{pivots: pivots[i:end]}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	merged := make(map[int64]float64, n)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	completed := 0
+	cancelled := false
+	for i := 0; i < depth; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			buf := brandesPool.get(n)
+			defer brandesPool.put(buf)
+
+			local := make(map[int64]float64)
+			localDone := 0
+			workerCancelled := false
+
+		chunkLoop:
+			for chunk := range chunks {
+				for _, sourceIdx := range chunk.pivots {
+					select {
+					case <-ctx.Done():
+						workerCancelled = true
+						break chunkLoop
+					default:
+					}
+					if !singleSourceBetweennessDenseCtx(ctx, adj, sourceIdx, buf, checkInterval) {
+						workerCancelled = true
+						break chunkLoop
+					}
+					for _, w := range buf.stack {
+						local[idx.idxToID[w]] += buf.bc[w]
+					}
+					localDone++
+				}
+			}
+
+			mu.Lock()
+			for id, v := range local {
+				merged[id] += v
+			}
+			completed += localDone
+			if workerCancelled {
+				cancelled = true
+			}
+			if opts.Progress != nil {
+				opts.Progress(completed, sampleSize)
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if cancelled || ctx.Err() != nil {
+		result.TimedOut = true
+	}
+	result.Annotations = append(result.Annotations, pivotApproximationAnnotation(sampleSize, n))
+
+	// Scale up from however many pivots actually completed, not from
+	// SampleSize: on cancellation that's the honest extrapolation base.
+	effective := completed
+	if effective < 1 {
+		effective = 1
+	}
+	scale := float64(n) / float64(effective)
+	scores := make(map[int64]float64, len(merged))
+	for id, val := range merged {
+		if val == 0 {
+			continue
+		}
+		scores[id] = val * scale
+	}
+	result.Scores = scores
+	result.Elapsed = time.Since(start)
+	if result.TimedOut {
+		result.Annotations = append(result.Annotations, Annotation{
+			Metric:   "betweenness",
+			Severity: SeverityWarning,
+			Code:     "betweenness.timeout",
+			Message:  "betweenness timed out before every pivot finished; scores are extrapolated from a partial sample",
+			Detail: map[string]any{
+				"elapsed":          result.Elapsed.String(),
+				"pivots_completed": completed,
+				"pivots_requested": sampleSize,
+			},
+		})
+	}
+	return result, nil
+}
+
 // sampleIndices returns a random sample of k indices from [0,n).
 // Uses Fisher-Yates shuffle for unbiased sampling.
 func sampleIndices(n, k int, seed int64) []int {
@@ -387,6 +862,211 @@ func sampleIndices(n, k int, seed int64) []int {
 	return shuffled[:k]
 }
 
+// structuralHeads returns the DAG's sinks (zero out-degree nodes) as the
+// starting frontier for sampleIndicesStructural, or - if the graph has
+// no sinks (e.g. it contains a cycle) - the top 5% of nodes by in-degree
+// instead, so the walk still has somewhere hub-adjacent to start from.
+func structuralHeads(adj cachedAdjacency) []int {
+	n := len(adj.outgoing)
+	var heads []int
+	for i := 0; i < n; i++ {
+		if len(adj.outgoing[i]) == 0 {
+			heads = append(heads, i)
+		}
+	}
+	if len(heads) > 0 {
+		return heads
+	}
+
+	type degreeNode struct {
+		idx    int
+		degree int
+	}
+	byInDegree := make([]degreeNode, n)
+	for i := range byInDegree {
+		byInDegree[i] = degreeNode{idx: i, degree: len(adj.incoming[i])}
+	}
+	sort.Slice(byInDegree, func(i, j int) bool { return byInDegree[i].degree > byInDegree[j].degree })
+
+	headCount := n / 20
+	if headCount < 1 {
+		headCount = 1
+	}
+	heads = make([]int, headCount)
+	for i := range heads {
+		heads[i] = byInDegree[i].idx
+	}
+	return heads
+}
+
+// sampleIndicesStructural samples k pivot indices biased toward
+// structurally distinctive nodes, using a BFS-frontier expansion
+// inspired by Mercurial's partial-discovery algorithm: starting from the
+// DAG's heads (see structuralHeads), it walks backward along incoming
+// edges in frontiers at exponentially increasing distances (1, 2, 4, 8,
+// ...), unioning a random subset of each of those frontiers into the
+// sample. This covers hub-like regions a uniform sample tends to
+// under-represent in graphs with a skewed degree distribution.
+//
+// When respectSize is true, sampling stops as soon as k indices are
+// chosen. Otherwise it keeps walking until the DAG's roots are reached,
+// then random-fills any remaining slots - first from nodes the walk
+// visited but didn't select, then from nodes it never reached at all
+// (only possible on a disconnected graph).
+func sampleIndicesStructural(adj cachedAdjacency, k int, seed int64, respectSize bool) []int {
+	n := len(adj.outgoing)
+	if k >= n {
+		idxs := make([]int, n)
+		for i := range idxs {
+			idxs[i] = i
+		}
+		return idxs
+	}
+	if k < 1 {
+		k = 1
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	selected := make(map[int]bool, k)
+
+	addRandomSubset := func(candidates []int) {
+		shuffled := append([]int(nil), candidates...)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		for _, idx := range shuffled {
+			if respectSize && len(selected) >= k {
+				return
+			}
+			selected[idx] = true
+		}
+	}
+
+	dist := make([]int, n)
+	for i := range dist {
+		dist[i] = -1
+	}
+
+	heads := structuralHeads(adj)
+	frontier := make([]int, 0, len(heads))
+	for _, h := range heads {
+		if dist[h] < 0 {
+			dist[h] = 0
+			frontier = append(frontier, h)
+		}
+	}
+	visited := append([]int(nil), frontier...)
+	addRandomSubset(frontier)
+
+	nextTargetDist := 1
+	for len(frontier) > 0 && (!respectSize || len(selected) < k) {
+		seen := make(map[int]bool)
+		var next []int
+		for _, v := range frontier {
+			for _, p := range adj.incoming[v] {
+				if dist[p] < 0 {
+					dist[p] = dist[v] + 1
+					if !seen[p] {
+						seen[p] = true
+						next = append(next, p)
+					}
+				}
+			}
+		}
+		if len(next) == 0 {
+			break
+		}
+		visited = append(visited, next...)
+
+		// Only sample at the exponential checkpoints (1, 2, 4, 8, ...);
+		// frontiers in between just extend BFS reach without
+		// contributing to the sample, keeping the bias toward a handful
+		// of well-separated distance bands instead of every hop.
+		if dist[next[0]] == nextTargetDist {
+			addRandomSubset(next)
+			nextTargetDist *= 2
+		}
+		frontier = next
+	}
+
+	if !respectSize || len(selected) < k {
+		fillRandomly(selected, visited, n, k, rng)
+	}
+
+	result := make([]int, 0, k)
+	for idx := range selected {
+		result = append(result, idx)
+	}
+	sort.Ints(result)
+	if len(result) > k {
+		result = result[:k]
+	}
+	return result
+}
+
+// fillRandomly tops selected up to k entries, preferring nodes the walk
+// already visited (but didn't select) over nodes it never reached.
+func fillRandomly(selected map[int]bool, visited []int, n, k int, rng *rand.Rand) {
+	visitedSet := make(map[int]bool, len(visited))
+	candidates := make([]int, 0, len(visited))
+	for _, v := range visited {
+		visitedSet[v] = true
+		if !selected[v] {
+			candidates = append(candidates, v)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if !visitedSet[i] && !selected[i] {
+			candidates = append(candidates, i)
+		}
+	}
+
+	rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	for _, c := range candidates {
+		if len(selected) >= k {
+			return
+		}
+		selected[c] = true
+	}
+}
+
+// sampleIndicesDegreeWeighted samples k pivot indices without
+// replacement, with probability proportional to each node's total
+// degree (in + out), via Efraimidis-Spirakis weighted reservoir
+// sampling: every node gets a key of u^(1/weight) for a fresh uniform
+// random u, and the k largest keys win.
+func sampleIndicesDegreeWeighted(adj cachedAdjacency, k int, seed int64) []int {
+	n := len(adj.outgoing)
+	if k >= n {
+		idxs := make([]int, n)
+		for i := range idxs {
+			idxs[i] = i
+		}
+		return idxs
+	}
+	if k < 1 {
+		k = 1
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	type weightedIdx struct {
+		idx int
+		key float64
+	}
+	keys := make([]weightedIdx, n)
+	for i := 0; i < n; i++ {
+		weight := float64(len(adj.outgoing[i])+len(adj.incoming[i])) + 1 // +1 so isolated nodes still have a nonzero chance
+		u := rng.Float64()
+		keys[i] = weightedIdx{idx: i, key: math.Pow(u, 1/weight)}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key > keys[j].key })
+
+	result := make([]int, k)
+	for i := 0; i < k; i++ {
+		result[i] = keys[i].idx
+	}
+	sort.Ints(result)
+	return result
+}
+
 // singleSourceBetweennessDense computes the betweenness contribution from a single source index.
 // This is the core of Brandes' algorithm, run once per pivot, using dense indexing.
 //
@@ -452,6 +1132,77 @@ func singleSourceBetweennessDense(adj cachedAdjacency, sourceIdx int, buf *brand
 	}
 }
 
+// singleSourceBetweennessDenseCtx is singleSourceBetweennessDense's
+// cancellable counterpart for ApproxBetweennessCtx. It's identical
+// except the BFS phase checks ctx.Done() every checkInterval queue
+// pops - a raw channel select on every pop would cost more than the BFS
+// step itself - and bails out immediately if cancelled, returning false
+// without running the accumulation phase. The caller must then discard
+// buf's contribution for this pivot: it reflects whatever fraction of
+// the BFS ran, not the source's true betweenness contribution.
+func singleSourceBetweennessDenseCtx(ctx context.Context, adj cachedAdjacency, sourceIdx int, buf *brandesBuffers, checkInterval int) bool {
+	nodeCount := len(adj.outgoing)
+	if nodeCount == 0 {
+		return true
+	}
+
+	buf.reset(nodeCount)
+
+	sigma := buf.sigma
+	dist := buf.dist
+	delta := buf.delta
+	pred := buf.pred
+
+	sigma[sourceIdx] = 1
+	dist[sourceIdx] = 0
+
+	buf.queue = append(buf.queue, sourceIdx)
+
+	pops := 0
+	for len(buf.queue) > 0 {
+		pops++
+		if checkInterval > 0 && pops%checkInterval == 0 {
+			select {
+			case <-ctx.Done():
+				return false
+			default:
+			}
+		}
+
+		v := buf.queue[0]
+		buf.queue = buf.queue[1:]
+		buf.stack = append(buf.stack, v)
+
+		for _, w := range adj.outgoing[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				buf.queue = append(buf.queue, w)
+			}
+
+			if dist[w] == dist[v]+1 {
+				sigma[w] += sigma[v]
+				pred[w] = append(pred[w], v)
+			}
+		}
+	}
+
+	for i := len(buf.stack) - 1; i >= 0; i-- {
+		w := buf.stack[i]
+		if w == sourceIdx {
+			continue
+		}
+
+		for _, v := range pred[w] {
+			if sigma[w] > 0 {
+				delta[v] += (sigma[v] / sigma[w]) * (1 + delta[w])
+			}
+		}
+
+		buf.bc[w] += delta[w]
+	}
+	return true
+}
+
 // RecommendSampleSize returns a recommended sample size based on graph characteristics.
 // The goal is to balance accuracy vs. speed.
 //
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestApproxBetweennessCtxMatchesWithOptionsWhenUncancelled(t *testing.T) {
+	g := chainGraph(20)
+	plain := ApproxBetweennessWithOptions(g, 8, 42, BetweennessOptions{})
+
+	got, err := ApproxBetweennessCtx(context.Background(), g, BetweennessOptions{SampleSize: 8, Seed: 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.TimedOut {
+		t.Errorf("expected TimedOut false for an uncancelled context, got true")
+	}
+	if len(got.Scores) != len(plain.Scores) {
+		t.Fatalf("expected same score count, got %d vs %d", len(got.Scores), len(plain.Scores))
+	}
+	for id, score := range plain.Scores {
+		if got.Scores[id] != score {
+			t.Errorf("node %d: expected score %v, got %v", id, score, got.Scores[id])
+		}
+	}
+}
+
+func TestApproxBetweennessCtxReturnsErrorForAlreadyCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g := chainGraph(10)
+	_, err := ApproxBetweennessCtx(ctx, g, BetweennessOptions{SampleSize: 4})
+	if err == nil {
+		t.Fatal("expected an error for an already-cancelled context")
+	}
+}
+
+func TestApproxBetweennessCtxReportsProgress(t *testing.T) {
+	g := chainGraph(30)
+
+	var calls [][2]int
+	_, err := ApproxBetweennessCtx(context.Background(), g, BetweennessOptions{
+		SampleSize: 12,
+		Seed:       7,
+		Progress: func(done, total int) {
+			calls = append(calls, [2]int{done, total})
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calls) == 0 {
+		t.Fatal("expected at least one progress callback")
+	}
+	last := calls[len(calls)-1]
+	if last[0] != 12 || last[1] != 12 {
+		t.Errorf("expected final progress call to report 12/12, got %v", last)
+	}
+}
+
+func TestApproxBetweennessCtxTimesOutOnDeadline(t *testing.T) {
+	adj := benchScaleFreeGraph(20_000)
+	g := simple.NewDirectedGraph()
+	for v := range adj.outgoing {
+		g.AddNode(simple.Node(v))
+	}
+	for v, neighbors := range adj.outgoing {
+		for _, w := range neighbors {
+			g.SetEdge(simple.Edge{F: simple.Node(v), T: simple.Node(w)})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	result, err := ApproxBetweennessCtx(ctx, g, BetweennessOptions{
+		SampleSize:   len(adj.outgoing) / 2,
+		Seed:         1,
+		CancelPolicy: BetweennessCancelPolicy{BFSCheckInterval: 64},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.TimedOut {
+		t.Error("expected a 1ms deadline against a 20k-node graph to time out")
+	}
+}
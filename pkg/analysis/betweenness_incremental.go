@@ -0,0 +1,451 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// incrementalInvalidateFraction is the fraction of anchor pivots a
+// single edit can invalidate before IncrementalBetweenness gives up on
+// repairing them individually and falls back to rebuildAll: past this
+// point, rerunning every invalidated pivot's BFS costs about as much as
+// recomputing all of them, without the bookkeeping IncrementalBetweenness
+// exists to avoid.
+const incrementalInvalidateFraction = 0.4
+
+// IncrementalBetweenness maintains approximate betweenness centrality
+// across a sequence of graph edits - AddEdge, RemoveEdge, AddNode,
+// RemoveNode - the way a beads graph actually changes: one issue or
+// dependency at a time. Recomputing ApproxBetweenness from scratch after
+// every such edit throws away every pivot's BFS tree even though most of
+// them are untouched by a single-edge change.
+//
+// Instead, IncrementalBetweenness keeps each anchor pivot's last BFS
+// tree (dist/sigma/pred/stack, the same quantities
+// singleSourceBetweennessDense computes) and, on each edit, only reruns
+// the pivots whose tree the edit could have changed - detected from
+// dist[u]+1 == dist[v], i.e. the edited edge lies on (or would lie on)
+// that pivot's shortest-path DAG. Pivots outside that set keep their
+// cached contribution untouched. If too large a fraction of pivots are
+// invalidated at once, it falls back to a full rebuildAll rather than
+// pay rerun costs with none of the savings.
+//
+// Node identity is tracked by int64 ID via idToIdx/idxToID rather than
+// the sorted-position dense index buildDenseIndex derives from a node
+// list snapshot: AddNode appends a new index and RemoveNode tombstones
+// its slot (clearing its adjacency, leaving the slot unused) instead of
+// compacting and renumbering every larger index, which would invalidate
+// every pivot's BFS state on every removal.
+//
+// Known limitation: invalidation via dist[u]+1 == dist[v] catches an
+// edit that changes a pivot's shortest-path counts (sigma) without
+// necessarily changing any distances, and catches distance changes
+// where one endpoint was previously unreachable. It does not detect the
+// rarer case of a new edge creating a strictly shorter path through a
+// node the edit doesn't otherwise touch (du+1 < dv) without landing
+// exactly on an existing dist boundary; Snapshot's scores then carry the
+// same kind of sampling error ApproxBetweenness already tolerates until
+// the next full rebuild.
+type IncrementalBetweenness struct {
+	mu sync.Mutex
+
+	idToIdx    map[int64]int
+	idxToID    []int64
+	tombstoned []bool
+
+	adj cachedAdjacency
+
+	pivots []int // anchor pivot dense indices
+	seed   int64
+	state  []pivotState // parallel to pivots
+
+	partialBC []float64 // summed contribution across all valid pivots, dense-indexed
+}
+
+// pivotState is one anchor pivot's last-computed BFS tree plus its
+// contribution to partialBC, dense-indexed exactly like brandesBuffers -
+// except, unlike brandesBuffers, these slices are owned by one pivot for
+// as long as it stays valid rather than pooled and reused across pivots.
+type pivotState struct {
+	valid bool
+	dist  []int
+	sigma []float64
+	pred  [][]int
+	stack []int
+	bc    []float64
+}
+
+// computePivotState runs a full single-source BFS and Brandes
+// accumulation from sourceIdx over adj, the same computation
+// singleSourceBetweennessDense performs against pooled buffers, but
+// returns owned slices: IncrementalBetweenness keeps a pivot's tree
+// around between edits instead of returning it to a pool once the
+// caller reads off the contribution.
+func computePivotState(adj cachedAdjacency, sourceIdx int) pivotState {
+	n := len(adj.outgoing)
+	dist := make([]int, n)
+	sigma := make([]float64, n)
+	delta := make([]float64, n)
+	pred := make([][]int, n)
+	for i := range dist {
+		dist[i] = -1
+	}
+	sigma[sourceIdx] = 1
+	dist[sourceIdx] = 0
+
+	queue := []int{sourceIdx}
+	stack := make([]int, 0, n)
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		stack = append(stack, v)
+
+		for _, w := range adj.outgoing[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
+			}
+			if dist[w] == dist[v]+1 {
+				sigma[w] += sigma[v]
+				pred[w] = append(pred[w], v)
+			}
+		}
+	}
+
+	bc := make([]float64, n)
+	for i := len(stack) - 1; i >= 0; i-- {
+		w := stack[i]
+		if w == sourceIdx {
+			continue
+		}
+		for _, v := range pred[w] {
+			if sigma[w] > 0 {
+				delta[v] += (sigma[v] / sigma[w]) * (1 + delta[w])
+			}
+		}
+		bc[w] += delta[w]
+	}
+
+	return pivotState{valid: true, dist: dist, sigma: sigma, pred: pred, stack: stack, bc: bc}
+}
+
+// NewIncrementalBetweenness builds an IncrementalBetweenness over g,
+// sampling sampleSize anchor pivots uniformly with seed exactly like
+// ApproxBetweenness's default strategy.
+func NewIncrementalBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) *IncrementalBetweenness {
+	nodes := pooledNodesOf(g.Nodes())
+	defer putPooledNodes(nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	n := len(nodes)
+	idToIdx := make(map[int64]int, n)
+	idxToID := make([]int64, n)
+	for i, nd := range nodes {
+		idxToID[i] = nd.ID()
+		idToIdx[nd.ID()] = i
+	}
+
+	adj := buildCachedAdjacency(g, denseIndex{idToIdx: idToIdx, idxToID: idxToID})
+
+	if sampleSize < 1 {
+		sampleSize = 1
+	}
+	if sampleSize > n {
+		sampleSize = n
+	}
+
+	ib := &IncrementalBetweenness{
+		idToIdx:    idToIdx,
+		idxToID:    idxToID,
+		tombstoned: make([]bool, n),
+		adj:        adj,
+		seed:       seed,
+	}
+
+	if n > 0 {
+		ib.pivots = sampleIndices(n, sampleSize, seed)
+		ib.rebuildAll()
+	}
+	return ib
+}
+
+// rebuildAll recomputes every anchor pivot's BFS tree from scratch and
+// rebuilds partialBC from them. Called on construction and whenever an
+// edit invalidates too large a fraction of pivots to repair individually.
+func (ib *IncrementalBetweenness) rebuildAll() {
+	ib.state = make([]pivotState, len(ib.pivots))
+	ib.partialBC = make([]float64, len(ib.idxToID))
+	for i, p := range ib.pivots {
+		st := computePivotState(ib.adj, p)
+		ib.state[i] = st
+		for w, v := range st.bc {
+			ib.partialBC[w] += v
+		}
+	}
+}
+
+// AddNode registers a brand-new, edgeless node under id. Since a node
+// with no edges can't be on any existing pivot's shortest-path tree, no
+// pivot needs invalidating.
+func (ib *IncrementalBetweenness) AddNode(id int64) error {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	if _, exists := ib.idToIdx[id]; exists {
+		return fmt.Errorf("analysis: node %d already present", id)
+	}
+	ib.invalidateCentralityCache()
+
+	idx := len(ib.idxToID)
+	ib.idToIdx[id] = idx
+	ib.idxToID = append(ib.idxToID, id)
+	ib.tombstoned = append(ib.tombstoned, false)
+	ib.adj.outgoing = append(ib.adj.outgoing, nil)
+	ib.adj.incoming = append(ib.adj.incoming, nil)
+	ib.partialBC = append(ib.partialBC, 0)
+
+	for i := range ib.state {
+		ib.state[i].dist = append(ib.state[i].dist, -1)
+		ib.state[i].sigma = append(ib.state[i].sigma, 0)
+		ib.state[i].pred = append(ib.state[i].pred, nil)
+		ib.state[i].bc = append(ib.state[i].bc, 0)
+	}
+	return nil
+}
+
+// RemoveNode removes id, first removing each of its incident edges
+// (invalidating pivots exactly as RemoveEdge would) before tombstoning
+// its slot. If id was itself an anchor pivot, that pivot is dropped
+// rather than replaced.
+func (ib *IncrementalBetweenness) RemoveNode(id int64) error {
+	ib.mu.Lock()
+	idx, ok := ib.idToIdx[id]
+	if !ok || ib.tombstoned[idx] {
+		ib.mu.Unlock()
+		return fmt.Errorf("analysis: unknown node %d", id)
+	}
+	ib.invalidateCentralityCache() // covers the edgeless case; RemoveEdge below covers each incident edge
+
+	type incidentEdge struct{ from, to int64 }
+	edges := make([]incidentEdge, 0, len(ib.adj.outgoing[idx])+len(ib.adj.incoming[idx]))
+	for _, w := range ib.adj.outgoing[idx] {
+		edges = append(edges, incidentEdge{from: id, to: ib.idxToID[w]})
+	}
+	for _, v := range ib.adj.incoming[idx] {
+		edges = append(edges, incidentEdge{from: ib.idxToID[v], to: id})
+	}
+	ib.mu.Unlock()
+
+	for _, e := range edges {
+		if err := ib.RemoveEdge(e.from, e.to); err != nil {
+			return err
+		}
+	}
+
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+	ib.tombstoned[idx] = true
+	ib.partialBC[idx] = 0
+	for i := 0; i < len(ib.pivots); {
+		if ib.pivots[i] == idx {
+			ib.pivots = append(ib.pivots[:i], ib.pivots[i+1:]...)
+			ib.state = append(ib.state[:i], ib.state[i+1:]...)
+			continue
+		}
+		i++
+	}
+	return nil
+}
+
+// AddEdge adds the directed edge u->v, re-running singleSourceBetweennessDense-
+// equivalent BFS only for the anchor pivots AddEdge's invalidation check
+// selects, and falling back to a full rebuildAll if too many pivots are
+// affected at once (see incrementalInvalidateFraction).
+func (ib *IncrementalBetweenness) AddEdge(u, v int64) error {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	uIdx, vIdx, err := ib.resolvePair(u, v)
+	if err != nil {
+		return err
+	}
+	ib.invalidateCentralityCache()
+
+	ib.adj.outgoing[uIdx] = insertSorted(ib.adj.outgoing[uIdx], vIdx)
+	ib.adj.incoming[vIdx] = insertSorted(ib.adj.incoming[vIdx], uIdx)
+
+	ib.invalidateAffected(uIdx, vIdx)
+	return nil
+}
+
+// RemoveEdge removes the directed edge u->v, invalidating and
+// recomputing anchor pivots the same way AddEdge does.
+func (ib *IncrementalBetweenness) RemoveEdge(u, v int64) error {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	uIdx, vIdx, err := ib.resolvePair(u, v)
+	if err != nil {
+		return err
+	}
+	ib.invalidateCentralityCache()
+
+	ib.adj.outgoing[uIdx] = removeSorted(ib.adj.outgoing[uIdx], vIdx)
+	ib.adj.incoming[vIdx] = removeSorted(ib.adj.incoming[vIdx], uIdx)
+
+	ib.invalidateAffected(uIdx, vIdx)
+	return nil
+}
+
+func (ib *IncrementalBetweenness) resolvePair(u, v int64) (uIdx, vIdx int, err error) {
+	uIdx, ok := ib.idToIdx[u]
+	if !ok || ib.tombstoned[uIdx] {
+		return 0, 0, fmt.Errorf("analysis: unknown node %d", u)
+	}
+	vIdx, ok = ib.idToIdx[v]
+	if !ok || ib.tombstoned[vIdx] {
+		return 0, 0, fmt.Errorf("analysis: unknown node %d", v)
+	}
+	return uIdx, vIdx, nil
+}
+
+// graphHash fingerprints ib's current live (non-tombstoned) node/edge
+// set in the same canonical form FingerprintGraph produces for a
+// *simple.DirectedGraph, so invalidateCentralityCache can evict the
+// CentralityCache entries ApproxBetweenness would have filed this graph
+// under - IncrementalBetweenness doesn't keep its own reference to the
+// original graph, so it fingerprints its index-mapped adjacency instead.
+func (ib *IncrementalBetweenness) graphHash() [32]byte {
+	var edges []fingerprintEdge
+	for uIdx, outs := range ib.adj.outgoing {
+		if ib.tombstoned[uIdx] {
+			continue
+		}
+		for _, vIdx := range outs {
+			edges = append(edges, fingerprintEdge{src: ib.idxToID[uIdx], dst: ib.idxToID[vIdx]})
+		}
+	}
+	return fingerprintEdges(edges)
+}
+
+// invalidateCentralityCache evicts defaultCentralityCache's entries for
+// ib's current graph state, called before each edit takes effect so the
+// entries it drops are the ones a prior ApproxBetweenness call on this
+// same graph would have filed under - a graph-mutation event, same as
+// the watcher callback InvalidateAsync's doc comment describes, just
+// sourced from IncrementalBetweenness's own edits instead of an external
+// file watcher. A no-op if no cache has been installed via
+// SetDefaultCentralityCache.
+func (ib *IncrementalBetweenness) invalidateCentralityCache() {
+	if defaultCentralityCache == nil {
+		return
+	}
+	defaultCentralityCache.InvalidateAsync(ib.graphHash())
+}
+
+// invalidateAffected marks every anchor pivot pivotAffectedByEdge
+// selects for the u->v edit, subtracts each one's stale contribution
+// from partialBC, reruns its BFS, and adds back the fresh contribution -
+// or, once too many pivots qualify, gives up and calls rebuildAll.
+func (ib *IncrementalBetweenness) invalidateAffected(uIdx, vIdx int) {
+	var affected []int
+	for i, st := range ib.state {
+		if st.valid && pivotAffectedByEdge(st, uIdx, vIdx) {
+			affected = append(affected, i)
+		}
+	}
+
+	if len(ib.pivots) > 0 && float64(len(affected))/float64(len(ib.pivots)) > incrementalInvalidateFraction {
+		ib.rebuildAll()
+		return
+	}
+
+	for _, i := range affected {
+		old := ib.state[i]
+		for w, val := range old.bc {
+			ib.partialBC[w] -= val
+		}
+		st := computePivotState(ib.adj, ib.pivots[i])
+		ib.state[i] = st
+		for w, val := range st.bc {
+			ib.partialBC[w] += val
+		}
+	}
+}
+
+// pivotAffectedByEdge reports whether pivot's cached dist says the u->v
+// edit could change its shortest-path DAG: either one endpoint's
+// reachability from pivot could change, or dist[u]+1 == dist[v] - the
+// edge lies on (add) or lay on (remove) a shortest path from pivot to v,
+// which changes sigma/pred bookkeeping even when dist itself doesn't move.
+func pivotAffectedByEdge(st pivotState, uIdx, vIdx int) bool {
+	du, dv := st.dist[uIdx], st.dist[vIdx]
+	if du < 0 && dv < 0 {
+		return false
+	}
+	if du < 0 || dv < 0 {
+		return true
+	}
+	return du+1 == dv
+}
+
+// insertSorted inserts v into the ascending sorted slice s if not
+// already present, preserving order - the same invariant
+// buildCachedAdjacency's sort.Ints establishes for outgoing/incoming.
+func insertSorted(s []int, v int) []int {
+	i := sort.SearchInts(s, v)
+	if i < len(s) && s[i] == v {
+		return s
+	}
+	s = append(s, 0)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// removeSorted removes v from the ascending sorted slice s if present.
+func removeSorted(s []int, v int) []int {
+	i := sort.SearchInts(s, v)
+	if i >= len(s) || s[i] != v {
+		return s
+	}
+	return append(s[:i], s[i+1:]...)
+}
+
+// Snapshot returns the current approximate betweenness scores, scaled by
+// n/k exactly like ApproxBetweennessWithOptions's result: n the number
+// of live (non-tombstoned) nodes, k the number of anchor pivots.
+func (ib *IncrementalBetweenness) Snapshot() BetweennessResult {
+	ib.mu.Lock()
+	defer ib.mu.Unlock()
+
+	n := 0
+	for _, tombstoned := range ib.tombstoned {
+		if !tombstoned {
+			n++
+		}
+	}
+	k := len(ib.pivots)
+
+	result := BetweennessResult{
+		Scores:     make(map[int64]float64),
+		Mode:       BetweennessApproximate,
+		SampleSize: k,
+		TotalNodes: n,
+	}
+	if k == 0 || n == 0 {
+		return result
+	}
+
+	scale := float64(n) / float64(k)
+	for idx, val := range ib.partialBC {
+		if val == 0 || ib.tombstoned[idx] {
+			continue
+		}
+		result.Scores[ib.idxToID[idx]] = val * scale
+	}
+	return result
+}
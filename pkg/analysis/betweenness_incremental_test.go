@@ -0,0 +1,210 @@
+package analysis
+
+import (
+	"sync"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// hubGraph returns a directed star: hub -> 0, hub -> 1, ..., hub -> (n-1).
+func hubGraph(hub int64, n int) *simple.DirectedGraph {
+	g := simple.NewDirectedGraph()
+	g.AddNode(simple.Node(hub))
+	for i := 0; i < n; i++ {
+		if int64(i) == hub {
+			continue
+		}
+		g.AddNode(simple.Node(i))
+		g.SetEdge(simple.Edge{F: simple.Node(hub), T: simple.Node(i)})
+	}
+	return g
+}
+
+func TestIncrementalBetweennessMatchesApproxBetweennessOnConstruction(t *testing.T) {
+	g := chainGraph(8)
+
+	ib := NewIncrementalBetweenness(g, 8, 1)
+	snap := ib.Snapshot()
+	want := ApproxBetweenness(g, 8, 1)
+
+	if len(snap.Scores) != len(want.Scores) {
+		t.Fatalf("expected %d scores, got %d", len(want.Scores), len(snap.Scores))
+	}
+	for id, v := range want.Scores {
+		if got := snap.Scores[id]; got != v {
+			t.Errorf("node %d: expected %v, got %v", id, v, got)
+		}
+	}
+}
+
+func TestIncrementalBetweennessAddEdgeUpdatesAffectedPivots(t *testing.T) {
+	g := chainGraph(6) // 0->1->2->3->4->5
+
+	ib := NewIncrementalBetweenness(g, 6, 1) // sample every node as an anchor pivot
+	before := ib.Snapshot()
+
+	// Add a shortcut 0->3: pivots 1, 2 and 3 reach 3 but not 0, so
+	// pivotAffectedByEdge flags them; 3 of 6 exceeds
+	// incrementalInvalidateFraction, so this edit takes the rebuildAll path.
+	if err := ib.AddEdge(0, 3); err != nil {
+		t.Fatalf("AddEdge: %v", err)
+	}
+	after := ib.Snapshot()
+
+	if after.Scores[0] == before.Scores[0] && after.Scores[1] == before.Scores[1] {
+		t.Error("expected AddEdge to change at least one affected pivot's contribution")
+	}
+
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(3)})
+	want := ApproxBetweenness(g, 6, 1)
+	for id, v := range want.Scores {
+		if got := after.Scores[id]; got != v {
+			t.Errorf("node %d: expected %v after incremental AddEdge, got %v", id, v, got)
+		}
+	}
+}
+
+func TestIncrementalBetweennessRemoveEdgeMatchesFreshComputation(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for i := 0; i < 5; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(1)})
+	g.SetEdge(simple.Edge{F: simple.Node(1), T: simple.Node(2)})
+	g.SetEdge(simple.Edge{F: simple.Node(2), T: simple.Node(3)})
+	g.SetEdge(simple.Edge{F: simple.Node(3), T: simple.Node(4)})
+	g.SetEdge(simple.Edge{F: simple.Node(0), T: simple.Node(4)}) // shortcut
+
+	ib := NewIncrementalBetweenness(g, 5, 7)
+	if err := ib.RemoveEdge(0, 4); err != nil {
+		t.Fatalf("RemoveEdge: %v", err)
+	}
+	got := ib.Snapshot()
+
+	g.RemoveEdge(0, 4)
+	want := ApproxBetweenness(g, 5, 7)
+
+	if len(got.Scores) != len(want.Scores) {
+		t.Fatalf("expected %d scores, got %d", len(want.Scores), len(got.Scores))
+	}
+	for id, v := range want.Scores {
+		if gotVal := got.Scores[id]; gotVal != v {
+			t.Errorf("node %d: expected %v, got %v", id, v, gotVal)
+		}
+	}
+}
+
+func TestIncrementalBetweennessAddNodeIsEdgeless(t *testing.T) {
+	g := chainGraph(4)
+	ib := NewIncrementalBetweenness(g, 4, 3)
+	before := ib.Snapshot()
+
+	if err := ib.AddNode(100); err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+	after := ib.Snapshot()
+
+	// Adding an edgeless node touches no pivot's BFS tree, so every
+	// existing node's raw contribution is unchanged; Snapshot's n/k
+	// scale grows with the new live-node count (n), so compare ratios
+	// rather than raw scores.
+	scale := float64(after.TotalNodes) / float64(before.TotalNodes)
+	for id, v := range before.Scores {
+		want := v * scale
+		if got := after.Scores[id]; got != want {
+			t.Errorf("node %d: expected rescaled score %v after AddNode, got %v", id, want, got)
+		}
+	}
+	if _, ok := after.Scores[100]; ok {
+		t.Error("expected the freshly added edgeless node to have no betweenness contribution")
+	}
+}
+
+func TestIncrementalBetweennessRemoveNodeDropsIncidentEdges(t *testing.T) {
+	g := hubGraph(0, 5)
+	ib := NewIncrementalBetweenness(g, 5, 2)
+
+	if err := ib.RemoveNode(0); err != nil {
+		t.Fatalf("RemoveNode: %v", err)
+	}
+	got := ib.Snapshot()
+
+	if _, ok := got.Scores[0]; ok {
+		t.Error("expected removed node to have no score in the snapshot")
+	}
+	for id, v := range got.Scores {
+		if v != 0 {
+			t.Errorf("expected no remaining betweenness after the hub is removed, node %d has %v", id, v)
+		}
+	}
+}
+
+func TestIncrementalBetweennessAddEdgeUnknownNodeErrors(t *testing.T) {
+	g := chainGraph(3)
+	ib := NewIncrementalBetweenness(g, 3, 1)
+
+	if err := ib.AddEdge(0, 99); err == nil {
+		t.Error("expected an error for an edge referencing an unknown node")
+	}
+}
+
+// fakeCentralityCache is a CentralityCache that records every
+// Invalidate/InvalidateAsync call instead of touching disk, so tests can
+// assert on which graph hashes a mutation evicted without racing a real
+// background goroutine.
+type fakeCentralityCache struct {
+	mu          sync.Mutex
+	invalidated [][32]byte
+}
+
+func (f *fakeCentralityCache) Get(CentralityFingerprint) (CentralityResult, bool) {
+	return CentralityResult{}, false
+}
+func (f *fakeCentralityCache) Put(CentralityFingerprint, CentralityResult) error { return nil }
+func (f *fakeCentralityCache) Invalidate(graphHash [32]byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.invalidated = append(f.invalidated, graphHash)
+	return nil
+}
+func (f *fakeCentralityCache) InvalidateAsync(graphHash [32]byte) { _ = f.Invalidate(graphHash) }
+func (f *fakeCentralityCache) Close() error                       { return nil }
+
+func TestIncrementalBetweennessEditsInvalidateDefaultCentralityCache(t *testing.T) {
+	g := chainGraph(6)
+	fake := &fakeCentralityCache{}
+	SetDefaultCentralityCache(fake)
+	t.Cleanup(func() { SetDefaultCentralityCache(nil) })
+
+	ib := NewIncrementalBetweenness(g, 6, 1)
+
+	beforeAddEdge := ib.graphHash()
+	if err := ib.AddEdge(0, 5); err != nil {
+		t.Fatalf("AddEdge: %v", err)
+	}
+	beforeAddNode := ib.graphHash()
+	if err := ib.AddNode(100); err != nil {
+		t.Fatalf("AddNode: %v", err)
+	}
+	beforeRemoveEdge := ib.graphHash()
+	if err := ib.RemoveEdge(0, 5); err != nil {
+		t.Fatalf("RemoveEdge: %v", err)
+	}
+	beforeRemoveNode := ib.graphHash()
+	if err := ib.RemoveNode(100); err != nil {
+		t.Fatalf("RemoveNode: %v", err)
+	}
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	want := [][32]byte{beforeAddEdge, beforeAddNode, beforeRemoveEdge, beforeRemoveNode}
+	if len(fake.invalidated) != len(want) {
+		t.Fatalf("expected %d cache invalidations, got %d", len(want), len(fake.invalidated))
+	}
+	for i, hash := range want {
+		if fake.invalidated[i] != hash {
+			t.Errorf("edit %d: expected invalidation of the pre-edit graph hash %x, got %x", i, hash, fake.invalidated[i])
+		}
+	}
+}
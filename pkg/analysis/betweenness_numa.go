@@ -0,0 +1,162 @@
+package analysis
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"gonum.org/v1/gonum/graph/network"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// numaDomainPool is a brandesPool scoped to a single NUMA domain. A
+// worker pinned to that domain only Gets/Puts its own pool's buffers,
+// so a buffer's backing arrays stay resident on memory local to the
+// CPUs it runs on instead of bouncing between sockets the way a single
+// shared brandesPool would under cross-domain scheduling.
+type numaDomainPool struct {
+	pool sync.Pool
+}
+
+func newNUMADomainPool() *numaDomainPool {
+	return &numaDomainPool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return &brandesBuffers{
+					sigma:     make([]float64, 0, 256),
+					dist:      make([]int, 0, 256),
+					delta:     make([]float64, 0, 256),
+					pred:      make([][]int, 0, 256),
+					queue:     make([]int, 0, 256),
+					stack:     make([]int, 0, 256),
+					neighbors: make([]int, 0, 32),
+					bc:        make([]float64, 0, 256),
+				}
+			},
+		},
+	}
+}
+
+func (p *numaDomainPool) get() *brandesBuffers {
+	return p.pool.Get().(*brandesBuffers)
+}
+
+func (p *numaDomainPool) put(b *brandesBuffers) {
+	p.pool.Put(b)
+}
+
+// ApproxBetweennessNUMA is ApproxBetweenness's NUMA-aware counterpart.
+// With cfg.Enabled and at least two domains, it partitions the sampled
+// pivots round-robin across cfg.DomainCPUs, runs one pinned worker
+// goroutine per domain pulling from a domain-local brandesPool, and
+// sums each domain's partial centrality accumulator at the end. With
+// fewer than two domains - including the zero-value NUMAConfig
+// DetectNUMATopology returns on hosts without NUMA - it's equivalent
+// to calling ApproxBetweenness directly, so callers can pass whatever
+// DetectNUMATopology() returns unconditionally.
+func ApproxBetweennessNUMA(g *simple.DirectedGraph, sampleSize int, seed int64, cfg NUMAConfig) BetweennessResult {
+	if cfg.numaDomainCount() < 2 {
+		return ApproxBetweenness(g, sampleSize, seed)
+	}
+
+	start := time.Now()
+	nodes := pooledNodesOf(g.Nodes())
+	defer putPooledNodes(nodes)
+	n := len(nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	if sampleSize < 1 {
+		sampleSize = 1
+	}
+
+	result := BetweennessResult{
+		Scores:     make(map[int64]float64),
+		Mode:       BetweennessApproximate,
+		SampleSize: sampleSize,
+		TotalNodes: n,
+	}
+
+	if n == 0 {
+		result.Elapsed = time.Since(start)
+		return result
+	}
+
+	if sampleSize >= n {
+		exact := network.Betweenness(g)
+		result.Scores = exact
+		result.Mode = BetweennessExact
+		result.SampleSize = n
+		result.Elapsed = time.Since(start)
+		return result
+	}
+
+	idx := buildDenseIndex(nodes)
+	adj := buildCachedAdjacency(g, idx)
+	if idx.idToIdx != nil {
+		denseIndexMapPool.Put(idx.idToIdx)
+		idx.idToIdx = nil
+	}
+
+	pivots := sampleIndices(n, sampleSize, seed)
+	domains := cfg.DomainCPUs
+	domainPivots := make([][]int, len(domains))
+	for i, p := range pivots {
+		d := i % len(domains)
+		domainPivots[d] = append(domainPivots[d], p)
+	}
+
+	partialBC := make([][]float64, len(domains))
+	var wg sync.WaitGroup
+	for d := range domains {
+		if len(domainPivots[d]) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go numaWorker(&wg, domains[d], domainPivots[d], adj, n, &partialBC[d])
+	}
+	wg.Wait()
+
+	merged := make([]float64, n)
+	for _, domainBC := range partialBC {
+		for i, v := range domainBC {
+			merged[i] += v
+		}
+	}
+
+	scale := float64(n) / float64(sampleSize)
+	scores := make(map[int64]float64, n)
+	for i, val := range merged {
+		if val == 0 {
+			continue
+		}
+		scores[idx.idxToID[i]] = val * scale
+	}
+	result.Scores = scores
+	result.Elapsed = time.Since(start)
+	return result
+}
+
+// numaWorker runs every pivot in pivots against adj, on a goroutine
+// locked to an OS thread pinned to cpus, pulling buffers from a pool
+// scoped to this call so they stay resident on cpus' local memory for
+// the whole batch. *out receives this domain's partial centrality
+// contribution, indexed like adj's dense indices.
+func numaWorker(wg *sync.WaitGroup, cpus []int, pivots []int, adj cachedAdjacency, n int, out *[]float64) {
+	defer wg.Done()
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	_ = pinCurrentThread(cpus) // best-effort: an unpinnable thread still computes correctly, just without the locality win
+
+	pool := newNUMADomainPool()
+	partial := make([]float64, n)
+	for _, sourceIdx := range pivots {
+		buf := pool.get()
+		singleSourceBetweennessDense(adj, sourceIdx, buf)
+		for _, w := range buf.stack {
+			partial[w] += buf.bc[w]
+		}
+		pool.put(buf)
+	}
+	*out = partial
+}
@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// chainGraph builds a directed chain 0->1->...->n-1, small enough that
+// exact betweenness is cheap to compare against.
+func chainGraph(n int) *simple.DirectedGraph {
+	g := simple.NewDirectedGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	for i := 0; i < n-1; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+	}
+	return g
+}
+
+func TestApproxBetweennessNUMADisabledMatchesPlain(t *testing.T) {
+	g := chainGraph(10)
+
+	plain := ApproxBetweenness(g, 4, 42)
+	numa := ApproxBetweennessNUMA(g, 4, 42, NUMAConfig{})
+
+	if numa.Mode != plain.Mode || numa.SampleSize != plain.SampleSize {
+		t.Fatalf("expected NUMA result to match plain result with disabled config, got %+v vs %+v", numa, plain)
+	}
+	if len(numa.Scores) != len(plain.Scores) {
+		t.Fatalf("expected same score count, got %d vs %d", len(numa.Scores), len(plain.Scores))
+	}
+	for id, score := range plain.Scores {
+		if numa.Scores[id] != score {
+			t.Errorf("node %d: expected score %v, got %v", id, score, numa.Scores[id])
+		}
+	}
+}
+
+func TestApproxBetweennessNUMASingleDomainMatchesPlain(t *testing.T) {
+	g := chainGraph(10)
+	cfg := NUMAConfig{Enabled: true, DomainCPUs: [][]int{{0, 1}}}
+
+	plain := ApproxBetweenness(g, 4, 7)
+	numa := ApproxBetweennessNUMA(g, 4, 7, cfg)
+
+	if len(numa.Scores) != len(plain.Scores) {
+		t.Fatalf("expected same score count with a single domain, got %d vs %d", len(numa.Scores), len(plain.Scores))
+	}
+}
+
+func TestApproxBetweennessNUMAMultiDomainMatchesPlain(t *testing.T) {
+	g := chainGraph(20)
+	cfg := NUMAConfig{Enabled: true, DomainCPUs: [][]int{{0, 1}, {2, 3}, {4}}}
+
+	plain := ApproxBetweenness(g, 8, 99)
+	numa := ApproxBetweennessNUMA(g, 8, 99, cfg)
+
+	// The pivot set, and thus the scale factor, is identical regardless
+	// of how many domains it's partitioned across - only the scheduling
+	// changes, not the math - so partitioning across domains must sum to
+	// exactly the same per-node scores as running one shared pool.
+	if len(numa.Scores) != len(plain.Scores) {
+		t.Fatalf("expected same score count across domains, got %d vs %d", len(numa.Scores), len(plain.Scores))
+	}
+	for id, score := range plain.Scores {
+		if numa.Scores[id] != score {
+			t.Errorf("node %d: expected score %v, got %v", id, score, numa.Scores[id])
+		}
+	}
+}
+
+func TestApproxBetweennessNUMAExactPathForFullSample(t *testing.T) {
+	g := chainGraph(5)
+	cfg := NUMAConfig{Enabled: true, DomainCPUs: [][]int{{0}, {1}}}
+
+	result := ApproxBetweennessNUMA(g, 5, 1, cfg)
+	if result.Mode != BetweennessExact {
+		t.Errorf("expected exact mode when sampleSize >= n, got %v", result.Mode)
+	}
+}
+
+func TestApproxBetweennessNUMAEmptyGraph(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	cfg := NUMAConfig{Enabled: true, DomainCPUs: [][]int{{0}, {1}}}
+
+	result := ApproxBetweennessNUMA(g, 4, 1, cfg)
+	if result.TotalNodes != 0 || len(result.Scores) != 0 {
+		t.Errorf("expected empty result for empty graph, got %+v", result)
+	}
+}
+
+func TestNUMAConfigDomainCount(t *testing.T) {
+	if (NUMAConfig{}).numaDomainCount() != 0 {
+		t.Error("expected zero-value NUMAConfig to report 0 domains")
+	}
+	if (NUMAConfig{Enabled: false, DomainCPUs: [][]int{{0}, {1}}}).numaDomainCount() != 0 {
+		t.Error("expected disabled NUMAConfig to report 0 domains even with DomainCPUs set")
+	}
+	if got := (NUMAConfig{Enabled: true, DomainCPUs: [][]int{{0}, {1}, {2}}}).numaDomainCount(); got != 3 {
+		t.Errorf("expected 3 domains, got %d", got)
+	}
+}
+
+func TestDetectNUMATopologyDoesNotPanic(t *testing.T) {
+	// Sandboxes and CI hosts rarely expose real NUMA topology, so the
+	// only contract this test checks is that detection degrades
+	// gracefully rather than panicking or hanging.
+	_ = DetectNUMATopology()
+}
@@ -0,0 +1,116 @@
+package analysis
+
+import (
+	"strconv"
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestApproxBetweennessWithOptionsZeroValueMatchesApproxBetweenness(t *testing.T) {
+	g := chainGraph(20)
+
+	plain := ApproxBetweenness(g, 8, 42)
+	withOpts := ApproxBetweennessWithOptions(g, 8, 42, BetweennessOptions{})
+
+	if withOpts.Mode != plain.Mode || withOpts.SampleSize != plain.SampleSize {
+		t.Fatalf("expected zero-valued options to match ApproxBetweenness, got %+v vs %+v", withOpts, plain)
+	}
+	if len(withOpts.Scores) != len(plain.Scores) {
+		t.Fatalf("expected same score count, got %d vs %d", len(withOpts.Scores), len(plain.Scores))
+	}
+	for id, score := range plain.Scores {
+		if withOpts.Scores[id] != score {
+			t.Errorf("node %d: expected score %v, got %v", id, score, withOpts.Scores[id])
+		}
+	}
+}
+
+func TestApproxBetweennessWithOptionsVaryingDepthAndChunkSize(t *testing.T) {
+	g := chainGraph(30)
+	plain := ApproxBetweenness(g, 12, 7)
+
+	configs := []BetweennessOptions{
+		{PipelineDepth: 1, ChunkSize: 1},
+		{PipelineDepth: 1, ChunkSize: 4},
+		{PipelineDepth: 4, ChunkSize: 1},
+		{PipelineDepth: 4, ChunkSize: 3},
+		{PipelineDepth: 8, ChunkSize: 8},
+	}
+
+	for _, cfg := range configs {
+		got := ApproxBetweennessWithOptions(g, 12, 7, cfg)
+		if len(got.Scores) != len(plain.Scores) {
+			t.Errorf("cfg %+v: expected same score count, got %d vs %d", cfg, len(got.Scores), len(plain.Scores))
+			continue
+		}
+		for id, score := range plain.Scores {
+			if got.Scores[id] != score {
+				t.Errorf("cfg %+v: node %d: expected score %v, got %v", cfg, id, score, got.Scores[id])
+			}
+		}
+	}
+}
+
+func TestApproxBetweennessWithOptionsExactPathForFullSample(t *testing.T) {
+	g := chainGraph(5)
+	result := ApproxBetweennessWithOptions(g, 5, 1, BetweennessOptions{PipelineDepth: 2, ChunkSize: 2})
+	if result.Mode != BetweennessExact {
+		t.Errorf("expected exact mode when sampleSize >= n, got %v", result.Mode)
+	}
+}
+
+func TestApproxBetweennessWithOptionsEmptyGraph(t *testing.T) {
+	g := chainGraph(0)
+	result := ApproxBetweennessWithOptions(g, 4, 1, BetweennessOptions{PipelineDepth: 3})
+	if result.TotalNodes != 0 || len(result.Scores) != 0 {
+		t.Errorf("expected empty result for empty graph, got %+v", result)
+	}
+}
+
+func TestBetweennessOptionsDefaults(t *testing.T) {
+	var o BetweennessOptions
+	if o.pipelineDepth() <= 0 {
+		t.Errorf("expected positive default pipeline depth, got %d", o.pipelineDepth())
+	}
+	if o.chunkSize() != 1 {
+		t.Errorf("expected default chunk size 1, got %d", o.chunkSize())
+	}
+
+	o = BetweennessOptions{PipelineDepth: 6, ChunkSize: 10}
+	if o.pipelineDepth() != 6 {
+		t.Errorf("expected configured pipeline depth 6, got %d", o.pipelineDepth())
+	}
+	if o.chunkSize() != 10 {
+		t.Errorf("expected configured chunk size 10, got %d", o.chunkSize())
+	}
+}
+
+// BenchmarkApproxBetweennessWithOptionsChunking reports allocs/op across
+// chunk sizes on the same scale-free graph buffer_pool_test.go uses for
+// its dense-path benchmark. Larger chunks mean fewer channel receives per
+// pivot; each worker takes its brandesBuffers once for its whole lifetime
+// whatever the chunk size.
+func BenchmarkApproxBetweennessWithOptionsChunking(b *testing.B) {
+	adj := benchScaleFreeGraph(2000)
+	g := simple.NewDirectedGraph()
+	for v := range adj.outgoing {
+		g.AddNode(simple.Node(v))
+	}
+	for v, neighbors := range adj.outgoing {
+		for _, w := range neighbors {
+			g.SetEdge(simple.Edge{F: simple.Node(v), T: simple.Node(w)})
+		}
+	}
+
+	for _, chunkSize := range []int{1, 4, 16, 64} {
+		b.Run("chunk="+strconv.Itoa(chunkSize), func(b *testing.B) {
+			opts := BetweennessOptions{PipelineDepth: 4, ChunkSize: chunkSize}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				ApproxBetweennessWithOptions(g, 200, int64(i), opts)
+			}
+		})
+	}
+}
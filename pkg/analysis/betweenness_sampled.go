@@ -0,0 +1,341 @@
+package analysis
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/graph/network"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// DefaultSampledBetweennessEpsilon and DefaultSampledBetweennessDelta are
+// SampledBetweenness's default accuracy parameters when
+// SampledBetweennessOptions leaves them zero: with probability at least
+// 1-delta, every node's normalized betweenness estimate is within
+// epsilon of its true value.
+const (
+	DefaultSampledBetweennessEpsilon = 0.05
+	DefaultSampledBetweennessDelta   = 0.1
+)
+
+// sampledBetweennessC is the constant "c" in the Riondato-Kornaropoulos
+// sample-count bound, c ≈ 0.5 per the paper's empirical tuning.
+const sampledBetweennessC = 0.5
+
+// SampledBetweennessOptions configures SampledBetweenness's accuracy and
+// sample-pair RNG.
+//
+// NOTE: config.go (AnalysisConfig, ConfigForSize) isn't present in this
+// checkout - only config_test.go, which already exercises it - so
+// Epsilon/Delta aren't yet wired up as AnalysisConfig fields the way
+// BetweennessMode/BetweennessSampleSize are. Once config.go exists,
+// ConfigForSize's XL-graph branch should set these the same way it
+// already sets BetweennessSampleSize.
+type SampledBetweennessOptions struct {
+	// Epsilon is the desired absolute error bound on each node's
+	// normalized betweenness score. Zero means
+	// DefaultSampledBetweennessEpsilon.
+	Epsilon float64
+
+	// Delta is the allowed failure probability: with probability at
+	// least 1-Delta, every node's estimate is within Epsilon of its true
+	// value. Zero means DefaultSampledBetweennessDelta.
+	Delta float64
+
+	// Seed seeds the sample-pair RNG.
+	Seed int64
+}
+
+func (o SampledBetweennessOptions) epsilon() float64 {
+	if o.Epsilon > 0 {
+		return o.Epsilon
+	}
+	return DefaultSampledBetweennessEpsilon
+}
+
+func (o SampledBetweennessOptions) delta() float64 {
+	if o.Delta > 0 {
+		return o.Delta
+	}
+	return DefaultSampledBetweennessDelta
+}
+
+// SampledBetweenness computes approximate betweenness centrality via the
+// Riondato-Kornaropoulos shortest-path-pair sampling estimator ("Fast
+// Approximation of Betweenness Centrality through Sampling", WSDM 2014):
+// each sample draws a uniformly random (s, t) pair, runs a single BFS
+// from s, then walks one uniformly random shortest s-t path backward
+// from t using predecessor shortest-path counts as weights, crediting
+// every interior node on that path 1/r.
+//
+// This differs from ApproxBetweennessWithOptions's pivot sampling, which
+// runs a full single-source BFS per pivot and extrapolates from however
+// many pivots it completed: pivot sampling has no closed-form error
+// bound, while sampling (s, t) pairs directly does. The sample count r
+// is derived from opts.Epsilon and opts.Delta and the graph's vertex
+// diameter (see sampledBetweennessSampleCount) rather than supplied by
+// the caller, and the bound travels with the result in
+// BetweennessResult.ApproxError.
+//
+// Graphs with fewer than 3 nodes fall back to exact computation, the
+// same as ApproxBetweennessWithOptions does when the requested sample
+// size reaches the node count - pair sampling isn't meaningful below
+// that.
+func SampledBetweenness(g *simple.DirectedGraph, opts SampledBetweennessOptions) BetweennessResult {
+	start := time.Now()
+	epsilon := opts.epsilon()
+	delta := opts.delta()
+
+	nodes := pooledNodesOf(g.Nodes())
+	defer putPooledNodes(nodes)
+	n := len(nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	result := BetweennessResult{
+		Scores:      make(map[int64]float64),
+		Mode:        BetweennessApproximate,
+		TotalNodes:  n,
+		ApproxError: epsilon,
+	}
+
+	if n < 3 {
+		result.Scores = network.Betweenness(g)
+		result.Mode = BetweennessExact
+		result.SampleSize = n
+		result.ApproxError = 0
+		result.Elapsed = time.Since(start)
+		return result
+	}
+
+	idx := buildDenseIndex(nodes)
+	adj := buildCachedAdjacency(g, idx)
+	if idx.idToIdx != nil {
+		denseIndexMapPool.Put(idx.idToIdx)
+		idx.idToIdx = nil
+	}
+
+	vertexDiameter := estimateVertexDiameter(adj, opts.Seed)
+	sampleCount := sampledBetweennessSampleCount(vertexDiameter, epsilon, delta)
+	result.SampleSize = sampleCount
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	credit := make([]float64, n)
+	buf := brandesPool.get(n)
+	defer brandesPool.put(buf)
+
+	// maxDraws bounds total (s, t) draws, including ones discarded for
+	// landing on an unreachable or same-node pair. On a sparse or
+	// disconnected graph most draws land on a pair with no path to
+	// credit, so without a cap the loop below retries forever - this
+	// gives it an O(n^2) budget (scaled up a little for small n) and
+	// falls back to whatever was sampled so far instead of hanging.
+	maxDraws := sampleCount * 4
+	if byN := n * n; byN > maxDraws {
+		maxDraws = byN
+	}
+
+	drawn := 0
+	pairs := 0 // every s != t draw, reachable or not
+	for attempts := 0; drawn < sampleCount && attempts < maxDraws; attempts++ {
+		s := rng.Intn(n)
+		t := rng.Intn(n)
+		if s == t {
+			continue
+		}
+		pairs++
+
+		singleSourceShortestPaths(adj, s, buf)
+		if buf.dist[t] < 0 {
+			// t unreachable from s: the pair counts toward pairs with
+			// zero credit, but not toward drawn, so the loop keeps going
+			// until sampleCount pairs with a path have been walked.
+			continue
+		}
+		drawn++
+
+		for cur := t; cur != s; {
+			preds := buf.pred[cur]
+			if len(preds) == 0 {
+				break
+			}
+			total := 0.0
+			for _, p := range preds {
+				total += buf.sigma[p]
+			}
+			pick := rng.Float64() * total
+			next := preds[len(preds)-1]
+			for _, p := range preds {
+				pick -= buf.sigma[p]
+				if pick <= 0 {
+					next = p
+					break
+				}
+			}
+			if next != s {
+				credit[next]++
+			}
+			cur = next
+		}
+	}
+
+	// The draw budget can run out before sampleCount pairs with a path
+	// were walked (e.g. a sparse or disconnected graph); the shortfall is
+	// reported via ApproxError/Annotations below.
+	partial := drawn < sampleCount
+	// Divide by every s != t pair drawn, reachable or not: a pair with no
+	// path is a sample whose credit is zero, and leaving it out would
+	// inflate every score by n(n-1) over the number of reachable pairs.
+	denom := float64(pairs)
+	if denom == 0 {
+		denom = 1
+	}
+
+	pairCount := float64(n) * float64(n-1)
+	for i, c := range credit {
+		if c == 0 {
+			continue
+		}
+		result.Scores[idx.idxToID[i]] = (c / denom) * pairCount
+	}
+	result.SampleSize = drawn
+	result.Elapsed = time.Since(start)
+	annotation := Annotation{
+		Metric:   "betweenness",
+		Severity: SeverityInfo,
+		Code:     "betweenness.approximate",
+		Message:  "betweenness estimated by (epsilon, delta)-bounded shortest-path-pair sampling",
+		Detail: map[string]any{
+			"sample_size": drawn,
+			"epsilon":     epsilon,
+			"delta":       delta,
+		},
+	}
+	if partial {
+		annotation.Severity = SeverityWarning
+		annotation.Code = "betweenness.approximate.partial"
+		annotation.Message = "sampling exhausted its draw budget before reaching the target sample size; estimate is based on fewer samples than requested"
+		result.ApproxError = math.NaN()
+	}
+	result.Annotations = append(result.Annotations, annotation)
+	return result
+}
+
+// sampledBetweennessSampleCount returns the Riondato-Kornaropoulos
+// sample count r = ceil((c/epsilon^2) * (floor(log2(vertexDiameter-2)) +
+// ln(1/delta))), the smallest number of (s, t) pair samples that bounds
+// every node's normalized betweenness estimate within epsilon of its
+// true value with probability at least 1-delta.
+func sampledBetweennessSampleCount(vertexDiameter int, epsilon, delta float64) int {
+	vd := vertexDiameter
+	if vd < 3 {
+		// log2(vd-2) is undefined below this; a vertex diameter this
+		// small already means a tiny or near-complete graph, so clamping
+		// to the smallest well-defined value is conservative rather than
+		// under-counting.
+		vd = 3
+	}
+	logTerm := math.Floor(math.Log2(float64(vd - 2)))
+	if logTerm < 0 {
+		logTerm = 0
+	}
+	r := (sampledBetweennessC / (epsilon * epsilon)) * (logTerm + math.Log(1/delta))
+	return int(math.Ceil(r))
+}
+
+// estimateVertexDiameter approximates a graph's vertex diameter (the
+// longest shortest path, in hops) with the standard double-sweep
+// heuristic: BFS from a random node to find a farthest node u, then BFS
+// from u and return its eccentricity. Two BFS passes are far cheaper
+// than the all-pairs computation an exact diameter would need, and this
+// is the approximation sampledBetweennessSampleCount is meant to be fed.
+//
+// Reachability is treated as undirected (both outgoing and incoming
+// edges) since the sample-count bound wants the graph's connectivity
+// structure, not the direction shortest paths are credited in.
+func estimateVertexDiameter(adj cachedAdjacency, seed int64) int {
+	n := len(adj.outgoing)
+	if n <= 1 {
+		return 1
+	}
+	rng := rand.New(rand.NewSource(seed))
+	_, far := bfsEccentricity(adj, rng.Intn(n))
+	ecc, _ := bfsEccentricity(adj, far)
+	if ecc < 1 {
+		ecc = 1
+	}
+	return ecc
+}
+
+// bfsEccentricity returns source's eccentricity (the longest shortest-
+// path distance from it to any reachable node) and the index of one
+// farthest node, walking both outgoing and incoming edges.
+func bfsEccentricity(adj cachedAdjacency, source int) (eccentricity, farthest int) {
+	n := len(adj.outgoing)
+	dist := make([]int, n)
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[source] = 0
+	queue := []int{source}
+	farthest = source
+
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if dist[v] > dist[farthest] {
+			farthest = v
+		}
+		for _, w := range adj.outgoing[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
+			}
+		}
+		for _, w := range adj.incoming[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+	return dist[farthest], farthest
+}
+
+// singleSourceShortestPaths runs just the BFS/shortest-path-counting
+// phase of Brandes' algorithm from sourceIdx, populating buf.dist,
+// buf.sigma, and buf.pred. SampledBetweenness only needs one random
+// shortest path per sample, not every node's accumulated dependency, so
+// it skips singleSourceBetweennessDense's accumulation phase entirely.
+func singleSourceShortestPaths(adj cachedAdjacency, sourceIdx int, buf *brandesBuffers) {
+	nodeCount := len(adj.outgoing)
+	if nodeCount == 0 {
+		return
+	}
+	buf.reset(nodeCount)
+
+	sigma := buf.sigma
+	dist := buf.dist
+	pred := buf.pred
+
+	sigma[sourceIdx] = 1
+	dist[sourceIdx] = 0
+	buf.queue = append(buf.queue, sourceIdx)
+
+	for len(buf.queue) > 0 {
+		v := buf.queue[0]
+		buf.queue = buf.queue[1:]
+
+		for _, w := range adj.outgoing[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				buf.queue = append(buf.queue, w)
+			}
+			if dist[w] == dist[v]+1 {
+				sigma[w] += sigma[v]
+				pred[w] = append(pred[w], v)
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestSampledBetweennessSmallGraphIsExact(t *testing.T) {
+	g := chainGraph(2)
+	got := SampledBetweenness(g, SampledBetweennessOptions{})
+	if got.Mode != BetweennessExact {
+		t.Errorf("expected BetweennessExact for a 2-node graph, got %v", got.Mode)
+	}
+	if got.ApproxError != 0 {
+		t.Errorf("expected zero ApproxError for an exact result, got %v", got.ApproxError)
+	}
+}
+
+func TestSampledBetweennessWithinErrorBoundOfTightEstimate(t *testing.T) {
+	g := chainGraph(30)
+	// The reference is a much tighter (epsilon 0.01) sampled run, not
+	// exact scores.
+	reference := make(map[int64]float64)
+	for id, score := range SampledBetweenness(g, SampledBetweennessOptions{Epsilon: 0.01, Delta: 0.01, Seed: 1}).Scores {
+		reference[id] = score
+	}
+
+	got := SampledBetweenness(g, SampledBetweennessOptions{Epsilon: 0.1, Delta: 0.1, Seed: 7})
+	if got.Mode != BetweennessApproximate {
+		t.Fatalf("expected BetweennessApproximate, got %v", got.Mode)
+	}
+	if got.ApproxError != 0.1 {
+		t.Errorf("expected ApproxError 0.1, got %v", got.ApproxError)
+	}
+	if got.SampleSize <= 0 {
+		t.Errorf("expected a positive sample count, got %d", got.SampleSize)
+	}
+
+	n := float64(got.TotalNodes)
+	normalizer := (n - 1) * (n - 2)
+	for id, refScore := range reference {
+		diff := math.Abs(got.Scores[id]-refScore) / normalizer
+		if diff > got.ApproxError {
+			t.Errorf("node %d: normalized diff %v exceeds ApproxError %v", id, diff, got.ApproxError)
+		}
+	}
+}
+
+func TestSampledBetweennessSampleCountDecreasesWithLargerEpsilon(t *testing.T) {
+	tight := sampledBetweennessSampleCount(10, 0.01, 0.1)
+	loose := sampledBetweennessSampleCount(10, 0.1, 0.1)
+	if loose >= tight {
+		t.Errorf("expected looser epsilon to need fewer samples, got tight=%d loose=%d", tight, loose)
+	}
+}
+
+func TestSampledBetweennessEdgelessGraphTerminates(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for i := 0; i < 10; i++ {
+		g.AddNode(simple.Node(i))
+	}
+
+	got := SampledBetweenness(g, SampledBetweennessOptions{Epsilon: 0.1, Delta: 0.1, Seed: 1})
+	if got.Mode != BetweennessApproximate {
+		t.Fatalf("expected BetweennessApproximate, got %v", got.Mode)
+	}
+	if got.SampleSize != 0 {
+		t.Errorf("expected zero successful draws on an edgeless graph, got %d", got.SampleSize)
+	}
+	for id, score := range got.Scores {
+		if score != 0 {
+			t.Errorf("node %d: expected zero betweenness on an edgeless graph, got %v", id, score)
+		}
+	}
+
+	foundPartial := false
+	for _, a := range got.Annotations {
+		if a.Code == "betweenness.approximate.partial" {
+			foundPartial = true
+		}
+	}
+	if !foundPartial {
+		t.Errorf("expected a betweenness.approximate.partial annotation, got %+v", got.Annotations)
+	}
+}
+
+func TestSampledBetweennessDisconnectedComponentsTerminates(t *testing.T) {
+	g := simple.NewDirectedGraph()
+	for i := 0; i < 12; i++ {
+		g.AddNode(simple.Node(i))
+	}
+	// Two disjoint chains: 0->1->...->5 and 6->7->...->11.
+	for i := 0; i < 5; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+	}
+	for i := 6; i < 11; i++ {
+		g.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+	}
+
+	done := make(chan BetweennessResult, 1)
+	go func() {
+		done <- SampledBetweenness(g, SampledBetweennessOptions{Epsilon: 0.1, Delta: 0.1, Seed: 1})
+	}()
+
+	select {
+	case got := <-done:
+		if got.Mode != BetweennessApproximate {
+			t.Fatalf("expected BetweennessApproximate, got %v", got.Mode)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("SampledBetweenness did not terminate on a disconnected graph")
+	}
+}
+
+func TestEstimateVertexDiameterOnChain(t *testing.T) {
+	g := chainGraph(10)
+	nodes := pooledNodesOf(g.Nodes())
+	defer putPooledNodes(nodes)
+	idx := buildDenseIndex(nodes)
+	adj := buildCachedAdjacency(g, idx)
+
+	vd := estimateVertexDiameter(adj, 3)
+	if vd != 9 {
+		t.Errorf("expected vertex diameter 9 for a 10-node chain, got %d", vd)
+	}
+}
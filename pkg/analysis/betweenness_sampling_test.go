@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"testing"
+)
+
+func starGraph(n int) cachedAdjacency {
+	// A single hub (node 0) with n-1 spokes, each pointing at the hub:
+	// a deliberately extreme degree skew so a uniform sample of a small
+	// k rarely picks the one node that matters.
+	outgoing := make([][]int, n)
+	incoming := make([][]int, n)
+	for i := 1; i < n; i++ {
+		outgoing[i] = []int{0}
+		incoming[0] = append(incoming[0], i)
+	}
+	return cachedAdjacency{outgoing: outgoing, incoming: incoming}
+}
+
+func TestStructuralHeadsFindsSinks(t *testing.T) {
+	adj := starGraph(10)
+	heads := structuralHeads(adj)
+	if len(heads) != 1 || heads[0] != 0 {
+		t.Errorf("expected the hub (zero out-degree) as the only head, got %v", heads)
+	}
+}
+
+func TestStructuralHeadsFallsBackToInDegreeWhenNoSinks(t *testing.T) {
+	// A directed cycle has no zero out-degree node.
+	n := 20
+	outgoing := make([][]int, n)
+	incoming := make([][]int, n)
+	for i := 0; i < n; i++ {
+		next := (i + 1) % n
+		outgoing[i] = []int{next}
+		incoming[next] = append(incoming[next], i)
+	}
+	adj := cachedAdjacency{outgoing: outgoing, incoming: incoming}
+
+	heads := structuralHeads(adj)
+	if len(heads) == 0 {
+		t.Fatal("expected a fallback head set for a graph with no sinks")
+	}
+}
+
+func TestSampleIndicesStructuralRespectsK(t *testing.T) {
+	adj := starGraph(50)
+	for _, k := range []int{1, 5, 20} {
+		got := sampleIndicesStructural(adj, k, 1, true)
+		if len(got) != k {
+			t.Errorf("k=%d: expected %d indices, got %d", k, k, len(got))
+		}
+	}
+}
+
+func TestSampleIndicesStructuralNoDuplicates(t *testing.T) {
+	adj := starGraph(50)
+	got := sampleIndicesStructural(adj, 20, 7, true)
+	seen := make(map[int]bool, len(got))
+	for _, idx := range got {
+		if seen[idx] {
+			t.Errorf("duplicate index %d in structural sample", idx)
+		}
+		seen[idx] = true
+	}
+}
+
+func TestSampleIndicesStructuralIncludesHub(t *testing.T) {
+	adj := starGraph(200)
+	got := sampleIndicesStructural(adj, 10, 3, true)
+	found := false
+	for _, idx := range got {
+		if idx == 0 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected the hub (a head of the star DAG) to be included in a small structural sample")
+	}
+}
+
+func TestSampleIndicesDegreeWeightedBiasesTowardHighDegree(t *testing.T) {
+	adj := benchScaleFreeGraph(300)
+	degree := func(i int) int { return len(adj.outgoing[i]) + len(adj.incoming[i]) }
+
+	const trials = 30
+	const k = 20
+	var weightedTotal, uniformTotal int
+	for seed := int64(0); seed < trials; seed++ {
+		weighted := sampleIndicesDegreeWeighted(adj, k, seed)
+		uniform := sampleIndices(len(adj.outgoing), k, seed)
+		for _, idx := range weighted {
+			weightedTotal += degree(idx)
+		}
+		for _, idx := range uniform {
+			uniformTotal += degree(idx)
+		}
+	}
+
+	if weightedTotal <= uniformTotal {
+		t.Errorf("expected degree-weighted sampling to pick higher-degree nodes on average, got weighted total degree %d vs uniform %d over %d trials", weightedTotal, uniformTotal, trials)
+	}
+}
+
+func TestBetweennessOptionsStrategyDefaultsToUniform(t *testing.T) {
+	var o BetweennessOptions
+	if o.strategy() != SamplingUniform {
+		t.Errorf("expected zero-valued options to default to SamplingUniform, got %v", o.strategy())
+	}
+	o.Strategy = SamplingStructural
+	if o.strategy() != SamplingStructural {
+		t.Errorf("expected configured strategy to be respected, got %v", o.strategy())
+	}
+}
+
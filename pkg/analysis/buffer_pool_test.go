@@ -4,65 +4,34 @@ import (
 	"runtime"
 	"sync"
 	"testing"
-
-	"gonum.org/v1/gonum/graph"
 )
 
-// mockNode implements graph.Node for testing
-type mockNode struct {
-	id int64
-}
-
-func (n mockNode) ID() int64 { return n.id }
-
-// createTestBuffer creates a brandesBuffers with test data
-func createTestBuffer() *brandesBuffers {
-	return &brandesBuffers{
-		sigma:     make(map[int64]float64, 256),
-		dist:      make(map[int64]int, 256),
-		delta:     make(map[int64]float64, 256),
-		pred:      make(map[int64][]int64, 256),
-		queue:     make([]int64, 0, 256),
-		stack:     make([]int64, 0, 256),
-		neighbors: make([]int64, 0, 32),
-	}
-}
-
 // =============================================================================
 // brandesBuffers Struct Tests
 // =============================================================================
 
 // TestBrandesBuffersInitialization verifies struct creation
 func TestBrandesBuffersInitialization(t *testing.T) {
-	t.Log("Testing brandesBuffers struct initialization...")
-
-	buf := createTestBuffer()
-
-	t.Logf("Created buffer with queue capacity: %d", cap(buf.queue))
+	buf := newBrandesBuffers(256)
 
 	if buf.sigma == nil {
-		t.Fatal("sigma map should be initialized")
+		t.Fatal("sigma slice should be initialized")
 	}
 	if buf.dist == nil {
-		t.Fatal("dist map should be initialized")
+		t.Fatal("dist slice should be initialized")
 	}
 	if buf.delta == nil {
-		t.Fatal("delta map should be initialized")
+		t.Fatal("delta slice should be initialized")
 	}
 	if buf.pred == nil {
-		t.Fatal("pred map should be initialized")
-	}
-	if cap(buf.queue) != 256 {
-		t.Errorf("queue capacity: got %d, want 256", cap(buf.queue))
+		t.Fatal("pred slice should be initialized")
 	}
-	if cap(buf.stack) != 256 {
-		t.Errorf("stack capacity: got %d, want 256", cap(buf.stack))
+	if cap(buf.sigma) != 256 {
+		t.Errorf("sigma capacity: got %d, want 256", cap(buf.sigma))
 	}
 	if cap(buf.neighbors) != 32 {
 		t.Errorf("neighbors capacity: got %d, want 32", cap(buf.neighbors))
 	}
-
-	t.Log("PASS: All fields initialized correctly")
 }
 
 // =============================================================================
@@ -71,30 +40,17 @@ func TestBrandesBuffersInitialization(t *testing.T) {
 
 // TestResetClearsAllValues verifies reset produces clean state
 func TestResetClearsAllValues(t *testing.T) {
-	t.Log("Testing reset() clears all values...")
-
-	// Create buffer with stale data
-	buf := createTestBuffer()
+	buf := newBrandesBuffers(8)
+	buf.reset(3)
 	buf.sigma[1] = 999.0
 	buf.dist[1] = 999
 	buf.delta[1] = 999.0
-	buf.pred[1] = []int64{1, 2, 3}
+	buf.pred[1] = append(buf.pred[1], 0, 2)
 	buf.queue = append(buf.queue, 1, 2, 3)
-	buf.stack = append(buf.stack, 4, 5, 6)
-
-	t.Logf("Before reset: sigma[1]=%v, dist[1]=%v, queue len=%d",
-		buf.sigma[1], buf.dist[1], len(buf.queue))
-
-	// Create mock nodes
-	nodes := []graph.Node{mockNode{id: 1}, mockNode{id: 2}}
-
-	// Reset
-	buf.reset(nodes)
+	buf.stack = append(buf.stack, 0, 1, 2)
 
-	t.Logf("After reset: sigma[1]=%v, dist[1]=%v, queue len=%d",
-		buf.sigma[1], buf.dist[1], len(buf.queue))
+	buf.reset(3)
 
-	// Verify reset state matches fresh allocation
 	if buf.sigma[1] != 0.0 {
 		t.Errorf("sigma[1] should be 0 after reset, got %v", buf.sigma[1])
 	}
@@ -113,30 +69,19 @@ func TestResetClearsAllValues(t *testing.T) {
 	if len(buf.stack) != 0 {
 		t.Errorf("stack should be empty after reset, got len %d", len(buf.stack))
 	}
-
-	t.Log("PASS: reset() produces correct initial state")
 }
 
 // TestResetRetainsPredCapacity verifies pred slices retain capacity
 func TestResetRetainsPredCapacity(t *testing.T) {
-	t.Log("Testing reset() retains predecessor slice capacity...")
-
-	buf := createTestBuffer()
-	nodes := []graph.Node{mockNode{id: 1}}
+	buf := newBrandesBuffers(8)
+	buf.reset(2)
 
-	// First reset - allocates small slice
-	buf.reset(nodes)
-	t.Logf("After first reset: pred[1] cap=%d", cap(buf.pred[1]))
-
-	// Add predecessors to grow slice
-	buf.pred[1] = append(buf.pred[1], 10, 20, 30, 40, 50)
+	// Grow pred[1]'s capacity.
+	buf.pred[1] = append(buf.pred[1], 0, 1, 0, 1, 0)
 	oldCap := cap(buf.pred[1])
-	t.Logf("After appends: pred[1] cap=%d", oldCap)
 
-	// Reset again - should retain capacity
-	buf.reset(nodes)
+	buf.reset(2)
 	newCap := cap(buf.pred[1])
-	t.Logf("After second reset: pred[1] cap=%d", newCap)
 
 	if newCap < oldCap {
 		t.Errorf("pred capacity should be retained: got %d, want >= %d", newCap, oldCap)
@@ -144,51 +89,44 @@ func TestResetRetainsPredCapacity(t *testing.T) {
 	if len(buf.pred[1]) != 0 {
 		t.Errorf("pred length should be 0 after reset, got %d", len(buf.pred[1]))
 	}
-
-	t.Log("PASS: reset() retains predecessor slice capacity")
 }
 
-// TestResetTriggersClearOnOversizedMaps verifies 2x threshold
-func TestResetTriggersClearOnOversizedMaps(t *testing.T) {
-	t.Log("Testing reset() triggers clear() on oversized maps...")
+// TestResetGrowsUndersizedBuffers verifies reset allocates larger backing
+// arrays when nodeCount exceeds the buffer's current capacity.
+func TestResetGrowsUndersizedBuffers(t *testing.T) {
+	buf := newBrandesBuffers(4)
+	buf.reset(500)
 
-	buf := createTestBuffer()
-
-	// Grow maps very large
-	for i := int64(0); i < 5000; i++ {
-		buf.sigma[i] = float64(i)
-		buf.dist[i] = int(i)
-		buf.delta[i] = float64(i)
-		buf.pred[i] = []int64{i}
+	if len(buf.sigma) != 500 || cap(buf.sigma) < 500 {
+		t.Fatalf("expected sigma grown to at least 500, got len=%d cap=%d", len(buf.sigma), cap(buf.sigma))
 	}
-	t.Logf("Grew maps to %d entries", len(buf.sigma))
-
-	// Reset with tiny node set (should trigger clear due to 2x threshold)
-	nodes := []graph.Node{mockNode{id: 0}, mockNode{id: 1}}
-	buf.reset(nodes)
+	if len(buf.dist) != 500 || len(buf.pred) != 500 {
+		t.Fatalf("expected dist/pred sized to 500, got dist=%d pred=%d", len(buf.dist), len(buf.pred))
+	}
+}
 
-	t.Logf("After reset with 2 nodes: sigma has %d entries", len(buf.sigma))
+// TestResetRetainsOversizedCapacity verifies reset no longer shrinks a
+// buffer just because nodeCount is much smaller than its capacity -
+// bucketing in brandesPool is what bounds retention now, not reset.
+func TestResetRetainsOversizedCapacity(t *testing.T) {
+	buf := newBrandesBuffers(5000)
+	buf.reset(2)
 
-	// Should have been cleared and only 2 entries remain
 	if len(buf.sigma) != 2 {
-		t.Errorf("oversized map should be cleared: got %d entries, want 2", len(buf.sigma))
+		t.Errorf("expected sigma truncated to 2, got len %d", len(buf.sigma))
 	}
-	if len(buf.dist) != 2 {
-		t.Errorf("dist map should be cleared: got %d entries, want 2", len(buf.dist))
+	if cap(buf.sigma) < 5000 {
+		t.Errorf("expected sigma to retain its large capacity, got cap %d", cap(buf.sigma))
 	}
-
-	t.Log("PASS: clear() triggered for oversized maps")
 }
 
-// TestResetHandlesEmptyNodes verifies reset with empty node slice
-func TestResetHandlesEmptyNodes(t *testing.T) {
-	t.Log("Testing reset() with empty node slice...")
+// TestResetHandlesZeroNodes verifies reset with a zero node count
+func TestResetHandlesZeroNodes(t *testing.T) {
+	buf := newBrandesBuffers(8)
+	buf.reset(3)
+	buf.sigma[0] = 999.0
 
-	buf := createTestBuffer()
-	buf.sigma[1] = 999.0
-
-	nodes := []graph.Node{}
-	buf.reset(nodes)
+	buf.reset(0)
 
 	if len(buf.queue) != 0 {
 		t.Errorf("queue should be empty, got len %d", len(buf.queue))
@@ -196,183 +134,153 @@ func TestResetHandlesEmptyNodes(t *testing.T) {
 	if len(buf.stack) != 0 {
 		t.Errorf("stack should be empty, got len %d", len(buf.stack))
 	}
-
-	t.Log("PASS: reset() handles empty node slice")
+	if len(buf.sigma) != 0 {
+		t.Errorf("sigma should be truncated to 0, got len %d", len(buf.sigma))
+	}
 }
 
 // =============================================================================
-// Pool Behavior Tests
+// Pool Bucketing Tests
 // =============================================================================
 
-// TestPoolReturnsNonNilBuffer verifies pool.Get() works
-func TestPoolReturnsNonNilBuffer(t *testing.T) {
-	t.Log("Testing brandesPool.Get() returns valid buffer...")
-
-	for i := 0; i < 10; i++ {
-		buf := brandesPool.Get().(*brandesBuffers)
-		if buf == nil {
-			t.Fatal("pool should never return nil")
+// TestBucketForCapacityRoundsUpToPowerOfTwo verifies bucket selection
+func TestBucketForCapacityRoundsUpToPowerOfTwo(t *testing.T) {
+	cases := []struct {
+		capacity int
+		want     int
+	}{
+		{1, 0},
+		{2, 1},
+		{3, 2},
+		{4, 2},
+		{5, 3},
+		{256, 8},
+		{257, 9},
+	}
+	for _, c := range cases {
+		if got := bucketForCapacity(c.capacity); got != c.want {
+			t.Errorf("bucketForCapacity(%d) = %d, want %d", c.capacity, got, c.want)
 		}
-		t.Logf("Got buffer %d: sigma=%p", i, buf.sigma)
-		brandesPool.Put(buf)
 	}
+}
 
-	t.Log("PASS: Pool consistently returns valid buffers")
+// TestBrandesPoolGetReturnsBufferWithSufficientCapacity verifies Get
+// hands out a buffer from the bucket that fits nodeCount.
+func TestBrandesPoolGetReturnsBufferWithSufficientCapacity(t *testing.T) {
+	buf := brandesPool.get(100)
+	if buf == nil {
+		t.Fatal("pool should never return nil")
+	}
+	if cap(buf.sigma) < 100 {
+		t.Errorf("expected capacity >= 100, got %d", cap(buf.sigma))
+	}
+	brandesPool.put(buf)
 }
 
-// TestPoolPreallocation verifies pool's New() function allocates correctly
-func TestPoolPreallocation(t *testing.T) {
-	t.Log("Testing pool preallocation capacities...")
+// TestBrandesPoolPutKeepsBufferInItsBucket verifies a buffer returned to
+// the pool comes back from a Get() for the same size class.
+func TestBrandesPoolPutKeepsBufferInItsBucket(t *testing.T) {
+	buf := brandesPool.get(1000)
+	buf.reset(1000)
+	brandesPool.put(buf)
 
-	// Note: We can't guarantee exact capacities because:
-	// 1. Pool may return previously-used buffers with grown slices
-	// 2. Pool may have been cleared by GC
-	// What we CAN verify: buffers are always functional and non-nil
+	again := brandesPool.get(900) // same bucket as 1000 (1024)
+	if cap(again.sigma) < 900 {
+		t.Errorf("expected a buffer from the 1024 bucket, got capacity %d", cap(again.sigma))
+	}
+	brandesPool.put(again)
+}
 
-	buf := brandesPool.Get().(*brandesBuffers)
+// TestBrandesPoolPreallocation verifies pool's New() function allocates correctly
+func TestBrandesPoolPreallocation(t *testing.T) {
+	buf := brandesPool.get(256)
 	if buf == nil {
 		t.Fatal("Pool returned nil buffer")
 	}
-
-	// Verify all maps are initialized
 	if buf.sigma == nil || buf.dist == nil || buf.delta == nil || buf.pred == nil {
-		t.Error("One or more maps are nil")
-	}
-
-	// Verify slices are at least usable (not nil)
-	if buf.queue == nil {
-		t.Error("queue slice is nil")
-	}
-	if buf.stack == nil {
-		t.Error("stack slice is nil")
+		t.Error("One or more slices are nil")
 	}
-	if buf.neighbors == nil {
-		t.Error("neighbors slice is nil")
+	if buf.queue == nil || buf.stack == nil || buf.neighbors == nil {
+		t.Error("One or more auxiliary slices are nil")
 	}
-
-	brandesPool.Put(buf)
-	t.Log("PASS: Pool returns valid, usable buffers")
+	brandesPool.put(buf)
 }
 
-// TestPoolEvictionRecovery verifies behavior after GC
-func TestPoolEvictionRecovery(t *testing.T) {
-	t.Log("Testing pool recovery after GC eviction...")
+// TestBrandesPoolEvictionRecovery verifies behavior after GC
+func TestBrandesPoolEvictionRecovery(t *testing.T) {
+	buf1 := brandesPool.get(256)
+	buf1.reset(1)
+	buf1.sigma[0] = 3.14
+	brandesPool.put(buf1)
 
-	// Get and return a buffer
-	buf1 := brandesPool.Get().(*brandesBuffers)
-	buf1.sigma[42] = 3.14
-	brandesPool.Put(buf1)
-
-	t.Log("Forcing GC to potentially evict pool entries...")
 	runtime.GC()
 	runtime.GC()
 
-	// Get buffer again - might be new or recycled
-	buf2 := brandesPool.Get().(*brandesBuffers)
+	buf2 := brandesPool.get(256)
 	if buf2 == nil {
 		t.Fatal("pool must return buffer even after GC")
 	}
-
-	// Key point: behavior is correct regardless of whether buf1 == buf2
-	t.Logf("Got buffer after GC: sigma=%p (may or may not be same)", buf2.sigma)
-
-	brandesPool.Put(buf2)
-	t.Log("PASS: Pool handles GC eviction gracefully")
+	brandesPool.put(buf2)
 }
 
 // =============================================================================
 // Equivalence to Fresh Allocation Tests
 // =============================================================================
 
-// TestResetEquivalentToFreshAllocation is the KEY isomorphism test
+// TestResetEquivalentToFreshAllocation is the KEY isomorphism test: a
+// pooled buffer after reset(nodeCount) must be indistinguishable from
+// one built fresh for the same nodeCount.
 func TestResetEquivalentToFreshAllocation(t *testing.T) {
-	t.Log("Testing that reset() produces state equivalent to fresh allocation...")
-
-	nodes := []graph.Node{mockNode{id: 1}, mockNode{id: 2}, mockNode{id: 3}}
-
-	// Fresh allocation (baseline)
-	fresh := &brandesBuffers{
-		sigma: make(map[int64]float64),
-		dist:  make(map[int64]int),
-		delta: make(map[int64]float64),
-		pred:  make(map[int64][]int64),
-	}
-	for _, n := range nodes {
-		nid := n.ID()
-		fresh.sigma[nid] = 0
-		fresh.dist[nid] = -1
-		fresh.delta[nid] = 0
-		fresh.pred[nid] = make([]int64, 0)
-	}
-
-	// Pooled + reset (optimized)
-	pooled := brandesPool.Get().(*brandesBuffers)
-	pooled.sigma[999] = 999.0 // Add stale data
-	pooled.dist[999] = 999
-	pooled.delta[999] = 999.0
-	pooled.reset(nodes)
-
-	// Compare
-	for _, n := range nodes {
-		nid := n.ID()
-		t.Logf("Node %d: fresh sigma=%v, pooled sigma=%v", nid, fresh.sigma[nid], pooled.sigma[nid])
-
-		if fresh.sigma[nid] != pooled.sigma[nid] {
-			t.Errorf("sigma mismatch for node %d: fresh=%v, pooled=%v", nid, fresh.sigma[nid], pooled.sigma[nid])
+	const nodeCount = 3
+
+	fresh := newBrandesBuffers(nodeCount)
+	fresh.reset(nodeCount)
+
+	pooled := brandesPool.get(nodeCount)
+	pooled.reset(nodeCount)
+	// Simulate stale data from a previous caller, then reset again.
+	pooled.sigma[0] = 999.0
+	pooled.dist[0] = 999
+	pooled.delta[0] = 999.0
+	pooled.pred[0] = append(pooled.pred[0], 1, 2)
+	pooled.reset(nodeCount)
+
+	for i := 0; i < nodeCount; i++ {
+		if fresh.sigma[i] != pooled.sigma[i] {
+			t.Errorf("sigma mismatch at %d: fresh=%v, pooled=%v", i, fresh.sigma[i], pooled.sigma[i])
 		}
-		if fresh.dist[nid] != pooled.dist[nid] {
-			t.Errorf("dist mismatch for node %d: fresh=%v, pooled=%v", nid, fresh.dist[nid], pooled.dist[nid])
+		if fresh.dist[i] != pooled.dist[i] {
+			t.Errorf("dist mismatch at %d: fresh=%v, pooled=%v", i, fresh.dist[i], pooled.dist[i])
 		}
-		if fresh.delta[nid] != pooled.delta[nid] {
-			t.Errorf("delta mismatch for node %d: fresh=%v, pooled=%v", nid, fresh.delta[nid], pooled.delta[nid])
+		if fresh.delta[i] != pooled.delta[i] {
+			t.Errorf("delta mismatch at %d: fresh=%v, pooled=%v", i, fresh.delta[i], pooled.delta[i])
 		}
-		if len(fresh.pred[nid]) != len(pooled.pred[nid]) {
-			t.Errorf("pred len mismatch for node %d: fresh=%d, pooled=%d", nid, len(fresh.pred[nid]), len(pooled.pred[nid]))
+		if len(fresh.pred[i]) != len(pooled.pred[i]) {
+			t.Errorf("pred len mismatch at %d: fresh=%d, pooled=%d", i, len(fresh.pred[i]), len(pooled.pred[i]))
 		}
 	}
 
-	brandesPool.Put(pooled)
-	t.Log("PASS: reset() produces state equivalent to fresh allocation")
+	brandesPool.put(pooled)
 }
 
-// TestStaleEntriesNotAccessible verifies stale entries don't affect correctness
+// TestStaleEntriesNotAccessible verifies stale entries from a larger
+// previous use don't affect a subsequent smaller reset.
 func TestStaleEntriesNotAccessible(t *testing.T) {
-	t.Log("Testing that stale entries from previous usage don't affect results...")
-
-	buf := createTestBuffer()
-
-	// Simulate first usage with many nodes
-	oldNodes := make([]graph.Node, 100)
-	for i := range oldNodes {
-		oldNodes[i] = mockNode{id: int64(i)}
-	}
-	buf.reset(oldNodes)
-
-	// Add some values
-	for i := int64(0); i < 100; i++ {
+	buf := newBrandesBuffers(256)
+	buf.reset(100)
+	for i := 0; i < 100; i++ {
 		buf.sigma[i] = float64(i * 10)
-		buf.dist[i] = int(i)
+		buf.dist[i] = i
 	}
-	t.Logf("Set values for 100 nodes")
 
-	// Now reset with smaller set
-	newNodes := []graph.Node{mockNode{id: 5}, mockNode{id: 10}}
-	buf.reset(newNodes)
+	buf.reset(2)
 
-	// Only nodes 5 and 10 should have fresh values
-	if buf.sigma[5] != 0.0 {
-		t.Errorf("sigma[5] should be 0, got %v", buf.sigma[5])
+	if buf.sigma[0] != 0.0 || buf.sigma[1] != 0.0 {
+		t.Errorf("sigma should be reset for active nodes, got %v", buf.sigma)
 	}
-	if buf.dist[5] != -1 {
-		t.Errorf("dist[5] should be -1, got %v", buf.dist[5])
+	if buf.dist[0] != -1 || buf.dist[1] != -1 {
+		t.Errorf("dist should be reset for active nodes, got %v", buf.dist)
 	}
-	if buf.sigma[10] != 0.0 {
-		t.Errorf("sigma[10] should be 0, got %v", buf.sigma[10])
-	}
-
-	// Note: stale entries for other nodes may still exist but won't be accessed
-	// by the algorithm since only nodes in the current graph are traversed
-	t.Log("PASS: Stale entries don't affect active node values")
 }
 
 // =============================================================================
@@ -381,39 +289,27 @@ func TestStaleEntriesNotAccessible(t *testing.T) {
 
 // TestSliceCapacityRetention verifies queue/stack retain capacity
 func TestSliceCapacityRetention(t *testing.T) {
-	t.Log("Testing slice capacity retention across resets...")
-
-	buf := createTestBuffer()
-	nodes := []graph.Node{mockNode{id: 1}}
-	buf.reset(nodes)
+	buf := newBrandesBuffers(8)
+	buf.reset(1)
 
-	// Grow queue and stack
-	for i := int64(0); i < 500; i++ {
+	for i := 0; i < 500; i++ {
 		buf.queue = append(buf.queue, i)
 		buf.stack = append(buf.stack, i)
 	}
 	queueCap := cap(buf.queue)
 	stackCap := cap(buf.stack)
-	t.Logf("Grew slices: queue cap=%d, stack cap=%d", queueCap, stackCap)
 
-	// Reset
-	buf.reset(nodes)
+	buf.reset(1)
 
-	// Capacity should be retained
 	if cap(buf.queue) < queueCap {
 		t.Errorf("queue capacity decreased: got %d, want >= %d", cap(buf.queue), queueCap)
 	}
 	if cap(buf.stack) < stackCap {
 		t.Errorf("stack capacity decreased: got %d, want >= %d", cap(buf.stack), stackCap)
 	}
-	if len(buf.queue) != 0 {
-		t.Errorf("queue length should be 0, got %d", len(buf.queue))
+	if len(buf.queue) != 0 || len(buf.stack) != 0 {
+		t.Errorf("queue/stack length should be 0 after reset, got %d/%d", len(buf.queue), len(buf.stack))
 	}
-	if len(buf.stack) != 0 {
-		t.Errorf("stack length should be 0, got %d", len(buf.stack))
-	}
-
-	t.Log("PASS: Slice capacity retained, length reset")
 }
 
 // =============================================================================
@@ -423,8 +319,6 @@ func TestSliceCapacityRetention(t *testing.T) {
 // TestBufferPoolConcurrentAccess verifies no races under heavy concurrent load.
 // Run with: go test -race -run TestBufferPoolConcurrentAccess -count=10
 func TestBufferPoolConcurrentAccess(t *testing.T) {
-	t.Log("Testing buffer pool concurrent access...")
-
 	const numGoroutines = 50
 	const iterationsPerGoroutine = 20
 
@@ -435,64 +329,44 @@ func TestBufferPoolConcurrentAccess(t *testing.T) {
 		go func(workerID int) {
 			defer wg.Done()
 			for j := 0; j < iterationsPerGoroutine; j++ {
-				// Get buffer, use it, return it
-				buf := brandesPool.Get().(*brandesBuffers)
+				buf := brandesPool.get(256)
 				if buf == nil {
 					t.Error("Got nil buffer in concurrent access")
 					return
 				}
 
-				// Simulate work
-				nodes := []graph.Node{mockNode{id: int64(workerID*1000 + j)}}
-				buf.reset(nodes)
-				buf.sigma[int64(workerID*1000+j)] = float64(j)
-				buf.queue = append(buf.queue, int64(j))
+				buf.reset(1)
+				buf.sigma[0] = float64(j)
+				buf.queue = append(buf.queue, j)
 
-				brandesPool.Put(buf)
+				brandesPool.put(buf)
 			}
 		}(i)
 	}
 
 	wg.Wait()
-	t.Logf("PASS: Completed %d concurrent operations without race",
-		numGoroutines*iterationsPerGoroutine)
 }
 
 // TestBufferPoolLifecycle verifies correct Get/Put semantics
 func TestBufferPoolLifecycle(t *testing.T) {
-	t.Log("Testing buffer pool lifecycle...")
-
-	// Get a buffer
-	buf1 := brandesPool.Get().(*brandesBuffers)
+	buf1 := brandesPool.get(256)
 	if buf1 == nil {
-		t.Fatal("First Get returned nil")
+		t.Fatal("First get returned nil")
 	}
-
-	// Modify it
-	buf1.sigma[42] = 1.5
+	buf1.reset(1)
+	buf1.sigma[0] = 1.5
 	buf1.queue = append(buf1.queue, 100)
-	t.Logf("Modified buffer: sigma[42]=%v, queue=%v", buf1.sigma[42], buf1.queue)
-
-	// Return it
-	brandesPool.Put(buf1)
+	brandesPool.put(buf1)
 
-	// Get again - might be same buffer or new one
-	buf2 := brandesPool.Get().(*brandesBuffers)
+	buf2 := brandesPool.get(256)
 	if buf2 == nil {
-		t.Fatal("Second Get returned nil")
+		t.Fatal("Second get returned nil")
 	}
-
-	// Key invariant: no panic, no race
-	t.Logf("Got second buffer: sigma=%p", buf2.sigma)
-	brandesPool.Put(buf2)
-
-	t.Log("PASS: Pool lifecycle works correctly")
+	brandesPool.put(buf2)
 }
 
 // TestConcurrentPoolGetPut tests rapid Get/Put cycles
 func TestConcurrentPoolGetPut(t *testing.T) {
-	t.Log("Testing rapid concurrent Get/Put cycles...")
-
 	const cycles = 1000
 	const workers = 10
 
@@ -503,17 +377,72 @@ func TestConcurrentPoolGetPut(t *testing.T) {
 		go func() {
 			defer wg.Done()
 			for i := 0; i < cycles; i++ {
-				buf := brandesPool.Get().(*brandesBuffers)
+				buf := brandesPool.get(256)
 				if buf == nil {
 					t.Error("Got nil buffer")
 					return
 				}
-				// Immediately return
-				brandesPool.Put(buf)
+				brandesPool.put(buf)
 			}
 		}()
 	}
 
 	wg.Wait()
-	t.Logf("PASS: Completed %d rapid Get/Put cycles without race", cycles*workers)
+}
+
+// =============================================================================
+// Benchmarks
+// =============================================================================
+
+// benchScaleFreeGraph builds a directed scale-free-ish graph with n
+// nodes: each new node links back to a handful of earlier nodes chosen
+// with a bias toward already-popular ones (preferential attachment),
+// giving it the heavy-tailed degree distribution real dependency graphs
+// exhibit, unlike a uniform random graph.
+func benchScaleFreeGraph(n int) cachedAdjacency {
+	outgoing := make([][]int, n)
+	incoming := make([][]int, n)
+	targets := make([]int, 0, n*4) // grows with each node's in-degree, biasing future picks toward it
+
+	for v := 0; v < n; v++ {
+		links := 3
+		if links > v {
+			links = v
+		}
+		seen := make(map[int]bool, links)
+		for k := 0; k < links; k++ {
+			var w int
+			if len(targets) == 0 {
+				w = v - 1
+			} else {
+				w = targets[(v*2654435761+k)%len(targets)]
+			}
+			if w < 0 || w >= v || seen[w] {
+				continue
+			}
+			seen[w] = true
+			outgoing[v] = append(outgoing[v], w)
+			incoming[w] = append(incoming[w], v)
+			targets = append(targets, v, w)
+		}
+	}
+	return cachedAdjacency{outgoing: outgoing, incoming: incoming}
+}
+
+// BenchmarkSingleSourceBetweennessDense100kNodes measures the dense,
+// bucketed-pool Brandes path at a scale (100k nodes) representative of
+// the "large graph" case the capacity-bucketed brandesPool targets.
+// There is no remaining map-indexed implementation in this package to
+// compare against - it was fully replaced by dense indexing - so this
+// benchmark tracks the dense path's own cost over time instead.
+func BenchmarkSingleSourceBetweennessDense100kNodes(b *testing.B) {
+	const n = 100_000
+	adj := benchScaleFreeGraph(n)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf := brandesPool.get(n)
+		singleSourceBetweennessDense(adj, i%n, buf)
+		brandesPool.put(buf)
+	}
 }
@@ -0,0 +1,296 @@
+package analysis
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// CentralityCache persists CentralityResult values (which is how
+// ApproxBetweenness's BetweennessResult is stored too - see
+// cacheEntry) keyed by CentralityFingerprint, so a later session
+// analyzing the same beads graph with the same options can skip
+// recomputing it from scratch.
+type CentralityCache interface {
+	// Get returns the cached result for fingerprint and whether it was found.
+	Get(fingerprint CentralityFingerprint) (CentralityResult, bool)
+	// Put stores result under fingerprint, evicting the least recently
+	// accessed entry first if the cache is already at its size cap.
+	Put(fingerprint CentralityFingerprint, result CentralityResult) error
+	// Invalidate drops every cached entry for the graph identified by
+	// graphHash (CentralityFingerprint.GraphHash), regardless of which
+	// mode/sample/seed/strategy produced them.
+	Invalidate(graphHash [32]byte) error
+	// InvalidateAsync is Invalidate run from a background goroutine, for
+	// callers (a file watcher, IncrementalBetweenness's edit methods)
+	// that fire on graph mutation and shouldn't block on cache I/O.
+	InvalidateAsync(graphHash [32]byte)
+	// Close releases the cache's underlying storage.
+	Close() error
+}
+
+// centralityCacheBucket is the sole bbolt bucket BoltCentralityCache
+// uses; one flat keyspace is enough since CentralityFingerprint.Key
+// already groups a graph's entries under a common GraphHash prefix.
+const centralityCacheBucket = "centrality"
+
+// defaultCentralityCacheMaxEntries caps a fresh BoltCentralityCache
+// absent an explicit maxEntries, generous enough for many distinct
+// beads graphs (or sampling configurations of the same graph) without
+// letting the cache file grow unbounded across months of sessions.
+const defaultCentralityCacheMaxEntries = 512
+
+// cacheEntry is a CentralityFingerprint's on-disk, JSON-encoded value:
+// the result plus the access timestamp BoltCentralityCache's eviction
+// scan uses to find the least recently used entry.
+type cacheEntry struct {
+	Betweenness map[int64]float64 `json:"betweenness,omitempty"`
+	Closeness   map[int64]float64 `json:"closeness,omitempty"`
+	Harmonic    map[int64]float64 `json:"harmonic,omitempty"`
+	Reach       map[int64]int     `json:"reach,omitempty"`
+	Mode        BetweennessMode   `json:"mode"`
+	SampleSize  int               `json:"sample_size"`
+	TotalNodes  int               `json:"total_nodes"`
+	Elapsed     time.Duration     `json:"elapsed"`
+	AccessedAt  int64             `json:"accessed_at"` // UnixNano
+}
+
+func (e cacheEntry) toResult() CentralityResult {
+	return CentralityResult{
+		Betweenness: e.Betweenness,
+		Closeness:   e.Closeness,
+		Harmonic:    e.Harmonic,
+		Reach:       e.Reach,
+		Mode:        e.Mode,
+		SampleSize:  e.SampleSize,
+		TotalNodes:  e.TotalNodes,
+		Elapsed:     e.Elapsed,
+	}
+}
+
+func cacheEntryFromResult(result CentralityResult) cacheEntry {
+	return cacheEntry{
+		Betweenness: result.Betweenness,
+		Closeness:   result.Closeness,
+		Harmonic:    result.Harmonic,
+		Reach:       result.Reach,
+		Mode:        result.Mode,
+		SampleSize:  result.SampleSize,
+		TotalNodes:  result.TotalNodes,
+		Elapsed:     result.Elapsed,
+	}
+}
+
+// BoltCentralityCache is CentralityCache's default embedded-KV
+// implementation, backed by a single bbolt database file.
+//
+// Cross-process safety: OpenBoltCentralityCache takes the same
+// cross-process file lock lockFile/unlockFile protect (see
+// file_lock_unix.go / file_lock_windows.go) around opening the bbolt
+// file, so two beads_viewer processes racing to create the cache
+// directory or database for the first time can't corrupt it. Once
+// open, bbolt's own single-writer model protects concurrent Get/Put/
+// Invalidate calls from the same process.
+type BoltCentralityCache struct {
+	db         *bbolt.DB
+	maxEntries int
+}
+
+// DefaultCentralityCacheDir returns the directory BoltCentralityCache
+// uses absent an explicit path: the OS's per-user cache directory, in a
+// beads_viewer subdirectory - the same directory the instance package's
+// cross-process file lock lives in, so both subsystems agree on where
+// a viewer's on-disk state goes.
+func DefaultCentralityCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cacheDir, "beads_viewer")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// OpenBoltCentralityCache opens (creating if needed) a
+// BoltCentralityCache at dir/centrality.db, capped at maxEntries
+// entries. maxEntries <= 0 means defaultCentralityCacheMaxEntries.
+func OpenBoltCentralityCache(dir string, maxEntries int) (*BoltCentralityCache, error) {
+	if maxEntries <= 0 {
+		maxEntries = defaultCentralityCacheMaxEntries
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	lockPath := filepath.Join(dir, "centrality.lock")
+	lockHandle, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	defer lockHandle.Close()
+	if err := lockFile(lockHandle); err != nil {
+		return nil, err
+	}
+	defer unlockFile(lockHandle)
+
+	db, err := bbolt.Open(filepath.Join(dir, "centrality.db"), 0o644, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte(centralityCacheBucket))
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltCentralityCache{db: db, maxEntries: maxEntries}, nil
+}
+
+// Get implements CentralityCache. A hit bumps the entry's AccessedAt so
+// Put's eviction scan treats it as freshly used.
+func (c *BoltCentralityCache) Get(fingerprint CentralityFingerprint) (CentralityResult, bool) {
+	key := fingerprint.Key()
+	var entry cacheEntry
+	found := false
+
+	err := c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(centralityCacheBucket))
+		raw := bucket.Get(key)
+		if raw == nil {
+			return nil
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		found = true
+		entry.AccessedAt = time.Now().UnixNano()
+		updated, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(key, updated)
+	})
+	if err != nil || !found {
+		return CentralityResult{}, false
+	}
+	return entry.toResult(), true
+}
+
+// Put implements CentralityCache, evicting the least recently accessed
+// entry (by a linear scan of AccessedAt) if the store is at maxEntries
+// and key is new. maxEntries is deliberately small enough that an
+// O(n) scan per eviction is cheap next to the recomputation it's
+// saving.
+func (c *BoltCentralityCache) Put(fingerprint CentralityFingerprint, result CentralityResult) error {
+	key := fingerprint.Key()
+	entry := cacheEntryFromResult(result)
+	entry.AccessedAt = time.Now().UnixNano()
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(centralityCacheBucket))
+		if bucket.Get(key) == nil && bucket.Stats().KeyN >= c.maxEntries {
+			if err := evictLRU(bucket); err != nil {
+				return err
+			}
+		}
+		return bucket.Put(key, raw)
+	})
+}
+
+// evictLRU deletes the bucket entry with the oldest AccessedAt.
+func evictLRU(bucket *bbolt.Bucket) error {
+	var oldestKey []byte
+	var oldestAt int64
+
+	cursor := bucket.Cursor()
+	for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+		var entry cacheEntry
+		if err := json.Unmarshal(v, &entry); err != nil {
+			continue // corrupt entry: leave it for a future eviction pass rather than fail the whole Put
+		}
+		if oldestKey == nil || entry.AccessedAt < oldestAt {
+			oldestKey = append([]byte(nil), k...)
+			oldestAt = entry.AccessedAt
+		}
+	}
+	if oldestKey == nil {
+		return nil
+	}
+	return bucket.Delete(oldestKey)
+}
+
+// Invalidate implements CentralityCache by prefix-scanning for
+// graphHash: CentralityFingerprint.Key starts every key with GraphHash,
+// so every mode/sample/seed/strategy variant for this graph sorts
+// together and falls out of one cursor pass.
+func (c *BoltCentralityCache) Invalidate(graphHash [32]byte) error {
+	prefix := graphHash[:]
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(centralityCacheBucket))
+		cursor := bucket.Cursor()
+		var stale [][]byte
+		for k, _ := cursor.Seek(prefix); k != nil && hasPrefix(k, prefix); k, _ = cursor.Next() {
+			stale = append(stale, append([]byte(nil), k...))
+		}
+		for _, k := range stale {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// InvalidateAsync invalidates graphHash's entries from a background
+// goroutine instead of the caller's - meant for a watcher callback
+// firing on graph mutation, which shouldn't block on cache I/O to
+// deliver its notification.
+func (c *BoltCentralityCache) InvalidateAsync(graphHash [32]byte) {
+	go func() {
+		_ = c.Invalidate(graphHash) // best-effort: a stale entry just costs one future recompute
+	}()
+}
+
+// Close implements CentralityCache.
+func (c *BoltCentralityCache) Close() error {
+	return c.db.Close()
+}
+
+func hasPrefix(k, prefix []byte) bool {
+	if len(k) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if k[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultCentralityCache, if non-nil, is consulted by ApproxBetweenness
+// before it computes from scratch. It's nil until a caller opts in via
+// SetDefaultCentralityCache, matching pre-cache behavior by default.
+// Like brandesPool, this is process-wide shared state; callers are
+// expected to set it once at startup rather than swap it concurrently
+// with in-flight ApproxBetweenness calls.
+var defaultCentralityCache CentralityCache
+
+// SetDefaultCentralityCache installs (or, with nil, removes) the cache
+// ApproxBetweenness consults for its cache-lookup fast path. Typical
+// use is once at startup, with a BoltCentralityCache opened against
+// DefaultCentralityCacheDir.
+func SetDefaultCentralityCache(cache CentralityCache) {
+	defaultCentralityCache = cache
+}
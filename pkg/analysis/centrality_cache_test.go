@@ -0,0 +1,178 @@
+package analysis
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func openTestCache(t *testing.T, maxEntries int) *BoltCentralityCache {
+	t.Helper()
+	cache, err := OpenBoltCentralityCache(t.TempDir(), maxEntries)
+	if err != nil {
+		t.Fatalf("OpenBoltCentralityCache: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := cache.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	})
+	return cache
+}
+
+func TestBoltCentralityCacheMissThenHit(t *testing.T) {
+	cache := openTestCache(t, 10)
+	fp := CentralityFingerprint{GraphHash: [32]byte{1, 2, 3}, Mode: BetweennessApproximate, SampleSize: 8, Seed: 42}
+
+	if _, ok := cache.Get(fp); ok {
+		t.Fatal("expected a miss before any Put")
+	}
+
+	want := CentralityResult{Betweenness: map[int64]float64{1: 0.5, 2: 1.5}, Mode: BetweennessApproximate, SampleSize: 8, TotalNodes: 3}
+	if err := cache.Put(fp, want); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, ok := cache.Get(fp)
+	if !ok {
+		t.Fatal("expected a hit after Put")
+	}
+	if len(got.Betweenness) != len(want.Betweenness) {
+		t.Fatalf("expected %d scores, got %d", len(want.Betweenness), len(got.Betweenness))
+	}
+	for id, v := range want.Betweenness {
+		if got.Betweenness[id] != v {
+			t.Errorf("node %d: expected %v, got %v", id, v, got.Betweenness[id])
+		}
+	}
+}
+
+func TestBoltCentralityCacheDistinctFingerprintsDontCollide(t *testing.T) {
+	cache := openTestCache(t, 10)
+	fpA := CentralityFingerprint{GraphHash: [32]byte{1}, SampleSize: 8, Seed: 1}
+	fpB := CentralityFingerprint{GraphHash: [32]byte{1}, SampleSize: 8, Seed: 2}
+
+	if err := cache.Put(fpA, CentralityResult{Betweenness: map[int64]float64{1: 1}}); err != nil {
+		t.Fatalf("Put fpA: %v", err)
+	}
+	if _, ok := cache.Get(fpB); ok {
+		t.Error("expected a different seed to produce a distinct cache entry")
+	}
+}
+
+func TestBoltCentralityCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := openTestCache(t, 2)
+
+	fps := make([]CentralityFingerprint, 3)
+	for i := range fps {
+		fps[i] = CentralityFingerprint{GraphHash: [32]byte{byte(i + 1)}, SampleSize: 4}
+	}
+
+	if err := cache.Put(fps[0], CentralityResult{Betweenness: map[int64]float64{1: 1}}); err != nil {
+		t.Fatalf("Put 0: %v", err)
+	}
+	if err := cache.Put(fps[1], CentralityResult{Betweenness: map[int64]float64{1: 1}}); err != nil {
+		t.Fatalf("Put 1: %v", err)
+	}
+	// Touch fps[0] so it's more recently used than fps[1].
+	if _, ok := cache.Get(fps[0]); !ok {
+		t.Fatal("expected fps[0] to be cached")
+	}
+	if err := cache.Put(fps[2], CentralityResult{Betweenness: map[int64]float64{1: 1}}); err != nil {
+		t.Fatalf("Put 2: %v", err)
+	}
+
+	if _, ok := cache.Get(fps[1]); ok {
+		t.Error("expected fps[1] (least recently used) to have been evicted")
+	}
+	if _, ok := cache.Get(fps[0]); !ok {
+		t.Error("expected fps[0] (recently touched) to survive eviction")
+	}
+	if _, ok := cache.Get(fps[2]); !ok {
+		t.Error("expected fps[2] (just inserted) to be present")
+	}
+}
+
+func TestBoltCentralityCacheInvalidateDropsOnlyMatchingGraph(t *testing.T) {
+	cache := openTestCache(t, 10)
+	graphA := [32]byte{1}
+	graphB := [32]byte{2}
+
+	fpA1 := CentralityFingerprint{GraphHash: graphA, SampleSize: 4, Seed: 1}
+	fpA2 := CentralityFingerprint{GraphHash: graphA, SampleSize: 8, Seed: 2}
+	fpB := CentralityFingerprint{GraphHash: graphB, SampleSize: 4, Seed: 1}
+
+	for _, fp := range []CentralityFingerprint{fpA1, fpA2, fpB} {
+		if err := cache.Put(fp, CentralityResult{Betweenness: map[int64]float64{1: 1}}); err != nil {
+			t.Fatalf("Put %+v: %v", fp, err)
+		}
+	}
+
+	if err := cache.Invalidate(graphA); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+
+	if _, ok := cache.Get(fpA1); ok {
+		t.Error("expected fpA1 to be invalidated")
+	}
+	if _, ok := cache.Get(fpA2); ok {
+		t.Error("expected fpA2 to be invalidated")
+	}
+	if _, ok := cache.Get(fpB); !ok {
+		t.Error("expected fpB (different graph) to survive Invalidate")
+	}
+}
+
+func TestFingerprintGraphStableUnderInsertionOrder(t *testing.T) {
+	a := chainGraph(5)
+
+	// Same edges as chainGraph(5) (0->1->2->3->4), added in reverse order.
+	b := simple.NewDirectedGraph()
+	for i := 4; i >= 0; i-- {
+		b.AddNode(simple.Node(i))
+	}
+	for i := 3; i >= 0; i-- {
+		b.SetEdge(simple.Edge{F: simple.Node(i), T: simple.Node(i + 1)})
+	}
+
+	if FingerprintGraph(a) != FingerprintGraph(b) {
+		t.Error("expected FingerprintGraph to be independent of node/edge insertion order")
+	}
+}
+
+func TestFingerprintGraphChangesWithEdges(t *testing.T) {
+	a := chainGraph(5)
+	b := chainGraph(6)
+
+	if FingerprintGraph(a) == FingerprintGraph(b) {
+		t.Error("expected different edge lists to fingerprint differently")
+	}
+}
+
+func TestApproxBetweennessUsesDefaultCentralityCache(t *testing.T) {
+	cache := openTestCache(t, 10)
+	SetDefaultCentralityCache(cache)
+	t.Cleanup(func() { SetDefaultCentralityCache(nil) })
+
+	g := chainGraph(20)
+	first := ApproxBetweenness(g, 8, 42)
+
+	fp := CentralityFingerprint{GraphHash: FingerprintGraph(g), Mode: BetweennessApproximate, SampleSize: 8, Seed: 42, Strategy: SamplingUniform}
+	cached, ok := cache.Get(fp)
+	if !ok {
+		t.Fatal("expected ApproxBetweenness to populate the default cache")
+	}
+	if len(cached.Betweenness) != len(first.Scores) {
+		t.Fatalf("expected cached scores to match computed scores, got %d vs %d", len(cached.Betweenness), len(first.Scores))
+	}
+
+	second := ApproxBetweenness(g, 8, 42)
+	if len(second.Scores) != len(first.Scores) {
+		t.Fatalf("expected cache-hit result to match original, got %d vs %d", len(second.Scores), len(first.Scores))
+	}
+	for id, score := range first.Scores {
+		if second.Scores[id] != score {
+			t.Errorf("node %d: expected score %v from cache hit, got %v", id, score, second.Scores[id])
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"golang.org/x/crypto/blake2b"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// CentralityFingerprint identifies one cached computation: a specific
+// graph (by edge-list hash), computed with a specific mode, sample
+// size, seed, and sampling strategy. Two processes analyzing the same
+// beads graph with the same options always fingerprint identically, so
+// they share one CentralityCache entry regardless of node iteration
+// order.
+type CentralityFingerprint struct {
+	GraphHash  [32]byte
+	Mode       BetweennessMode
+	SampleSize int
+	Seed       int64
+	Strategy   BetweennessSamplingStrategy
+}
+
+// Key returns fingerprint's on-disk cache key: GraphHash followed by the
+// sampleSize/seed/mode/strategy fields. Keys for the same graph sort
+// adjacently (GraphHash is the common prefix), which is what lets
+// CentralityCache.Invalidate prefix-scan and drop every entry for a
+// mutated graph without decoding each one.
+func (fp CentralityFingerprint) Key() []byte {
+	var buf bytes.Buffer
+	buf.Write(fp.GraphHash[:])
+	_ = binary.Write(&buf, binary.BigEndian, int64(fp.SampleSize))
+	_ = binary.Write(&buf, binary.BigEndian, fp.Seed)
+	buf.WriteString(string(fp.Mode))
+	buf.WriteByte(0) // separator: Mode/Strategy are variable-length strings
+	buf.WriteString(string(fp.Strategy))
+	return buf.Bytes()
+}
+
+// fingerprintEdge is one (srcID, dstID) pair as fingerprintEdges hashes
+// it - factored out of FingerprintGraph so IncrementalBetweenness, which
+// doesn't retain the *simple.DirectedGraph it was built from, can
+// fingerprint its own index-mapped adjacency the same way.
+type fingerprintEdge struct{ src, dst int64 }
+
+// FingerprintGraph canonically hashes g's edge list with BLAKE2b: every
+// (srcID, dstID) pair, sorted ascending before hashing so the digest is
+// independent of gonum's map-backed node/edge iteration order. This is
+// the GraphHash half of a CentralityFingerprint.
+func FingerprintGraph(g *simple.DirectedGraph) [32]byte {
+	var edges []fingerprintEdge
+	nodes := g.Nodes()
+	for nodes.Next() {
+		src := nodes.Node().ID()
+		to := g.From(src)
+		for to.Next() {
+			edges = append(edges, fingerprintEdge{src: src, dst: to.Node().ID()})
+		}
+	}
+	return fingerprintEdges(edges)
+}
+
+// fingerprintEdges is FingerprintGraph's hash core: sort edges ascending
+// so the digest doesn't depend on iteration order, then BLAKE2b them.
+func fingerprintEdges(edges []fingerprintEdge) [32]byte {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].src != edges[j].src {
+			return edges[i].src < edges[j].src
+		}
+		return edges[i].dst < edges[j].dst
+	})
+
+	h, _ := blake2b.New256(nil) // nil key, unkeyed hash - error only possible with a bad key length
+	var scratch [16]byte
+	for _, e := range edges {
+		binary.BigEndian.PutUint64(scratch[:8], uint64(e.src))
+		binary.BigEndian.PutUint64(scratch[8:], uint64(e.dst))
+		h.Write(scratch[:])
+	}
+
+	var sum [32]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
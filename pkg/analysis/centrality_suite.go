@@ -0,0 +1,324 @@
+package analysis
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// CentralityKinds is a bitmask selecting which centrality measures
+// ComputeCentralities computes together from the same pivot sampling and
+// BFS traversal.
+type CentralityKinds uint8
+
+const (
+	// KindBetweenness requests Brandes' betweenness contribution,
+	// accumulated and extrapolated exactly like
+	// ApproxBetweennessWithOptions.
+	KindBetweenness CentralityKinds = 1 << iota
+	// KindCloseness requests closeness as reachable-count divided by the
+	// sum of distances to those nodes (not scaled by the reachable
+	// fraction of the graph, as Wasserman-Faust closeness is), which stays
+	// meaningful on a graph a single BFS can't reach entirely.
+	KindCloseness
+	// KindHarmonic requests harmonic centrality: the sum of 1/distance
+	// to every reachable node, which (unlike closeness) degrades
+	// gracefully as reach shrinks instead of concentrating around a
+	// single distant node.
+	KindHarmonic
+	// KindReach requests the count of nodes reachable from each pivot.
+	KindReach
+)
+
+// has reports whether want includes kind.
+func (want CentralityKinds) has(kind CentralityKinds) bool {
+	return want&kind != 0
+}
+
+// CentralityResult is ComputeCentralities' combined result. Only the
+// maps for kinds present in the CentralityKinds passed to
+// ComputeCentralities are populated; the others are left nil.
+//
+// Betweenness covers every node in the graph, extrapolated from the
+// sampled pivots exactly like ApproxBetweennessWithOptions's result.
+// Closeness, Harmonic, and Reach are each a pivot's own forward BFS
+// measuring itself - exact for that pivot, with no extrapolation
+// needed - so they're only present for nodes that were actually
+// sampled as pivots.
+type CentralityResult struct {
+	Betweenness map[int64]float64
+	Closeness   map[int64]float64
+	Harmonic    map[int64]float64
+	Reach       map[int64]int
+
+	// Mode indicates whether SampleSize covered every node (BetweennessExact)
+	// or a subsample (BetweennessApproximate).
+	Mode BetweennessMode
+
+	// SampleSize is the number of pivot nodes BFS ran from.
+	SampleSize int
+
+	// TotalNodes is the total number of nodes in the graph.
+	TotalNodes int
+
+	// Elapsed is the time taken to compute.
+	Elapsed time.Duration
+
+	// Annotations records structured, machine-readable notes about how
+	// this result was computed, e.g. that it's a pivot-sampled
+	// approximation rather than an exhaustive pass. See Annotation.
+	Annotations Annotations
+}
+
+// pivotCentrality holds one pivot's own closeness/harmonic/reach,
+// gathered during its BFS and reported back to ComputeCentralities by ID.
+type pivotCentrality struct {
+	id        int64
+	closeness float64
+	harmonic  float64
+	reach     int
+}
+
+// ComputeCentralities computes one or more centrality measures for g in
+// a single pass of pivot sampling and BFS, instead of a separate O(V*E)
+// traversal per measure. opts is the same BetweennessOptions
+// ApproxBetweennessWithOptions takes - pivot sampling, pooling, and
+// worker pipeline configuration are shared between the two entry
+// points; opts.SampleSize and opts.Seed select the sample the way they
+// do for ApproxBetweennessCtx.
+//
+// Each worker's BFS accumulates Brandes' sigma/delta bookkeeping only
+// when want includes KindBetweenness; otherwise it reads closeness,
+// harmonic, and reach straight off the BFS distance array it already
+// had to build, essentially for free. See singleSourceCentralityDense.
+func ComputeCentralities(g *simple.DirectedGraph, want CentralityKinds, opts BetweennessOptions) CentralityResult {
+	start := time.Now()
+	sampleSize := opts.SampleSize
+	if sampleSize < 1 {
+		sampleSize = 1
+	}
+
+	nodes := pooledNodesOf(g.Nodes())
+	defer putPooledNodes(nodes)
+	n := len(nodes)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+
+	result := CentralityResult{
+		Mode:       BetweennessApproximate,
+		SampleSize: sampleSize,
+		TotalNodes: n,
+	}
+	if want.has(KindBetweenness) {
+		result.Betweenness = make(map[int64]float64)
+	}
+	if want.has(KindCloseness) {
+		result.Closeness = make(map[int64]float64)
+	}
+	if want.has(KindHarmonic) {
+		result.Harmonic = make(map[int64]float64)
+	}
+	if want.has(KindReach) {
+		result.Reach = make(map[int64]int)
+	}
+
+	if n == 0 || want == 0 {
+		result.Elapsed = time.Since(start)
+		return result
+	}
+
+	if sampleSize > n {
+		sampleSize = n
+	}
+	if sampleSize >= n {
+		result.Mode = BetweennessExact
+	}
+	result.SampleSize = sampleSize
+
+	idx := buildDenseIndex(nodes)
+	adj := buildCachedAdjacency(g, idx)
+	if idx.idToIdx != nil {
+		denseIndexMapPool.Put(idx.idToIdx)
+		idx.idToIdx = nil
+	}
+
+	var pivots []int
+	if sampleSize >= n {
+		pivots = make([]int, n)
+		for i := range pivots {
+			pivots[i] = i
+		}
+	} else {
+		switch opts.strategy() {
+		case SamplingStructural:
+			pivots = sampleIndicesStructural(adj, sampleSize, opts.Seed, true)
+		case SamplingDegreeWeighted:
+			pivots = sampleIndicesDegreeWeighted(adj, sampleSize, opts.Seed)
+		default:
+			pivots = sampleIndices(n, sampleSize, opts.Seed)
+		}
+	}
+
+	depth := opts.pipelineDepth()
+	chunkSize := opts.chunkSize()
+
+	chunks := make(chan sourceChunk, depth)
+	go func() {
+		defer close(chunks)
+		for i := 0; i < len(pivots); i += chunkSize {
+			end := i + chunkSize
+			if end > len(pivots) {
+				end = len(pivots)
+			}
+			chunks <- sourceChunk{pivots: pivots[i:end]}
+		}
+	}()
+
+	wantPivotStats := want.has(KindCloseness) || want.has(KindHarmonic) || want.has(KindReach)
+
+	mergedBC := make(map[int64]float64, n)
+	var pivotStats []pivotCentrality
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < depth; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			buf := brandesPool.get(n)
+			defer brandesPool.put(buf)
+
+			localBC := make(map[int64]float64)
+			var localStats []pivotCentrality
+			for chunk := range chunks {
+				for _, sourceIdx := range chunk.pivots {
+					closeness, harmonic, reach := singleSourceCentralityDense(adj, sourceIdx, buf, want)
+					if want.has(KindBetweenness) {
+						for _, w := range buf.stack {
+							localBC[idx.idxToID[w]] += buf.bc[w]
+						}
+					}
+					if wantPivotStats {
+						localStats = append(localStats, pivotCentrality{
+							id:        idx.idxToID[sourceIdx],
+							closeness: closeness,
+							harmonic:  harmonic,
+							reach:     reach,
+						})
+					}
+				}
+			}
+
+			mu.Lock()
+			for id, v := range localBC {
+				mergedBC[id] += v
+			}
+			pivotStats = append(pivotStats, localStats...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if want.has(KindBetweenness) {
+		scale := float64(n) / float64(sampleSize)
+		for id, val := range mergedBC {
+			if val == 0 {
+				continue
+			}
+			result.Betweenness[id] = val * scale
+		}
+	}
+	for _, stat := range pivotStats {
+		if want.has(KindCloseness) {
+			result.Closeness[stat.id] = stat.closeness
+		}
+		if want.has(KindHarmonic) {
+			result.Harmonic[stat.id] = stat.harmonic
+		}
+		if want.has(KindReach) {
+			result.Reach[stat.id] = stat.reach
+		}
+	}
+
+	result.Elapsed = time.Since(start)
+	if result.Mode == BetweennessApproximate {
+		result.Annotations = append(result.Annotations, pivotApproximationAnnotation(result.SampleSize, result.TotalNodes))
+	}
+	return result
+}
+
+// singleSourceCentralityDense runs one pivot's forward BFS and returns
+// its own closeness, harmonic, and reach - computed straight from the
+// BFS distance array - alongside (via buf.stack/buf.bc) Brandes'
+// betweenness contribution to every other node, when want includes
+// KindBetweenness. Skipping the sigma/predecessor/accumulation
+// bookkeeping entirely when betweenness isn't requested keeps a
+// closeness-or-harmonic-only call down to a plain BFS.
+func singleSourceCentralityDense(adj cachedAdjacency, sourceIdx int, buf *brandesBuffers, want CentralityKinds) (closeness, harmonic float64, reach int) {
+	nodeCount := len(adj.outgoing)
+	if nodeCount == 0 {
+		return 0, 0, 0
+	}
+
+	buf.reset(nodeCount)
+	needBC := want.has(KindBetweenness)
+
+	sigma := buf.sigma
+	dist := buf.dist
+	delta := buf.delta
+	pred := buf.pred
+
+	sigma[sourceIdx] = 1
+	dist[sourceIdx] = 0
+	buf.queue = append(buf.queue, sourceIdx)
+
+	for len(buf.queue) > 0 {
+		v := buf.queue[0]
+		buf.queue = buf.queue[1:]
+		buf.stack = append(buf.stack, v)
+
+		for _, w := range adj.outgoing[v] {
+			if dist[w] < 0 {
+				dist[w] = dist[v] + 1
+				buf.queue = append(buf.queue, w)
+			}
+			if needBC && dist[w] == dist[v]+1 {
+				sigma[w] += sigma[v]
+				pred[w] = append(pred[w], v)
+			}
+		}
+	}
+
+	var sumDist float64
+	reachCount := 0
+	for _, d := range dist {
+		if d <= 0 {
+			continue // d == -1: unreached. d == 0: the source itself.
+		}
+		reachCount++
+		sumDist += float64(d)
+		harmonic += 1 / float64(d)
+	}
+	reach = reachCount
+	if reachCount > 0 && sumDist > 0 {
+		closeness = float64(reachCount) / sumDist
+	}
+
+	if needBC {
+		for i := len(buf.stack) - 1; i >= 0; i-- {
+			w := buf.stack[i]
+			if w == sourceIdx {
+				continue
+			}
+			for _, v := range pred[w] {
+				if sigma[w] > 0 {
+					delta[v] += (sigma[v] / sigma[w]) * (1 + delta[w])
+				}
+			}
+			buf.bc[w] += delta[w]
+		}
+	}
+
+	return closeness, harmonic, reach
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import "testing"
+
+func TestComputeCentralitiesBetweennessMatchesApproxBetweenness(t *testing.T) {
+	g := chainGraph(20)
+
+	plain := ApproxBetweennessWithOptions(g, 8, 42, BetweennessOptions{})
+	suite := ComputeCentralities(g, KindBetweenness, BetweennessOptions{SampleSize: 8, Seed: 42})
+
+	if suite.Closeness != nil || suite.Harmonic != nil || suite.Reach != nil {
+		t.Fatalf("expected only Betweenness populated, got %+v", suite)
+	}
+	if len(suite.Betweenness) != len(plain.Scores) {
+		t.Fatalf("expected same score count, got %d vs %d", len(suite.Betweenness), len(plain.Scores))
+	}
+	for id, score := range plain.Scores {
+		if suite.Betweenness[id] != score {
+			t.Errorf("node %d: expected score %v, got %v", id, score, suite.Betweenness[id])
+		}
+	}
+}
+
+func TestComputeCentralitiesClosenessHarmonicReachOnChain(t *testing.T) {
+	// 0->1->2->3->4
+	g := chainGraph(5)
+
+	got := ComputeCentralities(g, KindCloseness|KindHarmonic|KindReach, BetweennessOptions{SampleSize: 5})
+	if got.Betweenness != nil {
+		t.Fatalf("expected Betweenness left nil, got %v", got.Betweenness)
+	}
+	if got.Mode != BetweennessExact {
+		t.Errorf("expected exact mode when sampling every node, got %v", got.Mode)
+	}
+
+	cases := []struct {
+		id        int64
+		reach     int
+		closeness float64
+		harmonic  float64
+	}{
+		{id: 0, reach: 4, closeness: 4.0 / 10.0, harmonic: 1 + 1.0/2 + 1.0/3 + 1.0/4},
+		{id: 2, reach: 2, closeness: 2.0 / 3.0, harmonic: 1 + 1.0/2},
+		{id: 4, reach: 0, closeness: 0, harmonic: 0},
+	}
+	for _, c := range cases {
+		if got.Reach[c.id] != c.reach {
+			t.Errorf("node %d: expected reach %d, got %d", c.id, c.reach, got.Reach[c.id])
+		}
+		if diff := got.Closeness[c.id] - c.closeness; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("node %d: expected closeness %v, got %v", c.id, c.closeness, got.Closeness[c.id])
+		}
+		if diff := got.Harmonic[c.id] - c.harmonic; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("node %d: expected harmonic %v, got %v", c.id, c.harmonic, got.Harmonic[c.id])
+		}
+	}
+}
+
+func TestComputeCentralitiesNoKindsRequestedIsNoOp(t *testing.T) {
+	g := chainGraph(10)
+	got := ComputeCentralities(g, 0, BetweennessOptions{SampleSize: 4})
+	if got.Betweenness != nil || got.Closeness != nil || got.Harmonic != nil || got.Reach != nil {
+		t.Errorf("expected all maps nil when no kinds requested, got %+v", got)
+	}
+}
+
+func TestComputeCentralitiesEmptyGraph(t *testing.T) {
+	g := chainGraph(0)
+	got := ComputeCentralities(g, KindBetweenness|KindCloseness, BetweennessOptions{SampleSize: 4})
+	if got.TotalNodes != 0 || len(got.Betweenness) != 0 || len(got.Closeness) != 0 {
+		t.Errorf("expected empty result for empty graph, got %+v", got)
+	}
+}
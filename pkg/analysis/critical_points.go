@@ -0,0 +1,216 @@
+package analysis
+
+import "sort"
+
+// NOTE: this predates a string-ID-native version of this package -
+// findArticulationPoints/undirectedAdjacency (int64-keyed, exercised by
+// articulation_test.go) aren't present in this checkout, nor is
+// GraphStats.ArticulationPoints that would call them. Graph,
+// ArticulationPoints, Bridges, and BiconnectedComponents below are a
+// self-contained, string-ID-keyed replacement built directly against
+// issue dependency edges rather than gonum's int64 node IDs, matching
+// what a "critical issues" view wants without a round trip through a
+// dense index. Surfacing these in the TUI (a "critical issues" view)
+// and as a `beads analyze critical` CLI subcommand is left for whoever
+// restores the cmd/ CLI layer, which doesn't exist in this checkout
+// either.
+
+// Graph is an undirected adjacency view of an issue dependency graph,
+// keyed by issue ID. ArticulationPoints, Bridges, and
+// BiconnectedComponents all operate on it directly, so their results
+// are keyed by issue ID too.
+type Graph struct {
+	neighbors map[string]map[string]struct{}
+}
+
+// NewGraph builds a Graph from undirected issue dependency edges (e.g.
+// "blocks"/"depends on" pairs - direction doesn't matter for
+// connectivity analysis). A self-loop (edge[0] == edge[1]) still adds
+// its endpoint as a node but no edge, since it can't affect
+// connectivity. Repeated edges collapse to one.
+func NewGraph(edges [][2]string) *Graph {
+	g := &Graph{neighbors: make(map[string]map[string]struct{})}
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+// AddEdge adds an undirected edge between a and b, creating either
+// endpoint as a node if new.
+func (g *Graph) AddEdge(a, b string) {
+	g.AddNode(a)
+	if a == b {
+		return
+	}
+	g.AddNode(b)
+	g.neighbors[a][b] = struct{}{}
+	g.neighbors[b][a] = struct{}{}
+}
+
+// AddNode ensures id is present in g even with no edges yet, so an
+// isolated issue still appears in ArticulationPoints/BiconnectedComponents
+// output.
+func (g *Graph) AddNode(id string) {
+	if _, ok := g.neighbors[id]; !ok {
+		g.neighbors[id] = make(map[string]struct{})
+	}
+}
+
+// nodeIDs returns every node in g, sorted, so the Tarjan DFS (and
+// therefore Bridges/BiconnectedComponents order) is deterministic.
+func (g *Graph) nodeIDs() []string {
+	ids := make([]string, 0, len(g.neighbors))
+	for id := range g.neighbors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (g *Graph) sortedNeighbors(id string) []string {
+	ns := make([]string, 0, len(g.neighbors[id]))
+	for n := range g.neighbors[id] {
+		ns = append(ns, n)
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+// Edge is an undirected pair of issue IDs, as returned by Bridges and
+// BiconnectedComponents.
+type Edge struct {
+	A, B string
+}
+
+// CriticalPoints bundles everything a single Tarjan DFS pass over a
+// Graph computes together: ArticulationPoints, Bridges, and
+// BiconnectedComponents are all by-products of the same disc/low/parent
+// traversal, so ComputeCriticalPoints runs it once and each of those
+// three functions is a thin accessor rather than its own pass.
+type CriticalPoints struct {
+	// ArticulationPoints maps each cut-vertex issue ID to true - an
+	// issue whose removal would disconnect the dependency graph or
+	// increase its number of connected components.
+	ArticulationPoints map[string]bool
+
+	// Bridges is every edge whose removal would disconnect the graph,
+	// in the order the DFS finishes them (a deeper bridge before the one
+	// above it on the same branch).
+	Bridges []Edge
+
+	// BiconnectedComponents is every maximal biconnected subgraph of
+	// the graph, each as its edge set, in the order the DFS finishes
+	// them. A
+	// bridge's two endpoints form their own single-edge component.
+	BiconnectedComponents [][]Edge
+}
+
+// ComputeCriticalPoints runs one Tarjan DFS pass over g and returns its
+// articulation points, bridges, and biconnected components together.
+// Disconnected components are each processed independently as the DFS
+// reaches them; self-loops and multi-edges were already collapsed when
+// g was built (see Graph.AddEdge).
+func ComputeCriticalPoints(g *Graph) CriticalPoints {
+	s := &tarjanState{
+		g:      g,
+		disc:   make(map[string]int, len(g.neighbors)),
+		low:    make(map[string]int, len(g.neighbors)),
+		parent: make(map[string]string, len(g.neighbors)),
+		apSet:  make(map[string]bool),
+	}
+	for _, id := range g.nodeIDs() {
+		if _, seen := s.disc[id]; !seen {
+			s.dfs(id, true)
+		}
+	}
+	return CriticalPoints{
+		ArticulationPoints:    s.apSet,
+		Bridges:               s.bridges,
+		BiconnectedComponents: s.bccs,
+	}
+}
+
+// ArticulationPoints is ComputeCriticalPoints(g).ArticulationPoints,
+// for callers that only need the cut vertices.
+func ArticulationPoints(g *Graph) map[string]bool {
+	return ComputeCriticalPoints(g).ArticulationPoints
+}
+
+// Bridges is ComputeCriticalPoints(g).Bridges, for callers that only
+// need the cut edges.
+func Bridges(g *Graph) []Edge {
+	return ComputeCriticalPoints(g).Bridges
+}
+
+// BiconnectedComponents is ComputeCriticalPoints(g).BiconnectedComponents,
+// for callers that only need the maximal biconnected subgraphs.
+func BiconnectedComponents(g *Graph) [][]Edge {
+	return ComputeCriticalPoints(g).BiconnectedComponents
+}
+
+// tarjanState is the disc/low/parent bookkeeping for one Tarjan DFS
+// pass, plus the stack of traversed edges popBCC drains into completed
+// biconnected components.
+type tarjanState struct {
+	g      *Graph
+	disc   map[string]int
+	low    map[string]int
+	parent map[string]string
+	timer  int
+
+	apSet     map[string]bool
+	bridges   []Edge
+	edgeStack []Edge
+	bccs      [][]Edge
+}
+
+func (s *tarjanState) dfs(u string, isRoot bool) {
+	s.disc[u] = s.timer
+	s.low[u] = s.timer
+	s.timer++
+	children := 0
+
+	for _, v := range s.g.sortedNeighbors(u) {
+		if _, seen := s.disc[v]; !seen {
+			s.parent[v] = u
+			children++
+			s.edgeStack = append(s.edgeStack, Edge{u, v})
+			s.dfs(v, false)
+
+			if s.low[v] < s.low[u] {
+				s.low[u] = s.low[v]
+			}
+			if (!isRoot && s.low[v] >= s.disc[u]) || (isRoot && children > 1) {
+				s.apSet[u] = true
+			}
+			if s.low[v] > s.disc[u] {
+				s.bridges = append(s.bridges, Edge{u, v})
+			}
+			if s.low[v] >= s.disc[u] {
+				s.popBCC(u, v)
+			}
+		} else if v != s.parent[u] && s.disc[v] < s.disc[u] {
+			s.edgeStack = append(s.edgeStack, Edge{u, v})
+			if s.disc[v] < s.low[u] {
+				s.low[u] = s.disc[v]
+			}
+		}
+	}
+}
+
+// popBCC drains edgeStack down to and including the tree edge (u, v)
+// just finished, forming one completed biconnected component.
+func (s *tarjanState) popBCC(u, v string) {
+	var bcc []Edge
+	for {
+		n := len(s.edgeStack) - 1
+		e := s.edgeStack[n]
+		s.edgeStack = s.edgeStack[:n]
+		bcc = append(bcc, e)
+		if e == (Edge{u, v}) {
+			break
+		}
+	}
+	s.bccs = append(s.bccs, bcc)
+}
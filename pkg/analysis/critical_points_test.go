@@ -0,0 +1,142 @@
+package analysis
+
+import "testing"
+
+func edgeSet(edges []Edge) map[Edge]bool {
+	set := make(map[Edge]bool, len(edges))
+	for _, e := range edges {
+		if e.A > e.B {
+			e.A, e.B = e.B, e.A
+		}
+		set[e] = true
+	}
+	return set
+}
+
+func TestArticulationPointsChain(t *testing.T) {
+	g := NewGraph([][2]string{{"a", "b"}, {"b", "c"}})
+
+	ap := ArticulationPoints(g)
+	if !ap["b"] {
+		t.Fatalf("expected b to be an articulation point, got %v", ap)
+	}
+	if ap["a"] || ap["c"] {
+		t.Fatalf("endpoints should not be articulation points: %v", ap)
+	}
+}
+
+func TestArticulationPointsCycleHasNone(t *testing.T) {
+	g := NewGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}})
+
+	ap := ArticulationPoints(g)
+	if len(ap) != 0 {
+		t.Fatalf("a simple cycle should have no articulation points, got %v", ap)
+	}
+}
+
+func TestBridgesChain(t *testing.T) {
+	g := NewGraph([][2]string{{"a", "b"}, {"b", "c"}})
+
+	got := edgeSet(Bridges(g))
+	want := edgeSet([]Edge{{A: "a", B: "b"}, {A: "b", B: "c"}})
+	if len(got) != len(want) {
+		t.Fatalf("got bridges %v, want %v", got, want)
+	}
+	for e := range want {
+		if !got[e] {
+			t.Fatalf("missing expected bridge %v in %v", e, got)
+		}
+	}
+}
+
+func TestBridgesCycleHasNone(t *testing.T) {
+	g := NewGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}})
+
+	if bridges := Bridges(g); len(bridges) != 0 {
+		t.Fatalf("a simple cycle should have no bridges, got %v", bridges)
+	}
+}
+
+func TestBiconnectedComponentsBridgeAndCycle(t *testing.T) {
+	// a-b-c forms a cycle (one BCC), c-d is a bridge (its own BCC).
+	g := NewGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}})
+
+	bccs := BiconnectedComponents(g)
+	if len(bccs) != 2 {
+		t.Fatalf("expected 2 biconnected components, got %d: %v", len(bccs), bccs)
+	}
+
+	var sawCycle, sawBridge bool
+	for _, bcc := range bccs {
+		switch len(bcc) {
+		case 3:
+			sawCycle = true
+		case 1:
+			sawBridge = true
+			if bcc[0] != (Edge{A: "c", B: "d"}) && bcc[0] != (Edge{A: "d", B: "c"}) {
+				t.Fatalf("unexpected single-edge component %v", bcc[0])
+			}
+		default:
+			t.Fatalf("unexpected biconnected component size %d: %v", len(bcc), bcc)
+		}
+	}
+	if !sawCycle || !sawBridge {
+		t.Fatalf("expected one 3-edge cycle component and one 1-edge bridge component, got %v", bccs)
+	}
+}
+
+func TestGraphDisconnectedComponentsProcessedIndependently(t *testing.T) {
+	g := NewGraph([][2]string{{"a", "b"}, {"b", "c"}, {"x", "y"}, {"y", "z"}})
+
+	ap := ArticulationPoints(g)
+	if !ap["b"] || !ap["y"] {
+		t.Fatalf("expected b and y to be articulation points in their own components, got %v", ap)
+	}
+	if len(ap) != 2 {
+		t.Fatalf("expected exactly 2 articulation points across both components, got %v", ap)
+	}
+}
+
+func TestGraphSelfLoopIgnored(t *testing.T) {
+	g := NewGraph([][2]string{{"a", "a"}, {"a", "b"}})
+
+	if ap := ArticulationPoints(g); ap["a"] {
+		t.Fatalf("a self-loop should not make a an articulation point: %v", ap)
+	}
+	if bridges := Bridges(g); len(bridges) != 1 {
+		t.Fatalf("expected exactly 1 bridge (a-b), got %v", bridges)
+	}
+}
+
+func TestGraphMultiEdgeCollapsed(t *testing.T) {
+	g := NewGraph([][2]string{{"a", "b"}, {"a", "b"}, {"b", "a"}})
+
+	if bridges := Bridges(g); len(bridges) != 1 {
+		t.Fatalf("duplicate edges should collapse to 1 bridge, got %v", bridges)
+	}
+}
+
+func TestGraphHandlesEmptyStringID(t *testing.T) {
+	// Mirrors the zero-ID sentinel-safety concern from the int64-keyed
+	// original: an empty-string issue ID must be treated like any other
+	// node, not as "no ID".
+	g := NewGraph([][2]string{{"", "a"}, {"a", "b"}})
+
+	ap := ArticulationPoints(g)
+	if !ap["a"] {
+		t.Fatalf("expected a to be an articulation point, got %v", ap)
+	}
+	if ap[""] || ap["b"] {
+		t.Fatalf("endpoints should not be articulation points: %v", ap)
+	}
+}
+
+func TestGraphAddNodeIsolated(t *testing.T) {
+	g := NewGraph(nil)
+	g.AddNode("solo")
+
+	ap := ArticulationPoints(g)
+	if ap["solo"] {
+		t.Fatalf("an isolated node should never be an articulation point: %v", ap)
+	}
+}
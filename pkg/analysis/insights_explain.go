@@ -0,0 +1,331 @@
+package analysis
+
+import "sort"
+
+// maxExplanationSources bounds how many source nodes are sampled when
+// explaining a bottleneck, since a full all-pairs BFS is O(n^2) and
+// explanations are meant to be computed on-demand for a handful of
+// top-ranked nodes, not on every analysis pass.
+const maxExplanationSources = 64
+
+// PathPair is a (From, To) pair whose shortest path is forced through a
+// bottleneck node.
+type PathPair struct {
+	From string
+	To   string
+}
+
+// BottleneckReason explains why a node scored highly on betweenness: the
+// sampled shortest-path pairs whose path is forced through it.
+type BottleneckReason struct {
+	ThroughPairs []PathPair
+}
+
+// KeystoneReason explains why removing a node would be damaging: the
+// concrete downstream nodes that become unreachable from the graph's roots
+// once the node is removed.
+type KeystoneReason struct {
+	UnreachableIfRemoved []string
+}
+
+// ArticulationReason explains which biconnected components would fragment
+// if a cut vertex were removed, represented as the sorted IDs of every
+// node on each side of the cut.
+type ArticulationReason struct {
+	FragmentedComponents [][]string
+}
+
+// CoreReason explains a node's k-core participation: its core number and
+// the neighbors that co-participate in that core.
+type CoreReason struct {
+	CoreNumber    int
+	CoreNeighbors []string
+}
+
+// ExplanationOptions bounds how much explanatory detail
+// GenerateInsightsWithExplanations computes per item, since path-recording
+// and reachability deltas are more expensive than the bare metrics.
+type ExplanationOptions struct {
+	MaxPairsPerBottleneck     int
+	MaxUnreachablePerKeystone int
+}
+
+// DefaultExplanationOptions returns sane bounds for interactive UI use.
+func DefaultExplanationOptions() ExplanationOptions {
+	return ExplanationOptions{
+		MaxPairsPerBottleneck:     5,
+		MaxUnreachablePerKeystone: 10,
+	}
+}
+
+// InsightsExplained mirrors Insights but carries a Reason for each item in
+// the metrics that support one. Everything else is identical to Insights.
+type InsightsExplained struct {
+	Insights
+
+	BottleneckReasons   map[string]BottleneckReason
+	KeystoneReasons     map[string]KeystoneReason
+	ArticulationReasons map[string]ArticulationReason
+	CoreReasons         map[string]CoreReason
+}
+
+// GenerateInsightsWithExplanations behaves like GenerateInsights but also
+// attaches a structured Reason to each Bottleneck/Keystone/Articulation/Core
+// entry, so a UI can render "node X is critical because it blocks {A, B, C}"
+// without recomputing the graph itself.
+func (s *GraphStats) GenerateInsightsWithExplanations(limit int, opts ExplanationOptions) InsightsExplained {
+	base := s.GenerateInsights(limit)
+
+	result := InsightsExplained{
+		Insights:            base,
+		BottleneckReasons:   make(map[string]BottleneckReason, len(base.Bottlenecks)),
+		KeystoneReasons:     make(map[string]KeystoneReason, len(base.Keystones)),
+		ArticulationReasons: make(map[string]ArticulationReason, len(base.Articulation)),
+		CoreReasons:         make(map[string]CoreReason, len(base.Cores)),
+	}
+
+	roots := s.rootIDs()
+
+	for _, item := range base.Bottlenecks {
+		result.BottleneckReasons[item.ID] = s.explainBottleneck(item.ID, opts.MaxPairsPerBottleneck)
+	}
+	for _, item := range base.Keystones {
+		result.KeystoneReasons[item.ID] = s.explainKeystone(item.ID, roots, opts.MaxUnreachablePerKeystone)
+	}
+	for _, id := range base.Articulation {
+		result.ArticulationReasons[id] = s.explainArticulation(id)
+	}
+	for _, item := range base.Cores {
+		result.CoreReasons[item.ID] = CoreReason{
+			CoreNumber:    int(item.Value),
+			CoreNeighbors: s.coreNeighbors(item.ID, int(item.Value)),
+		}
+	}
+
+	return result
+}
+
+// rootIDs returns the IDs with no predecessors, used as traversal roots
+// for reachability-delta computations.
+func (s *GraphStats) rootIDs() []string {
+	var roots []string
+	for id := range s.OutDegree {
+		if len(s.Predecessors(id)) == 0 {
+			roots = append(roots, id)
+		}
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// explainBottleneck samples source nodes and, for each, compares a normal
+// BFS against a BFS that skips id: any destination that becomes farther
+// away or unreachable had id on every shortest path from that source, so
+// the pair is attributed to id's betweenness score.
+func (s *GraphStats) explainBottleneck(id string, maxPairs int) BottleneckReason {
+	if maxPairs <= 0 {
+		return BottleneckReason{}
+	}
+
+	type candidate struct {
+		pair PathPair
+		gap  int // distance increase; a very large sentinel means "unreachable"
+	}
+	var candidates []candidate
+
+	for _, source := range s.sampleSources(maxExplanationSources) {
+		if source == id {
+			continue
+		}
+		withNode := bfsDistances(s, source, "")
+		withoutNode := bfsDistances(s, source, id)
+
+		for dest, dist := range withNode {
+			if dest == id || dest == source {
+				continue
+			}
+			altDist, reachable := withoutNode[dest]
+			if !reachable {
+				candidates = append(candidates, candidate{pair: PathPair{From: source, To: dest}, gap: 1 << 30})
+				continue
+			}
+			if altDist > dist {
+				candidates = append(candidates, candidate{pair: PathPair{From: source, To: dest}, gap: altDist - dist})
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].gap != candidates[j].gap {
+			return candidates[i].gap > candidates[j].gap
+		}
+		if candidates[i].pair.From != candidates[j].pair.From {
+			return candidates[i].pair.From < candidates[j].pair.From
+		}
+		return candidates[i].pair.To < candidates[j].pair.To
+	})
+
+	if len(candidates) > maxPairs {
+		candidates = candidates[:maxPairs]
+	}
+
+	pairs := make([]PathPair, len(candidates))
+	for i, c := range candidates {
+		pairs[i] = c.pair
+	}
+	return BottleneckReason{ThroughPairs: pairs}
+}
+
+// explainKeystone compares what's reachable from roots with and without
+// id, reporting the nodes that fall out of the reachable set.
+func (s *GraphStats) explainKeystone(id string, roots []string, maxUnreachable int) KeystoneReason {
+	before := reachableFrom(s, roots, "")
+	after := reachableFrom(s, roots, id)
+
+	var unreachable []string
+	for node := range before {
+		if node == id {
+			continue
+		}
+		if !after[node] {
+			unreachable = append(unreachable, node)
+		}
+	}
+	sort.Strings(unreachable)
+	if maxUnreachable > 0 && len(unreachable) > maxUnreachable {
+		unreachable = unreachable[:maxUnreachable]
+	}
+	return KeystoneReason{UnreachableIfRemoved: unreachable}
+}
+
+// explainArticulation groups id's neighbors by the component they end up
+// in once id is removed, which is exactly the set of biconnected
+// components that id glues together.
+func (s *GraphStats) explainArticulation(id string) ArticulationReason {
+	neighbors := append(append([]string{}, s.Successors(id)...), s.Predecessors(id)...)
+
+	assigned := make(map[string]bool, len(neighbors))
+	var components [][]string
+	for _, n := range neighbors {
+		if assigned[n] || n == id {
+			continue
+		}
+		component := reachableFrom(s, []string{n}, id)
+		component = undirectedClosure(s, component, id)
+
+		var members []string
+		for m := range component {
+			if m == id {
+				continue
+			}
+			members = append(members, m)
+			assigned[m] = true
+		}
+		sort.Strings(members)
+		if len(members) > 0 {
+			components = append(components, members)
+		}
+	}
+	return ArticulationReason{FragmentedComponents: components}
+}
+
+// coreNeighbors returns id's neighbors that also participate at the same
+// k-core number, matching CoreNumber's conventional definition.
+func (s *GraphStats) coreNeighbors(id string, coreNumber int) []string {
+	coreNum := s.CoreNumber()
+	var neighbors []string
+	for _, n := range append(append([]string{}, s.Successors(id)...), s.Predecessors(id)...) {
+		if coreNum[n] >= coreNumber {
+			neighbors = append(neighbors, n)
+		}
+	}
+	sort.Strings(neighbors)
+	return neighbors
+}
+
+// sampleSources returns a deterministic, bounded sample of node IDs to use
+// as BFS roots for explanation purposes.
+func (s *GraphStats) sampleSources(limit int) []string {
+	ids := make([]string, 0, len(s.OutDegree))
+	for id := range s.OutDegree {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
+// bfsDistances returns the shortest unweighted distance from source to
+// every reachable node, optionally never traversing through skip.
+func bfsDistances(s *GraphStats, source, skip string) map[string]int {
+	dist := map[string]int{source: 0}
+	if source == skip {
+		return dist
+	}
+	queue := []string{source}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range s.Successors(cur) {
+			if next == skip {
+				continue
+			}
+			if _, seen := dist[next]; seen {
+				continue
+			}
+			dist[next] = dist[cur] + 1
+			queue = append(queue, next)
+		}
+	}
+	return dist
+}
+
+// reachableFrom returns the set of nodes reachable from any of roots,
+// optionally never traversing through skip.
+func reachableFrom(s *GraphStats, roots []string, skip string) map[string]bool {
+	visited := make(map[string]bool, len(roots))
+	var queue []string
+	for _, r := range roots {
+		if r == skip || visited[r] {
+			continue
+		}
+		visited[r] = true
+		queue = append(queue, r)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range s.Successors(cur) {
+			if next == skip || visited[next] {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return visited
+}
+
+// undirectedClosure grows component by following both successor and
+// predecessor edges, since a biconnected component after removing a cut
+// vertex is defined over the underlying undirected graph.
+func undirectedClosure(s *GraphStats, component map[string]bool, skip string) map[string]bool {
+	queue := make([]string, 0, len(component))
+	for id := range component {
+		queue = append(queue, id)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range append(append([]string{}, s.Successors(cur)...), s.Predecessors(cur)...) {
+			if next == skip || component[next] {
+				continue
+			}
+			component[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return component
+}
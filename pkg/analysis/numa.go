@@ -0,0 +1,24 @@
+package analysis
+
+// NUMAConfig describes the NUMA domains ApproxBetweennessNUMA schedules
+// Brandes workers across. DetectNUMATopology fills it in automatically,
+// but callers can construct one directly to override detection (e.g.
+// to force a specific CPU layout) or to disable pinning in tests that
+// don't want sched_setaffinity calls.
+//
+// DomainCPUs[d] lists the CPU IDs local to domain d. Fewer than two
+// domains means NUMA-aware scheduling isn't worth the pinning overhead;
+// ApproxBetweennessNUMA then behaves exactly like ApproxBetweenness.
+type NUMAConfig struct {
+	Enabled    bool
+	DomainCPUs [][]int
+}
+
+// numaDomainCount returns the number of domains cfg actually schedules
+// across, or 0 if cfg is disabled.
+func (cfg NUMAConfig) numaDomainCount() int {
+	if !cfg.Enabled {
+		return 0
+	}
+	return len(cfg.DomainCPUs)
+}
@@ -0,0 +1,99 @@
+//go:build linux
+
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// DetectNUMATopology parses /sys/devices/system/node/ for each node's
+// CPU list, mirroring the per-node CPU set detection Nomad's numalib
+// uses to pin workers to the node holding their memory. A host with
+// fewer than two NUMA nodes, or where /sys isn't readable (containers,
+// restricted permissions), reports the zero-value NUMAConfig (disabled,
+// no domains) so callers gracefully fall back to the non-NUMA code path.
+func DetectNUMATopology() NUMAConfig {
+	const nodeDir = "/sys/devices/system/node"
+	entries, err := os.ReadDir(nodeDir)
+	if err != nil {
+		return NUMAConfig{}
+	}
+
+	var domains [][]int
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, "node") {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(name, "node")); err != nil {
+			continue
+		}
+		cpus, err := readCPUList(filepath.Join(nodeDir, name, "cpulist"))
+		if err != nil || len(cpus) == 0 {
+			continue
+		}
+		domains = append(domains, cpus)
+	}
+
+	if len(domains) < 2 {
+		return NUMAConfig{}
+	}
+	return NUMAConfig{Enabled: true, DomainCPUs: domains}
+}
+
+// readCPUList parses a Linux cpulist file's "0-3,8,10-11" range syntax
+// into a sorted slice of CPU IDs.
+func readCPUList(path string) ([]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cpus []int
+	for _, part := range strings.Split(strings.TrimSpace(string(data)), ",") {
+		if part == "" {
+			continue
+		}
+		before, after, isRange := strings.Cut(part, "-")
+		if !isRange {
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				continue
+			}
+			cpus = append(cpus, n)
+			continue
+		}
+		lo, err1 := strconv.Atoi(before)
+		hi, err2 := strconv.Atoi(after)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		for c := lo; c <= hi; c++ {
+			cpus = append(cpus, c)
+		}
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
+
+// pinCurrentThread restricts the calling OS thread to cpus via
+// sched_setaffinity; it does not lock the goroutine to that thread. The
+// Brandes iterations it runs afterward keep their working set on the
+// memory cpus are local to. Callers must pair it with
+// runtime.LockOSThread/UnlockOSThread around the goroutine's whole
+// lifetime - pinning the thread without locking the goroutine to it
+// would let the Go scheduler move the goroutine to an unpinned thread.
+func pinCurrentThread(cpus []int) error {
+	var set unix.CPUSet
+	set.Zero()
+	for _, c := range cpus {
+		set.Set(c)
+	}
+	return unix.SchedSetaffinity(0, &set)
+}
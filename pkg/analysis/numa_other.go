@@ -0,0 +1,19 @@
+//go:build !linux
+
+package analysis
+
+// DetectNUMATopology reports the zero-value NUMAConfig (disabled, no
+// domains) on
+// platforms without /sys/devices/system/node/. ApproxBetweennessNUMA
+// treats that the same as an explicitly disabled NUMAConfig and uses
+// the plain, non-pinned code path.
+func DetectNUMATopology() NUMAConfig {
+	return NUMAConfig{}
+}
+
+// pinCurrentThread is a no-op outside Linux: sched_setaffinity has no
+// portable equivalent, so these builds skip pinning and let the OS
+// scheduler place the goroutine's thread.
+func pinCurrentThread(cpus []int) error {
+	return nil
+}
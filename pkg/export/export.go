@@ -0,0 +1,220 @@
+// Package export renders a tree of issues as embeddable DOT or Mermaid
+// diagrams, so a beads database can be turned into docs/PR-friendly
+// graphs instead of only the terminal's tree view.
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+)
+
+// ColorMode selects what drives node fill color in the exported diagram.
+type ColorMode int
+
+const (
+	// ColorNone renders every node with no fill.
+	ColorNone ColorMode = iota
+	// ColorByStatus fills nodes by their issue status.
+	ColorByStatus
+	// ColorByPriority fills nodes by their issue priority.
+	ColorByPriority
+)
+
+// EdgeKind distinguishes the non-hierarchical dependency edges Options
+// can optionally include alongside the parent-child tree structure.
+type EdgeKind int
+
+const (
+	// EdgeBlocks marks a "blocks" dependency, filtered out of the
+	// hierarchy itself per TestTreeBuildBlockingDepsIgnored.
+	EdgeBlocks EdgeKind = iota
+	// EdgeRelated marks a "related" dependency, filtered out of the
+	// hierarchy itself per TestTreeBuildRelatedDepsIgnored.
+	EdgeRelated
+)
+
+// Edge is one non-hierarchical dependency edge to draw alongside the
+// tree, e.g. a blocking or related link pulled from model.Dependency by
+// the caller.
+type Edge struct {
+	From, To string
+	Kind     EdgeKind
+}
+
+// Options controls what ExportDOT and ExportMermaid draw beyond the
+// bare parent-child hierarchy.
+type Options struct {
+	// IncludeBlocking draws EdgeBlocks entries from extraEdges as dashed,
+	// colored edges.
+	IncludeBlocking bool
+	// IncludeRelated draws EdgeRelated entries from extraEdges as dashed,
+	// colored edges.
+	IncludeRelated bool
+	// ColorBy selects what drives node fill color.
+	ColorBy ColorMode
+	// VisibleOnly is documentary only at this layer: callers limiting to
+	// the current expanded subtree should pass just that subtree's roots
+	// rather than the full forest, so both exporters stay agnostic to
+	// what "visible" means.
+	VisibleOnly bool
+}
+
+// Node is what a tree node must expose to be exported: identity and
+// child structure via ui.WalkNode (so both exporters stay consistent
+// with on-screen sorting and cycle handling), a human-readable Label,
+// and a FillColor hook for Options.ColorBy. FillColor returns "" to
+// leave a node unfilled; by must be either ColorByStatus or
+// ColorByPriority (ColorNone is never passed to it).
+type Node[N any] interface {
+	ui.WalkNode[N]
+	Label() string
+	FillColor(by ColorMode) string
+}
+
+// DOT writes roots as a Graphviz "digraph" to w: one quoted node
+// statement per visited node (filled per opts.ColorBy when FillColor
+// returns non-empty), then one edge statement per parent-child link,
+// then - if requested - one dashed, colored edge statement per
+// extraEdges entry whose Kind is enabled in opts.
+func DOT[N Node[N]](w io.Writer, roots []N, extraEdges []Edge, opts Options) error {
+	nodes, hierarchyEdges := collect(roots)
+
+	if _, err := fmt.Fprintln(w, "digraph IssueTree {"); err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		fill := ""
+		if opts.ColorBy != ColorNone {
+			fill = n.FillColor(opts.ColorBy)
+		}
+		attrs := fmt.Sprintf("label=%q", n.Label())
+		if fill != "" {
+			attrs += fmt.Sprintf(", style=filled, fillcolor=%q", fill)
+		}
+		if _, err := fmt.Fprintf(w, "  %q [%s];\n", n.WalkID(), attrs); err != nil {
+			return err
+		}
+	}
+	for _, e := range hierarchyEdges {
+		if _, err := fmt.Fprintf(w, "  %q -> %q;\n", e.From, e.To); err != nil {
+			return err
+		}
+	}
+	for _, e := range filterExtraEdges(extraEdges, opts) {
+		kind := edgeKindLabel(e.Kind)
+		if _, err := fmt.Fprintf(w, "  %q -> %q [style=dashed, color=%q, label=%q];\n", e.From, e.To, edgeKindColor(e.Kind), kind); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// Mermaid writes roots as a Mermaid "graph TD" flowchart to w, one node
+// declaration and edge per visited node/link, mirroring DOT's node
+// ordering, coloring, and extra-edge filtering so the two stay in sync.
+func Mermaid[N Node[N]](w io.Writer, roots []N, extraEdges []Edge, opts Options) error {
+	nodes, hierarchyEdges := collect(roots)
+
+	if _, err := fmt.Fprintln(w, "graph TD"); err != nil {
+		return err
+	}
+	for _, n := range nodes {
+		if _, err := fmt.Fprintf(w, "  %s[%q]\n", mermaidID(n.WalkID()), n.Label()); err != nil {
+			return err
+		}
+	}
+	for _, e := range hierarchyEdges {
+		if _, err := fmt.Fprintf(w, "  %s --> %s\n", mermaidID(e.From), mermaidID(e.To)); err != nil {
+			return err
+		}
+	}
+	for _, e := range filterExtraEdges(extraEdges, opts) {
+		if _, err := fmt.Fprintf(w, "  %s -.->|%s| %s\n", mermaidID(e.From), edgeKindLabel(e.Kind), mermaidID(e.To)); err != nil {
+			return err
+		}
+	}
+	if opts.ColorBy == ColorNone {
+		return nil
+	}
+	for _, n := range nodes {
+		fill := n.FillColor(opts.ColorBy)
+		if fill == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "  style %s fill:%s\n", mermaidID(n.WalkID()), fill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type hierarchyEdge struct {
+	From, To string
+}
+
+// collect walks roots once via ui.Walk, returning every node in
+// deterministic pre-order plus one hierarchyEdge per parent-child link -
+// the shared traversal both DOT and Mermaid build their output from, so
+// node order and structure can never drift between the two formats.
+func collect[N Node[N]](roots []N) ([]N, []hierarchyEdge) {
+	var nodes []N
+	var edges []hierarchyEdge
+	ui.Walk(roots, func(node N, depth int, path []string) ui.WalkAction {
+		nodes = append(nodes, node)
+		if len(path) > 1 {
+			edges = append(edges, hierarchyEdge{From: path[len(path)-2], To: path[len(path)-1]})
+		}
+		return ui.WalkContinue
+	})
+	return nodes, edges
+}
+
+// filterExtraEdges keeps only the extraEdges kinds opts enables, sorted
+// for deterministic output.
+func filterExtraEdges(extraEdges []Edge, opts Options) []Edge {
+	var kept []Edge
+	for _, e := range extraEdges {
+		switch e.Kind {
+		case EdgeBlocks:
+			if opts.IncludeBlocking {
+				kept = append(kept, e)
+			}
+		case EdgeRelated:
+			if opts.IncludeRelated {
+				kept = append(kept, e)
+			}
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		if kept[i].From != kept[j].From {
+			return kept[i].From < kept[j].From
+		}
+		return kept[i].To < kept[j].To
+	})
+	return kept
+}
+
+func edgeKindLabel(kind EdgeKind) string {
+	if kind == EdgeRelated {
+		return "related"
+	}
+	return "blocks"
+}
+
+func edgeKindColor(kind EdgeKind) string {
+	if kind == EdgeRelated {
+		return "gray"
+	}
+	return "orange"
+}
+
+// mermaidID sanitizes id for use as a bare Mermaid node identifier by
+// quoting it; Mermaid node IDs can't contain spaces or most punctuation,
+// but a quoted string literal can stand in for one directly.
+func mermaidID(id string) string {
+	return fmt.Sprintf("%q", id)
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui/testkit"
+)
+
+// exportTestNode is a minimal export.Node used to exercise DOT/Mermaid
+// output without depending on the (currently missing) IssueTreeNode.
+type exportTestNode struct {
+	id       string
+	label    string
+	status   string
+	priority string
+	children []exportTestNode
+}
+
+func (n exportTestNode) WalkID() string                 { return n.id }
+func (n exportTestNode) WalkChildren() []exportTestNode { return n.children }
+func (n exportTestNode) Label() string                  { return n.label }
+func (n exportTestNode) FillColor(by ColorMode) string {
+	switch by {
+	case ColorByStatus:
+		return n.status
+	case ColorByPriority:
+		return n.priority
+	default:
+		return ""
+	}
+}
+
+// parentChildFixture mirrors TestTreeBuildParentChild's hierarchy:
+// epic-1 -> task-1 -> subtask-1.
+func parentChildFixture() []exportTestNode {
+	return []exportTestNode{
+		{
+			id: "epic-1", label: "Epic", status: "#4caf50", priority: "#9e9e9e",
+			children: []exportTestNode{
+				{
+					id: "task-1", label: "Task under Epic", status: "#2196f3", priority: "#ff9800",
+					children: []exportTestNode{
+						{id: "subtask-1", label: "Subtask", status: "#2196f3", priority: "#f44336"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// childSortingFixture mirrors TestTreeBuildChildSorting's already-sorted
+// child order: P1 Task, P1 Bug, P2 Task.
+func childSortingFixture() []exportTestNode {
+	return []exportTestNode{
+		{
+			id: "parent", label: "Parent",
+			children: []exportTestNode{
+				{id: "child-p1-task", label: "P1 Task"},
+				{id: "child-p1-bug", label: "P1 Bug"},
+				{id: "child-p2-task", label: "P2 Task"},
+			},
+		},
+	}
+}
+
+func TestExportDOTParentChild(t *testing.T) {
+	var buf strings.Builder
+	if err := DOT(&buf, parentChildFixture(), nil, Options{}); err != nil {
+		t.Fatalf("DOT: %v", err)
+	}
+	testkit.AssertGolden(t, "parent_child.dot", buf.String())
+}
+
+func TestExportDOTColorByStatus(t *testing.T) {
+	var buf strings.Builder
+	if err := DOT(&buf, parentChildFixture(), nil, Options{ColorBy: ColorByStatus}); err != nil {
+		t.Fatalf("DOT: %v", err)
+	}
+	testkit.AssertGolden(t, "parent_child_status.dot", buf.String())
+}
+
+func TestExportDOTChildSorting(t *testing.T) {
+	var buf strings.Builder
+	if err := DOT(&buf, childSortingFixture(), nil, Options{}); err != nil {
+		t.Fatalf("DOT: %v", err)
+	}
+	testkit.AssertGolden(t, "child_sorting.dot", buf.String())
+}
+
+func TestExportDOTExtraEdges(t *testing.T) {
+	extra := []Edge{
+		{From: "task-1", To: "epic-1", Kind: EdgeRelated},
+		{From: "subtask-1", To: "task-1", Kind: EdgeBlocks},
+	}
+
+	var withNeither strings.Builder
+	if err := DOT(&withNeither, parentChildFixture(), extra, Options{}); err != nil {
+		t.Fatalf("DOT: %v", err)
+	}
+	testkit.AssertGolden(t, "parent_child.dot", withNeither.String())
+
+	var withBoth strings.Builder
+	if err := DOT(&withBoth, parentChildFixture(), extra, Options{IncludeBlocking: true, IncludeRelated: true}); err != nil {
+		t.Fatalf("DOT: %v", err)
+	}
+	testkit.AssertGolden(t, "parent_child_extra_edges.dot", withBoth.String())
+}
+
+func TestExportMermaidParentChild(t *testing.T) {
+	var buf strings.Builder
+	if err := Mermaid(&buf, parentChildFixture(), nil, Options{}); err != nil {
+		t.Fatalf("Mermaid: %v", err)
+	}
+	testkit.AssertGolden(t, "parent_child.mmd", buf.String())
+}
+
+func TestExportMermaidColorByPriority(t *testing.T) {
+	var buf strings.Builder
+	if err := Mermaid(&buf, parentChildFixture(), nil, Options{ColorBy: ColorByPriority}); err != nil {
+		t.Fatalf("Mermaid: %v", err)
+	}
+	testkit.AssertGolden(t, "parent_child_priority.mmd", buf.String())
+}
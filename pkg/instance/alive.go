@@ -0,0 +1,8 @@
+package instance
+
+// IsAlive reports whether a process with the given PID is still running.
+// It is exported so other packages (e.g. metrics) can surface liveness
+// without duplicating the platform-specific checks in isProcessAlive.
+func IsAlive(pid int) bool {
+	return isProcessAlive(pid)
+}
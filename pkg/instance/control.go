@@ -0,0 +1,259 @@
+package instance
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// Verb identifies a control-plane RPC exposed by a running instance.
+type Verb string
+
+const (
+	VerbPing        Verb = "ping"
+	VerbShutdown    Verb = "shutdown"
+	VerbReload      Verb = "reload"
+	VerbStats       Verb = "stats"
+	VerbListWatched Verb = "list_watched"
+)
+
+// ControlRequest is a single JSON-lines request sent over the control
+// endpoint (an AF_UNIX socket on Unix, a named pipe on Windows).
+type ControlRequest struct {
+	Verb Verb            `json:"verb"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+// ControlResponse is the JSON-lines reply to a ControlRequest. Exactly one
+// of Result or Error is populated.
+type ControlResponse struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// StatsResult is the Result payload for a VerbStats request.
+type StatsResult struct {
+	PID          int      `json:"pid"`
+	UptimeS      float64  `json:"uptime_s"`
+	WatchedPaths []string `json:"watched_paths"`
+	IssueCount   int      `json:"issue_count"`
+}
+
+// Handler implements the control-plane verbs on behalf of a running
+// instance. CLIs obtain a ControlClient via Dial and drive a remote
+// instance through this same set of operations.
+type Handler interface {
+	// Ping returns nil if the instance is healthy.
+	Ping(ctx context.Context) error
+	// Shutdown asks the instance to exit gracefully.
+	Shutdown(ctx context.Context) error
+	// Reload asks the instance to re-read its configuration and data.
+	Reload(ctx context.Context) error
+	// Stats reports point-in-time instance statistics.
+	Stats(ctx context.Context) (StatsResult, error)
+	// ListWatched reports the filesystem paths currently being watched.
+	ListWatched(ctx context.Context) ([]string, error)
+}
+
+// ControlServer accepts connections on the per-instance control endpoint
+// and dispatches each request to a Handler.
+type ControlServer struct {
+	listener net.Listener
+	handler  Handler
+}
+
+// Serve creates the control endpoint for instanceID and begins accepting
+// connections in a background goroutine. Callers must call Close when the
+// instance shuts down to remove the socket/pipe.
+func Serve(instanceID string, handler Handler) (*ControlServer, error) {
+	listener, err := listenControlEndpoint(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("instance: control endpoint for %q: %w", instanceID, err)
+	}
+
+	s := &ControlServer{listener: listener, handler: handler}
+	go s.acceptLoop()
+	return s, nil
+}
+
+// Close stops accepting new connections and removes the control endpoint.
+func (s *ControlServer) Close() error {
+	return s.listener.Close()
+}
+
+func (s *ControlServer) acceptLoop() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *ControlServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	enc := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		var req ControlRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			enc.Encode(ControlResponse{Error: fmt.Sprintf("invalid request: %v", err)})
+			continue
+		}
+
+		resp := s.dispatch(context.Background(), req)
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+func (s *ControlServer) dispatch(ctx context.Context, req ControlRequest) ControlResponse {
+	switch req.Verb {
+	case VerbPing:
+		if err := s.handler.Ping(ctx); err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		return ControlResponse{Result: json.RawMessage(`true`)}
+	case VerbShutdown:
+		if err := s.handler.Shutdown(ctx); err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		return ControlResponse{Result: json.RawMessage(`true`)}
+	case VerbReload:
+		if err := s.handler.Reload(ctx); err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		return ControlResponse{Result: json.RawMessage(`true`)}
+	case VerbStats:
+		stats, err := s.handler.Stats(ctx)
+		if err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		return encodeResult(stats)
+	case VerbListWatched:
+		paths, err := s.handler.ListWatched(ctx)
+		if err != nil {
+			return ControlResponse{Error: err.Error()}
+		}
+		return encodeResult(paths)
+	default:
+		return ControlResponse{Error: fmt.Sprintf("unknown verb %q", req.Verb)}
+	}
+}
+
+func encodeResult(v any) ControlResponse {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return ControlResponse{Error: fmt.Sprintf("encoding result: %v", err)}
+	}
+	return ControlResponse{Result: raw}
+}
+
+// ControlClient is a connection to a running instance's control endpoint,
+// used by CLIs (e.g. an `instance` admin subcommand) to attach to an
+// already-running `beads` process instead of failing on its lock.
+type ControlClient struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	enc     *json.Encoder
+}
+
+// Dial connects to the control endpoint for instanceID.
+func Dial(instanceID string) (*ControlClient, error) {
+	conn, err := dialControlEndpoint(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("instance: dial control endpoint for %q: %w", instanceID, err)
+	}
+	return &ControlClient{
+		conn:    conn,
+		scanner: bufio.NewScanner(conn),
+		enc:     json.NewEncoder(conn),
+	}, nil
+}
+
+// Close closes the connection to the instance.
+func (c *ControlClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *ControlClient) call(verb Verb, args any) (ControlResponse, error) {
+	var raw json.RawMessage
+	if args != nil {
+		encoded, err := json.Marshal(args)
+		if err != nil {
+			return ControlResponse{}, fmt.Errorf("instance: encoding args for %q: %w", verb, err)
+		}
+		raw = encoded
+	}
+
+	if err := c.enc.Encode(ControlRequest{Verb: verb, Args: raw}); err != nil {
+		return ControlResponse{}, fmt.Errorf("instance: sending %q request: %w", verb, err)
+	}
+
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return ControlResponse{}, fmt.Errorf("instance: reading %q response: %w", verb, err)
+		}
+		return ControlResponse{}, fmt.Errorf("instance: connection closed awaiting %q response", verb)
+	}
+
+	var resp ControlResponse
+	if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
+		return ControlResponse{}, fmt.Errorf("instance: decoding %q response: %w", verb, err)
+	}
+	if resp.Error != "" {
+		return ControlResponse{}, fmt.Errorf("instance: %q failed: %s", verb, resp.Error)
+	}
+	return resp, nil
+}
+
+// Ping checks that the remote instance is responsive.
+func (c *ControlClient) Ping() error {
+	_, err := c.call(VerbPing, nil)
+	return err
+}
+
+// Shutdown asks the remote instance to exit gracefully.
+func (c *ControlClient) Shutdown() error {
+	_, err := c.call(VerbShutdown, nil)
+	return err
+}
+
+// Reload asks the remote instance to re-read its configuration and data.
+func (c *ControlClient) Reload() error {
+	_, err := c.call(VerbReload, nil)
+	return err
+}
+
+// Stats fetches point-in-time statistics from the remote instance.
+func (c *ControlClient) Stats() (StatsResult, error) {
+	resp, err := c.call(VerbStats, nil)
+	if err != nil {
+		return StatsResult{}, err
+	}
+	var stats StatsResult
+	if err := json.Unmarshal(resp.Result, &stats); err != nil {
+		return StatsResult{}, fmt.Errorf("instance: decoding stats result: %w", err)
+	}
+	return stats, nil
+}
+
+// ListWatched fetches the filesystem paths currently being watched by the
+// remote instance.
+func (c *ControlClient) ListWatched() ([]string, error) {
+	resp, err := c.call(VerbListWatched, nil)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	if err := json.Unmarshal(resp.Result, &paths); err != nil {
+		return nil, fmt.Errorf("instance: decoding list_watched result: %w", err)
+	}
+	return paths, nil
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package instance
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// controlSocketPath returns the path of the AF_UNIX socket used for the
+// control endpoint of instanceID, colocated with the instance lockfile.
+func controlSocketPath(instanceID string) string {
+	return filepath.Join(os.TempDir(), "beads-"+instanceID+".sock")
+}
+
+func listenControlEndpoint(instanceID string) (net.Listener, error) {
+	path := controlSocketPath(instanceID)
+	// A stale socket from an unclean shutdown would otherwise make
+	// net.Listen fail with "address already in use".
+	_ = os.Remove(path)
+	return net.Listen("unix", path)
+}
+
+func dialControlEndpoint(instanceID string) (net.Conn, error) {
+	return net.Dial("unix", controlSocketPath(instanceID))
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package instance
+
+import (
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// controlPipeName returns the named pipe path used for the control
+// endpoint of instanceID.
+func controlPipeName(instanceID string) string {
+	return `\\.\pipe\beads-` + instanceID
+}
+
+func listenControlEndpoint(instanceID string) (net.Listener, error) {
+	return winio.ListenPipe(controlPipeName(instanceID), nil)
+}
+
+func dialControlEndpoint(instanceID string) (net.Conn, error) {
+	return winio.DialPipe(controlPipeName(instanceID), nil)
+}
@@ -0,0 +1,240 @@
+//go:build windows
+
+package instance
+
+import (
+	"context"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Job Object APIs and constants not currently exposed by golang.org/x/sys/windows.
+const (
+	jobObjectExtendedLimitInformation = 9
+	jobObjectAssociateCompletionPort  = 7
+	jobObjectLimitKillOnJobClose      = 0x2000
+	jobObjectMsgActiveProcessZero     = 4
+	jobObjectMsgExitProcess           = 7
+	jobObjectMsgAbnormalExitProcess   = 8
+)
+
+type jobobjectBasicLimitInformation struct {
+	PerProcessUserTimeLimit int64
+	PerJobUserTimeLimit     int64
+	LimitFlags              uint32
+	MinimumWorkingSetSize   uintptr
+	MaximumWorkingSetSize   uintptr
+	ActiveProcessLimit      uint32
+	Affinity                uintptr
+	PriorityClass           uint32
+	SchedulingClass         uint32
+}
+
+type ioCounters struct {
+	ReadOperationCount  uint64
+	WriteOperationCount uint64
+	OtherOperationCount uint64
+	ReadTransferCount   uint64
+	WriteTransferCount  uint64
+	OtherTransferCount  uint64
+}
+
+type jobobjectExtendedLimitInformation struct {
+	BasicLimitInformation jobobjectBasicLimitInformation
+	IoInfo                ioCounters
+	ProcessMemoryLimit    uintptr
+	JobMemoryLimit        uintptr
+	PeakProcessMemoryUsed uintptr
+	PeakJobMemoryUsed     uintptr
+}
+
+type jobobjectAssociateCompletionPort struct {
+	CompletionKey  uintptr
+	CompletionPort windows.Handle
+}
+
+// JobTracker wraps a process tree in a named Windows Job Object so its
+// liveness can be observed without the PID-reuse false positives that
+// plague OpenProcess-based checks (see isProcessAlive): once a job's last
+// process exits, Windows posts JOB_OBJECT_MSG_ACTIVE_PROCESS_ZERO to the
+// job's I/O completion port and never reuses that signal for an unrelated
+// process. Callers that own the lifetime of the wrapped process (i.e. they
+// spawned it) should set killOnClose so the whole subtree is reaped if the
+// tracker is closed without an orderly shutdown; callers that are merely
+// re-attaching to an instance created by a previous parent should leave it
+// unset.
+//
+// The job's Name should be persisted alongside the instance lockfile so a
+// restarted parent can OpenJobObject and re-attach instead of falling back
+// to PID-based liveness checks.
+type JobTracker struct {
+	name string
+	job  windows.Handle
+	port windows.Handle
+}
+
+// NewJobTracker creates a new named Job Object, assigns pid to it, and wires
+// up an I/O completion port so WaitForExit can observe when the job's last
+// process terminates. name must be unique per-instance; it is typically
+// derived from the instance's lockfile identity.
+func NewJobTracker(name string, pid int, killOnClose bool) (*JobTracker, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, fmt.Errorf("instance: invalid job object name %q: %w", name, err)
+	}
+
+	job, err := windows.CreateJobObject(nil, namePtr)
+	if err != nil {
+		return nil, fmt.Errorf("instance: CreateJobObject(%q): %w", name, err)
+	}
+
+	if killOnClose {
+		limits := jobobjectExtendedLimitInformation{
+			BasicLimitInformation: jobobjectBasicLimitInformation{
+				LimitFlags: jobObjectLimitKillOnJobClose,
+			},
+		}
+		if _, err := setInformationJobObject(job, jobObjectExtendedLimitInformation, unsafe.Pointer(&limits), uint32(unsafe.Sizeof(limits))); err != nil {
+			windows.CloseHandle(job)
+			return nil, fmt.Errorf("instance: SetInformationJobObject(kill-on-close, %q): %w", name, err)
+		}
+	}
+
+	port, err := windows.CreateIoCompletionPort(windows.InvalidHandle, 0, 0, 1)
+	if err != nil {
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("instance: CreateIoCompletionPort(%q): %w", name, err)
+	}
+
+	assoc := jobobjectAssociateCompletionPort{
+		CompletionKey:  uintptr(job),
+		CompletionPort: port,
+	}
+	if _, err := setInformationJobObject(job, jobObjectAssociateCompletionPort, unsafe.Pointer(&assoc), uint32(unsafe.Sizeof(assoc))); err != nil {
+		windows.CloseHandle(port)
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("instance: SetInformationJobObject(completion-port, %q): %w", name, err)
+	}
+
+	handle, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		windows.CloseHandle(port)
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("instance: OpenProcess(%d) for job assignment: %w", pid, err)
+	}
+	defer windows.CloseHandle(handle)
+
+	if err := windows.AssignProcessToJobObject(job, handle); err != nil {
+		windows.CloseHandle(port)
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("instance: AssignProcessToJobObject(%d, %q): %w", pid, name, err)
+	}
+
+	return &JobTracker{name: name, job: job, port: port}, nil
+}
+
+// OpenJobTracker re-attaches to a Job Object created by a previous parent
+// process, using the name persisted in the instance lockfile. It sets up a
+// fresh completion port since completion port associations do not survive
+// the opening process exiting.
+func OpenJobTracker(name string) (*JobTracker, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, fmt.Errorf("instance: invalid job object name %q: %w", name, err)
+	}
+
+	const jobObjectAllAccess = 0x1F001F
+	job, err := windows.OpenJobObject(jobObjectAllAccess, false, namePtr)
+	if err != nil {
+		return nil, fmt.Errorf("instance: OpenJobObject(%q): %w", name, err)
+	}
+
+	port, err := windows.CreateIoCompletionPort(windows.InvalidHandle, 0, 0, 1)
+	if err != nil {
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("instance: CreateIoCompletionPort(%q): %w", name, err)
+	}
+
+	assoc := jobobjectAssociateCompletionPort{
+		CompletionKey:  uintptr(job),
+		CompletionPort: port,
+	}
+	if _, err := setInformationJobObject(job, jobObjectAssociateCompletionPort, unsafe.Pointer(&assoc), uint32(unsafe.Sizeof(assoc))); err != nil {
+		windows.CloseHandle(port)
+		windows.CloseHandle(job)
+		return nil, fmt.Errorf("instance: SetInformationJobObject(completion-port, %q): %w", name, err)
+	}
+
+	return &JobTracker{name: name, job: job, port: port}, nil
+}
+
+// Name returns the Job Object's name, for persisting in the instance
+// lockfile so a later process can OpenJobTracker and re-attach.
+func (t *JobTracker) Name() string {
+	return t.name
+}
+
+// WaitForExit blocks until every process in the job has exited, or ctx is
+// canceled. It polls GetQueuedCompletionStatus on a background goroutine so
+// cancellation is observed promptly even though IOCP waits themselves are
+// not context-aware.
+func (t *JobTracker) WaitForExit(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		for {
+			var code uint32
+			var key uintptr
+			var overlapped *windows.Overlapped
+			err := windows.GetQueuedCompletionStatus(t.port, &code, &key, &overlapped, windows.INFINITE)
+			if err != nil {
+				done <- fmt.Errorf("instance: GetQueuedCompletionStatus(%q): %w", t.name, err)
+				return
+			}
+			if key == uintptr(t.job) && code == jobObjectMsgActiveProcessZero {
+				done <- nil
+				return
+			}
+		}
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close releases the job and completion port handles. If the tracker was
+// created with killOnClose, this terminates every remaining process in the
+// job.
+func (t *JobTracker) Close() error {
+	var firstErr error
+	if err := windows.CloseHandle(t.port); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	if err := windows.CloseHandle(t.job); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+func setInformationJobObject(job windows.Handle, infoClass uint32, info unsafe.Pointer, length uint32) (uintptr, error) {
+	r1, _, err := procSetInformationJobObject.Call(
+		uintptr(job),
+		uintptr(infoClass),
+		uintptr(info),
+		uintptr(length),
+	)
+	if r1 == 0 {
+		return r1, err
+	}
+	return r1, nil
+}
+
+var (
+	modkernel32                 = windows.NewLazySystemDLL("kernel32.dll")
+	procSetInformationJobObject = modkernel32.NewProc("SetInformationJobObject")
+)
@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StaleRule flags issues that haven't been touched in Days days.
+type StaleRule struct {
+	Enabled bool `yaml:"enabled"`
+	Days    int  `yaml:"days"`
+}
+
+// MissingMetadataRule flags issues lacking fields a healthy backlog
+// expects to be filled in; each check is independently toggleable.
+type MissingMetadataRule struct {
+	Enabled         bool `yaml:"enabled"`
+	RequirePriority bool `yaml:"requirePriority"`
+	RequireAssignee bool `yaml:"requireAssignee"`
+	RequireLabels   bool `yaml:"requireLabels"`
+}
+
+// OversizedEpicRule flags epics with more than MaxChildren direct
+// children, a signal they should be split up.
+type OversizedEpicRule struct {
+	Enabled     bool `yaml:"enabled"`
+	MaxChildren int  `yaml:"maxChildren"`
+}
+
+// toggle is a bare enable/disable switch for rules with no thresholds.
+type toggle struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// Config is the on-disk shape of lint.yaml: thresholds and enable/disable
+// toggles for each rule Scan runs.
+type Config struct {
+	Stale              StaleRule           `yaml:"stale"`
+	Orphaned           toggle              `yaml:"orphaned"`
+	MissingMetadata    MissingMetadataRule `yaml:"missingMetadata"`
+	CircularDependency toggle              `yaml:"circularDependency"`
+	OversizedEpic      OversizedEpicRule   `yaml:"oversizedEpic"`
+	PriorityInversion  toggle              `yaml:"priorityInversion"`
+}
+
+// DefaultConfig returns the thresholds the board uses when no lint.yaml
+// is present: every rule enabled with conservative defaults.
+func DefaultConfig() Config {
+	return Config{
+		Stale:              StaleRule{Enabled: true, Days: 30},
+		Orphaned:           toggle{Enabled: true},
+		MissingMetadata:    MissingMetadataRule{Enabled: true, RequirePriority: true, RequireAssignee: true, RequireLabels: false},
+		CircularDependency: toggle{Enabled: true},
+		OversizedEpic:      OversizedEpicRule{Enabled: true, MaxChildren: 20},
+		PriorityInversion:  toggle{Enabled: true},
+	}
+}
+
+// LoadConfig reads and parses a lint.yaml file at path, starting from
+// DefaultConfig so an on-disk file only needs to override what it cares
+// about. A missing file returns DefaultConfig rather than an error, since
+// lint configuration is optional.
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("lint: reading config %q: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("lint: parsing config %q: %w", path, err)
+	}
+	return cfg, nil
+}
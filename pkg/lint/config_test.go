@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigMissingFileReturnsDefaults(t *testing.T) {
+	cfg, err := LoadConfig("/nonexistent/lint.yaml")
+	if err != nil {
+		t.Fatalf("LoadConfig of a missing file should not error, got: %v", err)
+	}
+	if cfg != DefaultConfig() {
+		t.Errorf("expected defaults for a missing file, got %+v", cfg)
+	}
+}
+
+func TestLoadConfigOverridesDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "lint.yaml")
+	yaml := `
+stale:
+  enabled: true
+  days: 7
+oversizedEpic:
+  enabled: false
+  maxChildren: 5
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Stale.Days != 7 {
+		t.Errorf("expected stale.days 7, got %d", cfg.Stale.Days)
+	}
+	if cfg.OversizedEpic.Enabled {
+		t.Error("expected oversizedEpic.enabled to be overridden to false")
+	}
+	if !cfg.Orphaned.Enabled {
+		t.Error("expected orphaned rule to keep its default (enabled) since the file doesn't mention it")
+	}
+}
@@ -0,0 +1,365 @@
+// Package lint scans a loaded set of model.Issue for hygiene problems and
+// scores them, in the spirit of Popeye's Kubernetes sanitizers: a handful
+// of independent rules each emit zero or more Findings, which are then
+// aggregated into a per-issue score and a global grade.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Severity ranks how serious a Finding is, highest first.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+// String renders s as the short label used in report output.
+func (s Severity) String() string {
+	switch s {
+	case SeverityCritical:
+		return "critical"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// Code identifies which rule produced a Finding.
+type Code string
+
+const (
+	CodeStale              Code = "stale"
+	CodeOrphaned           Code = "orphaned"
+	CodeMissingMetadata    Code = "missing_metadata"
+	CodeCircularDependency Code = "circular_dependency"
+	CodeOversizedEpic      Code = "oversized_epic"
+	CodePriorityInversion  Code = "priority_inversion"
+)
+
+// Finding is a single rule violation attributed to one issue.
+type Finding struct {
+	IssueID  string
+	Code     Code
+	Severity Severity
+	Message  string
+}
+
+// severityPenalty is how many points a Finding of each Severity deducts
+// from its issue's 0-100 score.
+var severityPenalty = map[Severity]int{
+	SeverityInfo:     5,
+	SeverityWarning:  15,
+	SeverityCritical: 30,
+}
+
+// Report aggregates the Findings from a single Scan: every Finding, a
+// per-issue score derived from every Finding against it, and a
+// global letter grade.
+type Report struct {
+	Findings []Finding
+	Scores   map[string]int // issue ID -> 0-100, 100 meaning no Findings
+	Grade    string
+}
+
+// Score returns the score for issueID, or 100 if it has no Findings.
+func (r Report) Score(issueID string) int {
+	if s, ok := r.Scores[issueID]; ok {
+		return s
+	}
+	return 100
+}
+
+// Scan runs every rule enabled in cfg against issues and returns the
+// aggregated Report. Findings are sorted by Severity descending, then by
+// IssueID, so the worst problems sort first without being tied to scan
+// order. Scan is allocation-light and linear-ish in len(issues): it's
+// meant to run on every board refresh, not just on demand.
+func Scan(issues []model.Issue, cfg Config) Report {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	var findings []Finding
+	if cfg.Stale.Enabled {
+		findings = append(findings, staleFindings(issues, cfg.Stale)...)
+	}
+	if cfg.Orphaned.Enabled {
+		findings = append(findings, orphanedFindings(issues, byID)...)
+	}
+	if cfg.MissingMetadata.Enabled {
+		findings = append(findings, missingMetadataFindings(issues, cfg.MissingMetadata)...)
+	}
+	if cfg.CircularDependency.Enabled {
+		findings = append(findings, circularDependencyFindings(issues)...)
+	}
+	if cfg.OversizedEpic.Enabled {
+		findings = append(findings, oversizedEpicFindings(issues, cfg.OversizedEpic)...)
+	}
+	if cfg.PriorityInversion.Enabled {
+		findings = append(findings, priorityInversionFindings(issues, byID)...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity > findings[j].Severity
+		}
+		return findings[i].IssueID < findings[j].IssueID
+	})
+
+	return Report{
+		Findings: findings,
+		Scores:   scoreFindings(findings),
+		Grade:    grade(scoreFindings(findings), len(issues)),
+	}
+}
+
+// scoreFindings reduces each issue's score by severityPenalty for every
+// Finding against it, floored at 0.
+func scoreFindings(findings []Finding) map[string]int {
+	scores := map[string]int{}
+	for _, f := range findings {
+		if _, ok := scores[f.IssueID]; !ok {
+			scores[f.IssueID] = 100
+		}
+		scores[f.IssueID] -= severityPenalty[f.Severity]
+		if scores[f.IssueID] < 0 {
+			scores[f.IssueID] = 0
+		}
+	}
+	return scores
+}
+
+// grade turns the average per-issue score (treating un-flagged issues as
+// a perfect 100) into a letter grade, matching the A-F bands Popeye uses
+// for its cluster sanitizer score.
+func grade(scores map[string]int, totalIssues int) string {
+	if totalIssues == 0 {
+		return "A"
+	}
+	sum := 0
+	for _, s := range scores {
+		sum += s
+	}
+	sum += 100 * (totalIssues - len(scores))
+	avg := sum / totalIssues
+
+	switch {
+	case avg >= 90:
+		return "A"
+	case avg >= 80:
+		return "B"
+	case avg >= 70:
+		return "C"
+	case avg >= 60:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+func staleFindings(issues []model.Issue, cfg StaleRule) []Finding {
+	var findings []Finding
+	threshold := time.Duration(cfg.Days) * 24 * time.Hour
+	for _, iss := range issues {
+		if iss.Status == model.StatusClosed {
+			continue
+		}
+		if iss.UpdatedAt.IsZero() {
+			continue
+		}
+		if time.Since(iss.UpdatedAt) <= threshold {
+			continue
+		}
+		findings = append(findings, Finding{
+			IssueID:  iss.ID,
+			Code:     CodeStale,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("no update in over %d days", cfg.Days),
+		})
+	}
+	return findings
+}
+
+func orphanedFindings(issues []model.Issue, byID map[string]*model.Issue) []Finding {
+	var findings []Finding
+	for _, iss := range issues {
+		for _, dep := range iss.Dependencies {
+			if dep.Type != model.DepBlocks {
+				continue
+			}
+			blocker, ok := byID[dep.DependsOnID]
+			if !ok {
+				findings = append(findings, Finding{
+					IssueID:  iss.ID,
+					Code:     CodeOrphaned,
+					Severity: SeverityCritical,
+					Message:  "blocked by nonexistent issue " + dep.DependsOnID,
+				})
+				continue
+			}
+			if blocker.Status == model.StatusClosed {
+				findings = append(findings, Finding{
+					IssueID:  iss.ID,
+					Code:     CodeOrphaned,
+					Severity: SeverityWarning,
+					Message:  "blocked by already-closed issue " + blocker.ID,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+func missingMetadataFindings(issues []model.Issue, cfg MissingMetadataRule) []Finding {
+	var findings []Finding
+	for _, iss := range issues {
+		if iss.Status == model.StatusClosed {
+			continue
+		}
+		if cfg.RequirePriority && iss.Priority < 0 {
+			findings = append(findings, Finding{
+				IssueID: iss.ID, Code: CodeMissingMetadata, Severity: SeverityInfo,
+				Message: "no priority set",
+			})
+		}
+		if cfg.RequireAssignee && iss.Assignee == "" {
+			findings = append(findings, Finding{
+				IssueID: iss.ID, Code: CodeMissingMetadata, Severity: SeverityInfo,
+				Message: "no assignee set",
+			})
+		}
+		if cfg.RequireLabels && len(iss.Labels) == 0 {
+			findings = append(findings, Finding{
+				IssueID: iss.ID, Code: CodeMissingMetadata, Severity: SeverityInfo,
+				Message: "no labels set",
+			})
+		}
+	}
+	return findings
+}
+
+// circularDependencyFindings walks the DepBlocks graph with a standard
+// three-color DFS and reports every issue that participates in a cycle.
+func circularDependencyFindings(issues []model.Issue) []Finding {
+	blocks := map[string][]string{}
+	for _, iss := range issues {
+		for _, dep := range iss.Dependencies {
+			if dep.Type == model.DepBlocks {
+				blocks[dep.DependsOnID] = append(blocks[dep.DependsOnID], dep.IssueID)
+			}
+		}
+	}
+
+	const (
+		white = 0
+		gray  = 1
+		black = 2
+	)
+	color := map[string]int{}
+	inCycle := map[string]bool{}
+
+	var visit func(id string, stack []string)
+	visit = func(id string, stack []string) {
+		color[id] = gray
+		stack = append(stack, id)
+		for _, next := range blocks[id] {
+			switch color[next] {
+			case white:
+				visit(next, stack)
+			case gray:
+				for i := len(stack) - 1; i >= 0; i-- {
+					inCycle[stack[i]] = true
+					if stack[i] == next {
+						break
+					}
+				}
+				inCycle[next] = true
+			}
+		}
+		color[id] = black
+	}
+
+	ids := make([]string, 0, len(issues))
+	for _, iss := range issues {
+		ids = append(ids, iss.ID)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if color[id] == white {
+			visit(id, nil)
+		}
+	}
+
+	var findings []Finding
+	for _, id := range ids {
+		if inCycle[id] {
+			findings = append(findings, Finding{
+				IssueID: id, Code: CodeCircularDependency, Severity: SeverityCritical,
+				Message: "participates in a circular dependency",
+			})
+		}
+	}
+	return findings
+}
+
+// oversizedEpicFindings flags epics whose direct child count - issues
+// they DepBlocks - exceeds cfg.MaxChildren.
+func oversizedEpicFindings(issues []model.Issue, cfg OversizedEpicRule) []Finding {
+	childCount := map[string]int{}
+	for _, iss := range issues {
+		for _, dep := range iss.Dependencies {
+			if dep.Type == model.DepBlocks {
+				childCount[dep.DependsOnID]++
+			}
+		}
+	}
+
+	var findings []Finding
+	for _, iss := range issues {
+		if iss.IssueType != model.TypeEpic {
+			continue
+		}
+		if n := childCount[iss.ID]; n > cfg.MaxChildren {
+			findings = append(findings, Finding{
+				IssueID: iss.ID, Code: CodeOversizedEpic, Severity: SeverityWarning,
+				Message: fmt.Sprintf("epic has %d children, above threshold %d", n, cfg.MaxChildren),
+			})
+		}
+	}
+	return findings
+}
+
+func priorityInversionFindings(issues []model.Issue, byID map[string]*model.Issue) []Finding {
+	var findings []Finding
+	for _, iss := range issues {
+		if iss.Status == model.StatusClosed {
+			continue
+		}
+		for _, dep := range iss.Dependencies {
+			if dep.Type != model.DepBlocks {
+				continue
+			}
+			blocker, ok := byID[dep.DependsOnID]
+			if !ok || blocker.Status == model.StatusClosed {
+				continue
+			}
+			if iss.Priority < blocker.Priority {
+				findings = append(findings, Finding{
+					IssueID: iss.ID, Code: CodePriorityInversion, Severity: SeverityCritical,
+					Message: fmt.Sprintf("P%d issue blocked by lower-priority P%d issue %s", iss.Priority, blocker.Priority, blocker.ID),
+				})
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,205 @@
+package lint
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestScanStale(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "fresh", Status: model.StatusOpen, Assignee: "x", UpdatedAt: time.Now()},
+		{ID: "stale", Status: model.StatusOpen, Assignee: "x", UpdatedAt: time.Now().Add(-60 * 24 * time.Hour)},
+		{ID: "stale-closed", Status: model.StatusClosed, UpdatedAt: time.Now().Add(-60 * 24 * time.Hour)},
+	}
+
+	report := Scan(issues, DefaultConfig())
+
+	if report.Score("fresh") != 100 {
+		t.Errorf("expected fresh issue to score 100, got %d", report.Score("fresh"))
+	}
+	if report.Score("stale") == 100 {
+		t.Error("expected stale issue to be penalized")
+	}
+	if report.Score("stale-closed") != 100 {
+		t.Error("closed issues should not be flagged stale")
+	}
+}
+
+func TestScanOrphaned(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, Assignee: "x", Dependencies: []*model.Dependency{
+			{IssueID: "a", DependsOnID: "missing", Type: model.DepBlocks},
+		}},
+		{ID: "b", Status: model.StatusOpen, Assignee: "x", Dependencies: []*model.Dependency{
+			{IssueID: "b", DependsOnID: "closed", Type: model.DepBlocks},
+		}},
+		{ID: "closed", Status: model.StatusClosed},
+	}
+
+	report := Scan(issues, DefaultConfig())
+
+	var codes []Code
+	for _, f := range report.Findings {
+		if f.IssueID == "a" || f.IssueID == "b" {
+			codes = append(codes, f.Code)
+		}
+	}
+	if len(codes) != 2 {
+		t.Fatalf("expected 2 orphaned findings, got %v", report.Findings)
+	}
+}
+
+func TestScanMissingMetadata(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bare", Status: model.StatusOpen, Priority: -1},
+		{ID: "full", Status: model.StatusOpen, Priority: 1, Assignee: "alice", Labels: []string{"x"}},
+	}
+
+	report := Scan(issues, DefaultConfig())
+
+	found := false
+	for _, f := range report.Findings {
+		if f.IssueID == "bare" && f.Code == CodeMissingMetadata {
+			found = true
+		}
+		if f.IssueID == "full" {
+			t.Errorf("fully-specified issue should not be flagged, got %+v", f)
+		}
+	}
+	if !found {
+		t.Error("expected missing-metadata finding for bare issue")
+	}
+}
+
+func TestScanCircularDependency(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Dependencies: []*model.Dependency{{IssueID: "a", DependsOnID: "b", Type: model.DepBlocks}}},
+		{ID: "b", Dependencies: []*model.Dependency{{IssueID: "b", DependsOnID: "a", Type: model.DepBlocks}}},
+		{ID: "c"},
+	}
+
+	report := Scan(issues, DefaultConfig())
+
+	flagged := map[string]bool{}
+	for _, f := range report.Findings {
+		if f.Code == CodeCircularDependency {
+			flagged[f.IssueID] = true
+		}
+	}
+	if !flagged["a"] || !flagged["b"] {
+		t.Errorf("expected a and b flagged as circular, got %+v", report.Findings)
+	}
+	if flagged["c"] {
+		t.Error("c has no dependencies and should not be flagged")
+	}
+}
+
+func TestScanOversizedEpic(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.OversizedEpic.MaxChildren = 2
+
+	issues := []model.Issue{
+		{ID: "epic", IssueType: model.TypeEpic},
+	}
+	for i := 0; i < 3; i++ {
+		id := fmt.Sprintf("child-%d", i)
+		issues = append(issues, model.Issue{
+			ID:           id,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: "epic", Type: model.DepBlocks}},
+		})
+	}
+
+	report := Scan(issues, cfg)
+
+	found := false
+	for _, f := range report.Findings {
+		if f.IssueID == "epic" && f.Code == CodeOversizedEpic {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected oversized-epic finding with 3 children against a threshold of 2")
+	}
+}
+
+func TestScanPriorityInversion(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "urgent", Status: model.StatusOpen, Priority: 0, Dependencies: []*model.Dependency{
+			{IssueID: "urgent", DependsOnID: "low", Type: model.DepBlocks},
+		}},
+		{ID: "low", Status: model.StatusOpen, Priority: 3},
+	}
+
+	report := Scan(issues, DefaultConfig())
+
+	found := false
+	for _, f := range report.Findings {
+		if f.IssueID == "urgent" && f.Code == CodePriorityInversion {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected priority-inversion finding for P0 blocked by P3")
+	}
+}
+
+func TestScanDisabledRuleProducesNoFindings(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Stale.Enabled = false
+
+	issues := []model.Issue{
+		{ID: "stale", Status: model.StatusOpen, Assignee: "x", UpdatedAt: time.Now().Add(-90 * 24 * time.Hour)},
+	}
+
+	report := Scan(issues, cfg)
+	if len(report.Findings) != 0 {
+		t.Errorf("expected no findings with stale rule disabled, got %+v", report.Findings)
+	}
+}
+
+func TestScanGrade(t *testing.T) {
+	clean := Scan([]model.Issue{{ID: "a", Status: model.StatusOpen, Priority: 1, Assignee: "x", Labels: []string{"y"}}}, DefaultConfig())
+	if clean.Grade != "A" {
+		t.Errorf("expected clean backlog to grade A, got %s", clean.Grade)
+	}
+
+	dirty := Scan([]model.Issue{
+		{ID: "a", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "a", DependsOnID: "missing", Type: model.DepBlocks},
+		}},
+	}, DefaultConfig())
+	if dirty.Grade == "A" {
+		t.Error("expected orphaned issue to pull the grade down from A")
+	}
+}
+
+func TestScanUnder50msOn10kIssues(t *testing.T) {
+	issues := make([]model.Issue, 10000)
+	for i := range issues {
+		id := fmt.Sprintf("bv-%d", i)
+		issues[i] = model.Issue{
+			ID:        id,
+			Status:    model.StatusOpen,
+			Priority:  i % 5,
+			Assignee:  "someone",
+			Labels:    []string{"x"},
+			UpdatedAt: time.Now(),
+		}
+		if i > 0 {
+			issues[i].Dependencies = []*model.Dependency{
+				{IssueID: id, DependsOnID: fmt.Sprintf("bv-%d", i-1), Type: model.DepBlocks},
+			}
+		}
+	}
+
+	start := time.Now()
+	Scan(issues, DefaultConfig())
+	elapsed := time.Since(start)
+
+	if elapsed > 50*time.Millisecond {
+		t.Errorf("Scan of 10k issues took %v, want under 50ms", elapsed)
+	}
+}
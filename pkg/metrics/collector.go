@@ -0,0 +1,134 @@
+// Package metrics exports analysis.Insights and watcher/instance health as
+// Prometheus metrics, so a long-running `beads` instance can be scraped by
+// Prometheus/Grafana instead of only being inspected interactively.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// TopN bounds how many of the highest-scoring bottleneck/keystone issues
+// get their own labeled gauge. Without a bound, a large graph's full node
+// set would each become a distinct time series.
+const TopN = 20
+
+var (
+	graphNodesDesc = prometheus.NewDesc(
+		"beads_graph_nodes", "Number of issues in the dependency graph.", nil, nil)
+	graphDensityDesc = prometheus.NewDesc(
+		"beads_graph_density", "Cluster density of the dependency graph.", nil, nil)
+	bottleneckScoreDesc = prometheus.NewDesc(
+		"beads_bottleneck_score", "Betweenness-derived bottleneck score for the top issues.", []string{"id"}, nil)
+	keystoneScoreDesc = prometheus.NewDesc(
+		"beads_keystone_score", "Keystone score for the top issues.", []string{"id"}, nil)
+	cyclesTotalDesc = prometheus.NewDesc(
+		"beads_cycles_total", "Number of dependency cycles detected.", nil, nil)
+	orphansTotalDesc = prometheus.NewDesc(
+		"beads_orphans_total", "Number of orphaned issues (no dependencies either way).", nil, nil)
+	articulationTotalDesc = prometheus.NewDesc(
+		"beads_articulation_total", "Number of articulation points in the dependency graph.", nil, nil)
+	closedTotalDesc = prometheus.NewDesc(
+		"beads_closed_total", "Issues closed within the trailing window.", []string{"window"}, nil)
+	avgDaysToCloseDesc = prometheus.NewDesc(
+		"beads_avg_days_to_close", "Average days from creation to close.", nil, nil)
+)
+
+// Collector is a prometheus.Collector backed by a pull-based Source
+// function. It calls Source on every scrape, so producers don't need to
+// push metric updates whenever the underlying analysis changes - the
+// latest analysis.Insights snapshot is read lazily, the same way bubbletea
+// models pull fresh DataSnapshots on render.
+type Collector struct {
+	Source func() *analysis.Insights
+}
+
+// NewCollector creates a Collector that reports metrics derived from
+// whatever analysis.Insights source returns at scrape time. source may
+// return nil while an instance's first analysis pass is still running.
+func NewCollector(source func() *analysis.Insights) *Collector {
+	return &Collector{Source: source}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- graphNodesDesc
+	ch <- graphDensityDesc
+	ch <- bottleneckScoreDesc
+	ch <- keystoneScoreDesc
+	ch <- cyclesTotalDesc
+	ch <- orphansTotalDesc
+	ch <- articulationTotalDesc
+	ch <- closedTotalDesc
+	ch <- avgDaysToCloseDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	insights := c.Source()
+	if insights == nil {
+		return
+	}
+
+	if insights.Stats != nil {
+		ch <- prometheus.MustNewConstMetric(graphNodesDesc, prometheus.GaugeValue, float64(insights.Stats.NodeCount))
+	}
+	ch <- prometheus.MustNewConstMetric(graphDensityDesc, prometheus.GaugeValue, insights.ClusterDensity)
+	ch <- prometheus.MustNewConstMetric(cyclesTotalDesc, prometheus.GaugeValue, float64(len(insights.Cycles)))
+	ch <- prometheus.MustNewConstMetric(orphansTotalDesc, prometheus.GaugeValue, float64(len(insights.Orphans)))
+	ch <- prometheus.MustNewConstMetric(articulationTotalDesc, prometheus.GaugeValue, float64(len(insights.Articulation)))
+
+	for _, item := range limitInsightItems(insights.Bottlenecks, insights.Slack, TopN) {
+		ch <- prometheus.MustNewConstMetric(bottleneckScoreDesc, prometheus.GaugeValue, item.Value, item.ID)
+	}
+	for _, item := range limitInsightItems(insights.Keystones, insights.Slack, TopN) {
+		ch <- prometheus.MustNewConstMetric(keystoneScoreDesc, prometheus.GaugeValue, item.Value, item.ID)
+	}
+
+	if v := insights.Velocity; v != nil {
+		ch <- prometheus.MustNewConstMetric(closedTotalDesc, prometheus.GaugeValue, float64(v.Closed7), "7d")
+		ch <- prometheus.MustNewConstMetric(closedTotalDesc, prometheus.GaugeValue, float64(v.Closed30), "30d")
+		ch <- prometheus.MustNewConstMetric(avgDaysToCloseDesc, prometheus.GaugeValue, v.AvgDays)
+	}
+}
+
+// limitInsightItems looks up each ID's score in scored (a []InsightItem
+// that carries values, such as insights.Slack) and returns at most limit
+// entries, since Bottlenecks/Keystones are plain ID lists without scores of
+// their own.
+func limitInsightItems(ids []string, scored []analysis.InsightItem, limit int) []analysis.InsightItem {
+	scoreByID := make(map[string]float64, len(scored))
+	for _, s := range scored {
+		scoreByID[s.ID] = s.Value
+	}
+
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	items := make([]analysis.InsightItem, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, analysis.InsightItem{ID: id, Value: scoreByID[id]})
+	}
+	return items
+}
+
+// Handler returns an http.Handler suitable for mounting at /metrics. It is
+// exposed separately from Serve so callers that already run their own HTTP
+// server can mount it themselves.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve starts an HTTP server exposing /metrics on addr and blocks until it
+// returns an error (e.g. the listener is closed). It is meant to be run in
+// its own goroutine, enabled optionally from the CLI (e.g. `--metrics-addr`).
+func Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return http.ListenAndServe(addr, mux)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+)
+
+var (
+	watcherFSType = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "beads_watcher_fs_type",
+		Help: "1 for the detected filesystem type of a watched path; a type that is no longer current is removed.",
+	}, []string{"path", "type"})
+
+	instanceAlive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "beads_instance_alive",
+		Help: "1 if the instance with this PID is alive, 0 otherwise.",
+	}, []string{"pid"})
+)
+
+// RecordWatcherFSType records the filesystem type detected for path, e.g.
+// from watcher.DetectFilesystemType. Any previously-recorded type for the
+// same path is deleted first so stale labels don't linger as 1 after a
+// remount changes the detected type.
+func RecordWatcherFSType(path string, fsType watcher.FilesystemType) {
+	watcherFSType.DeletePartialMatch(prometheus.Labels{"path": path})
+	watcherFSType.WithLabelValues(path, fsType.String()).Set(1)
+}
+
+// RecordInstanceAlive records the current liveness of the instance with
+// the given PID, as determined by instance.IsAlive.
+func RecordInstanceAlive(pid int) {
+	alive := float64(0)
+	if instance.IsAlive(pid) {
+		alive = 1
+	}
+	instanceAlive.WithLabelValues(strconv.Itoa(pid)).Set(alive)
+}
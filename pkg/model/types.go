@@ -0,0 +1,84 @@
+// Package model defines the beads issue shape every other package in
+// this tree (pkg/ui, pkg/lint, pkg/activity, pkg/analysis's callers)
+// reads and mutates: Issue plus the Status/IssueType/Dependency
+// vocabulary a beads graph is built from.
+package model
+
+import "time"
+
+// Issue is one tracked work item.
+type Issue struct {
+	ID           string        `json:"id"`
+	Title        string        `json:"title"`
+	Status       Status        `json:"status"`
+	Priority     int           `json:"priority"`
+	IssueType    IssueType     `json:"issue_type"`
+	Assignee     string        `json:"assignee,omitempty"`
+	Labels       []string      `json:"labels,omitempty"`
+	Dependencies []*Dependency `json:"dependencies,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// Status is an issue's current state.
+type Status string
+
+const (
+	StatusOpen       Status = "open"
+	StatusInProgress Status = "in_progress"
+	StatusBlocked    Status = "blocked"
+	StatusClosed     Status = "closed"
+)
+
+// IsValid reports whether s is one of the recognized statuses.
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed:
+		return true
+	}
+	return false
+}
+
+// IssueType categorizes the kind of work an Issue represents.
+type IssueType string
+
+const (
+	TypeBug     IssueType = "bug"
+	TypeFeature IssueType = "feature"
+	TypeTask    IssueType = "task"
+	TypeEpic    IssueType = "epic"
+	TypeChore   IssueType = "chore"
+)
+
+// IsValid reports whether t is non-empty: any non-empty type is
+// accepted so ecosystems built atop beads can introduce their own
+// issue types without a model change, the same way IssueType's
+// constants are only the built-in, well-known ones.
+func (t IssueType) IsValid() bool {
+	return t != ""
+}
+
+// Dependency is a directed relationship from IssueID to DependsOnID.
+type Dependency struct {
+	IssueID     string         `json:"issue_id"`
+	DependsOnID string         `json:"depends_on_id"`
+	Type        DependencyType `json:"type"`
+}
+
+// DependencyType categorizes a Dependency's relationship.
+type DependencyType string
+
+const (
+	DepBlocks      DependencyType = "blocks"
+	DepRelated     DependencyType = "related"
+	DepParentChild DependencyType = "parent-child"
+)
+
+// IsValid reports whether d is one of the recognized dependency types.
+func (d DependencyType) IsValid() bool {
+	switch d {
+	case DepBlocks, DepRelated, DepParentChild:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package search
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FeedbackSink records user interaction signals against search results
+// so a WeightOptimizer can learn from them. Implementations must be
+// safe for concurrent use, since clicks and dwell times arrive from the
+// UI goroutine while a Propose call may run concurrently from a
+// background tuning loop.
+type FeedbackSink interface {
+	// RecordClick notes that issueID, shown at rank (0-based) for query,
+	// was clicked.
+	RecordClick(query, issueID string, rank int)
+	// RecordDwell notes that the user spent dwell on issueID after
+	// opening it from query's results.
+	RecordDwell(query, issueID string, dwell time.Duration)
+}
+
+// Ranker re-scores a query's candidate results under weights and
+// returns their issueIDs in ranked order, best first. Propose calls it
+// once per candidate Weights it evaluates; callers typically implement
+// it by re-running HybridScorer.Score over the query's cached
+// candidate set with a scorer Configure'd to weights.
+type Ranker func(weights Weights, query string) []string
+
+// WeightBounds clamps every dimension WeightOptimizer is allowed to
+// move a weight to, so it never proposes Weights outside the ranges an
+// operator's presets already consider sane.
+type WeightBounds struct {
+	Min, Max Weights
+}
+
+type clickFeedback struct {
+	issueID string
+	rank    int
+}
+
+type dwellFeedback struct {
+	issueID string
+	dwell   time.Duration
+}
+
+// WeightOptimizer implements FeedbackSink, buffering click feedback for
+// the last MaxQueries distinct queries and using it to propose new
+// Weights via coordinate descent: Propose holds every dimension fixed
+// but one, tries Steps evenly-spaced values for that dimension across
+// WeightBounds, and keeps whichever value maximizes mean reciprocal
+// rank of the recorded clicks, then moves to the next dimension. This
+// is a v1 optimizer, not a global one - it finds a local improvement on
+// the current Weights, not a guaranteed optimum.
+type WeightOptimizer struct {
+	mu sync.Mutex
+
+	bounds     WeightBounds
+	maxQueries int
+	steps      int
+
+	order  []string // query insertion order, oldest first, for eviction
+	clicks map[string][]clickFeedback
+	dwells map[string][]dwellFeedback
+}
+
+// NewWeightOptimizer returns a WeightOptimizer that keeps feedback for
+// at most maxQueries distinct queries and searches steps candidate
+// values per dimension during Propose. A non-positive maxQueries or
+// steps is treated as 1.
+func NewWeightOptimizer(bounds WeightBounds, maxQueries, steps int) *WeightOptimizer {
+	if maxQueries < 1 {
+		maxQueries = 1
+	}
+	if steps < 1 {
+		steps = 1
+	}
+	return &WeightOptimizer{
+		bounds:     bounds,
+		maxQueries: maxQueries,
+		steps:      steps,
+		clicks:     make(map[string][]clickFeedback),
+		dwells:     make(map[string][]dwellFeedback),
+	}
+}
+
+// RecordClick implements FeedbackSink.
+func (o *WeightOptimizer) RecordClick(query, issueID string, rank int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.touchQueryLocked(query)
+	o.clicks[query] = append(o.clicks[query], clickFeedback{issueID: issueID, rank: rank})
+}
+
+// RecordDwell implements FeedbackSink. Dwell time isn't yet weighed by
+// Propose's reciprocal-rank objective - it's recorded so a future
+// optimizer revision can fold "clicked but immediately bounced" into
+// the signal without changing this interface.
+func (o *WeightOptimizer) RecordDwell(query, issueID string, dwell time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.touchQueryLocked(query)
+	o.dwells[query] = append(o.dwells[query], dwellFeedback{issueID: issueID, dwell: dwell})
+}
+
+// touchQueryLocked records query as freshly seen if this is its first
+// feedback, evicting the oldest buffered query once maxQueries is
+// exceeded. Callers must hold o.mu.
+func (o *WeightOptimizer) touchQueryLocked(query string) {
+	_, hasClicks := o.clicks[query]
+	_, hasDwells := o.dwells[query]
+	if hasClicks || hasDwells {
+		return
+	}
+
+	o.order = append(o.order, query)
+	for len(o.order) > o.maxQueries {
+		oldest := o.order[0]
+		o.order = o.order[1:]
+		delete(o.clicks, oldest)
+		delete(o.dwells, oldest)
+	}
+}
+
+// Propose runs coordinate descent from current using rank to evaluate
+// candidates, and returns the best Weights found. Score itself stays
+// pure and deterministic; only a caller that then passes Propose's
+// result to hybridScorer.Configure changes how future Scores behave.
+// With no buffered feedback, Propose returns current unchanged.
+func (o *WeightOptimizer) Propose(current Weights, rank Ranker) Weights {
+	o.mu.Lock()
+	queries := append([]string(nil), o.order...)
+	clicksByQuery := make(map[string][]clickFeedback, len(o.clicks))
+	for q, c := range o.clicks {
+		clicksByQuery[q] = append([]clickFeedback(nil), c...)
+	}
+	o.mu.Unlock()
+
+	if len(queries) == 0 {
+		return current
+	}
+
+	best := current
+	bestMRR := meanReciprocalRank(best, queries, clicksByQuery, rank)
+
+	for _, dim := range weightDimensions(o.bounds) {
+		for _, candidate := range dim.candidates(o.steps, dim.get(best)) {
+			trial := best
+			dim.set(&trial, candidate)
+
+			if mrr := meanReciprocalRank(trial, queries, clicksByQuery, rank); mrr > bestMRR {
+				best, bestMRR = trial, mrr
+			}
+		}
+	}
+
+	return best
+}
+
+// meanReciprocalRank scores weights by the mean, over queries, of
+// 1/(1+rank) for the best-ranked clicked issue in that query's results
+// under rank(weights, query) - the standard reciprocal-rank metric,
+// averaged so one heavily-clicked query can't dominate the objective.
+// A query with no results for any of its clicked issues contributes 0.
+func meanReciprocalRank(weights Weights, queries []string, clicksByQuery map[string][]clickFeedback, rank Ranker) float64 {
+	if len(queries) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, query := range queries {
+		clicked := clicksByQuery[query]
+		if len(clicked) == 0 {
+			continue
+		}
+
+		results := rank(weights, query)
+		position := make(map[string]int, len(results))
+		for i, issueID := range results {
+			position[issueID] = i
+		}
+
+		best := 0.0
+		for _, c := range clicked {
+			pos, found := position[c.issueID]
+			if !found {
+				continue
+			}
+			if rr := 1.0 / float64(1+pos); rr > best {
+				best = rr
+			}
+		}
+		total += best
+	}
+	return total / float64(len(queries))
+}
+
+// weightDimension is one coordinate Propose can search independently:
+// get/set read and write that field on a Weights value, bounded to
+// [min, max].
+type weightDimension struct {
+	get      func(Weights) float64
+	set      func(*Weights, float64)
+	min, max float64
+}
+
+// candidates returns steps evenly-spaced values across [min, max],
+// plus the dimension's current value (so the search never discards a
+// value it started at due to rounding), sorted and deduplicated.
+func (d weightDimension) candidates(steps int, current float64) []float64 {
+	values := make([]float64, 0, steps+1)
+	if steps == 1 {
+		values = append(values, d.min, d.max)
+	} else {
+		for i := 0; i < steps; i++ {
+			frac := float64(i) / float64(steps-1)
+			values = append(values, d.min+frac*(d.max-d.min))
+		}
+	}
+	values = append(values, current)
+
+	sort.Float64s(values)
+	deduped := values[:0]
+	for i, v := range values {
+		if i == 0 || v != deduped[len(deduped)-1] {
+			deduped = append(deduped, v)
+		}
+	}
+	return deduped
+}
+
+// weightDimensions returns one weightDimension per tunable Weights
+// field, bounded by bounds. RecencyHalfLife is excluded: it's a
+// duration, not a [0,1]-style weight, and coordinate descent over it is
+// left for a future revision.
+func weightDimensions(bounds WeightBounds) []weightDimension {
+	return []weightDimension{
+		{
+			get: func(w Weights) float64 { return w.TextRelevance },
+			set: func(w *Weights, v float64) { w.TextRelevance = v },
+			min: bounds.Min.TextRelevance, max: bounds.Max.TextRelevance,
+		},
+		{
+			get: func(w Weights) float64 { return w.PageRank },
+			set: func(w *Weights, v float64) { w.PageRank = v },
+			min: bounds.Min.PageRank, max: bounds.Max.PageRank,
+		},
+		{
+			get: func(w Weights) float64 { return w.Status },
+			set: func(w *Weights, v float64) { w.Status = v },
+			min: bounds.Min.Status, max: bounds.Max.Status,
+		},
+		{
+			get: func(w Weights) float64 { return w.Impact },
+			set: func(w *Weights, v float64) { w.Impact = v },
+			min: bounds.Min.Impact, max: bounds.Max.Impact,
+		},
+		{
+			get: func(w Weights) float64 { return w.Priority },
+			set: func(w *Weights, v float64) { w.Priority = v },
+			min: bounds.Min.Priority, max: bounds.Max.Priority,
+		},
+		{
+			get: func(w Weights) float64 { return w.Recency },
+			set: func(w *Weights, v float64) { w.Recency = v },
+			min: bounds.Min.Recency, max: bounds.Max.Recency,
+		},
+	}
+}
+
+// NOTE: this checkout's pkg/search is missing the files that define
+// Weights, HybridScore, HybridScorer, MetricsCache,
+// GetPreset/PresetDefault, and the normalizeStatus/normalizePriority/
+// normalizeImpact helpers - they are all referenced but defined nowhere
+// on disk here. This file and decayRecency (hybrid_scorer_impl.go) are
+// written against the Weights shape hybrid_scorer_impl.go already
+// assumes (TextRelevance/PageRank/Status/Impact/Priority/Recency
+// float64 fields), plus a new RecencyHalfLife time.Duration field that
+// belongs on Weights once it's restored. WeightOptimizer.Propose's
+// rank Ranker parameter is deliberately decoupled from hybridScorer so
+// it can be unit-tested without that missing scorer plumbing; wiring a
+// real Ranker backed by hybridScorer.Configure + Score over a query's
+// cached candidates is left for whoever restores weights.go.
@@ -0,0 +1,101 @@
+package search
+
+import "testing"
+
+func TestWeightDimensionCandidatesIncludesBoundsAndCurrent(t *testing.T) {
+	d := weightDimension{min: 0, max: 1}
+
+	got := d.candidates(3, 0.4)
+	want := []float64{0, 0.4, 0.5, 1}
+	if len(got) != len(want) {
+		t.Fatalf("candidates = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("candidates = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMeanReciprocalRankScoresBestClickedRank(t *testing.T) {
+	weights := Weights{Recency: 1}
+	clicksByQuery := map[string][]clickFeedback{
+		"q1": {{issueID: "b"}},
+	}
+	rank := func(w Weights, query string) []string {
+		return []string{"a", "b", "c"}
+	}
+
+	got := meanReciprocalRank(weights, []string{"q1"}, clicksByQuery, rank)
+	want := 1.0 / 2.0 // "b" is rank index 1, so 1/(1+1)
+	if got != want {
+		t.Fatalf("meanReciprocalRank = %v, want %v", got, want)
+	}
+}
+
+func TestMeanReciprocalRankIgnoresUnrankedClicks(t *testing.T) {
+	weights := Weights{}
+	clicksByQuery := map[string][]clickFeedback{
+		"q1": {{issueID: "missing"}},
+	}
+	rank := func(w Weights, query string) []string { return []string{"a"} }
+
+	if got := meanReciprocalRank(weights, []string{"q1"}, clicksByQuery, rank); got != 0 {
+		t.Fatalf("expected 0 for a click on an issue absent from results, got %v", got)
+	}
+}
+
+func TestWeightOptimizerProposeWithNoFeedbackReturnsUnchanged(t *testing.T) {
+	o := NewWeightOptimizer(WeightBounds{Max: Weights{TextRelevance: 1, Recency: 1}}, 10, 3)
+	current := Weights{TextRelevance: 0.5, Recency: 0.5}
+
+	got := o.Propose(current, func(w Weights, query string) []string { return nil })
+	if got != current {
+		t.Fatalf("expected Propose with no feedback to return current unchanged, got %+v", got)
+	}
+}
+
+func TestWeightOptimizerProposeImprovesReciprocalRank(t *testing.T) {
+	bounds := WeightBounds{
+		Min: Weights{Recency: 0},
+		Max: Weights{Recency: 1},
+	}
+	o := NewWeightOptimizer(bounds, 10, 5)
+	o.RecordClick("q1", "fresh-issue", 1)
+
+	// rank always puts "fresh-issue" first once Recency crosses 0.5,
+	// otherwise last - so Propose should climb toward Recency >= 0.5.
+	rank := func(w Weights, query string) []string {
+		if w.Recency >= 0.5 {
+			return []string{"fresh-issue", "stale-issue"}
+		}
+		return []string{"stale-issue", "fresh-issue"}
+	}
+
+	got := o.Propose(Weights{Recency: 0}, rank)
+	if got.Recency < 0.5 {
+		t.Fatalf("expected Propose to raise Recency to at least 0.5, got %v", got.Recency)
+	}
+}
+
+func TestWeightOptimizerEvictsOldestQueryBeyondCapacity(t *testing.T) {
+	o := NewWeightOptimizer(WeightBounds{Max: Weights{Recency: 1}}, 1, 3)
+	o.RecordClick("q1", "a", 0)
+	o.RecordClick("q2", "b", 0)
+
+	if len(o.order) != 1 || o.order[0] != "q2" {
+		t.Fatalf("expected only q2 to remain buffered, got %v", o.order)
+	}
+	if _, ok := o.clicks["q1"]; ok {
+		t.Fatal("expected q1's feedback to have been evicted")
+	}
+}
+
+func TestWeightOptimizerRecordDwellTracksQuery(t *testing.T) {
+	o := NewWeightOptimizer(WeightBounds{}, 10, 3)
+	o.RecordDwell("q1", "a", 0)
+
+	if len(o.order) != 1 || o.order[0] != "q1" {
+		t.Fatalf("expected RecordDwell to register q1, got %v", o.order)
+	}
+}
@@ -1,6 +1,15 @@
 package search
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultRecencyHalfLife is the half-life decayRecency falls back to when
+// Weights.RecencyHalfLife is unset (the zero value), matching the
+// implicit staleness window normalizeRecency used to enforce.
+const DefaultRecencyHalfLife = 14 * 24 * time.Hour
 
 type hybridScorer struct {
 	weights Weights
@@ -57,7 +66,7 @@ func (s *hybridScorer) Score(issueID string, textScore float64) (HybridScore, er
 		impactScore = normalizeImpact(metrics.BlockerCount, s.cache.MaxBlockerCount())
 	}
 	if s.weights.Recency > 0 {
-		recencyScore = normalizeRecency(metrics.UpdatedAt)
+		recencyScore = decayRecency(metrics.UpdatedAt, s.weights.RecencyHalfLife)
 	}
 
 	final := s.weights.TextRelevance*textScore +
@@ -92,3 +101,21 @@ func (s *hybridScorer) Configure(weights Weights) error {
 func (s *hybridScorer) GetWeights() Weights {
 	return s.weights
 }
+
+// decayRecency scores updatedAt on an exponential decay curve,
+// exp(-ln(2) * age / halfLife), so a result exactly halfLife old scores
+// 0.5 and one twice that old scores 0.25, rather than normalizeRecency's
+// fixed bucketing. A non-positive halfLife (the Weights zero value)
+// falls back to DefaultRecencyHalfLife. Ages before updatedAt (a clock
+// skew or future timestamp) are clamped to zero so they don't outscore
+// a just-updated issue.
+func decayRecency(updatedAt time.Time, halfLife time.Duration) float64 {
+	if halfLife <= 0 {
+		halfLife = DefaultRecencyHalfLife
+	}
+	age := time.Since(updatedAt)
+	if age < 0 {
+		age = 0
+	}
+	return math.Exp(-math.Ln2 * age.Seconds() / halfLife.Seconds())
+}
@@ -0,0 +1,35 @@
+package search
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecayRecencyAtOneHalfLife(t *testing.T) {
+	got := decayRecency(time.Now().Add(-14*24*time.Hour), 14*24*time.Hour)
+	if math.Abs(got-0.5) > 0.01 {
+		t.Fatalf("expected ~0.5 at one half-life, got %v", got)
+	}
+}
+
+func TestDecayRecencyAtTwoHalfLives(t *testing.T) {
+	got := decayRecency(time.Now().Add(-28*24*time.Hour), 14*24*time.Hour)
+	if math.Abs(got-0.25) > 0.01 {
+		t.Fatalf("expected ~0.25 at two half-lives, got %v", got)
+	}
+}
+
+func TestDecayRecencyFallsBackToDefaultHalfLife(t *testing.T) {
+	got := decayRecency(time.Now().Add(-DefaultRecencyHalfLife), 0)
+	if math.Abs(got-0.5) > 0.01 {
+		t.Fatalf("expected ~0.5 at the default half-life with RecencyHalfLife unset, got %v", got)
+	}
+}
+
+func TestDecayRecencyClampsFutureTimestamps(t *testing.T) {
+	got := decayRecency(time.Now().Add(time.Hour), 14*24*time.Hour)
+	if got != 1 {
+		t.Fatalf("expected a future timestamp to clamp to full freshness (1.0), got %v", got)
+	}
+}
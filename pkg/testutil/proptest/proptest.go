@@ -0,0 +1,267 @@
+// Package proptest provides small rapid-based helpers for property
+// testing, in particular comparing an "old" and a "new" implementation
+// of the same function across many generated inputs - the shape most of
+// beads_viewer's refactors need (verify a rewrite preserves behavior)
+// rather than rapid's more general invariant-checking style.
+package proptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"pgregory.net/rapid"
+)
+
+// GenFunc draws a single value of T from rt. It's the common shape
+// rapid's generators take once wrapped for reuse across test cases -
+// see IntRange, SliceOfN, SliceOfRange, and OneOf below.
+type GenFunc[T any] func(rt *rapid.T) T
+
+// IntRange returns a GenFunc drawing an int uniformly from [min, max].
+func IntRange(min, max int) GenFunc[int] {
+	gen := rapid.IntRange(min, max)
+	return func(rt *rapid.T) int {
+		return gen.Draw(rt, "value")
+	}
+}
+
+// SliceOfN returns a GenFunc drawing a slice of exactly n values from
+// elem.
+func SliceOfN[T any](n int, elem GenFunc[T]) GenFunc[[]T] {
+	return func(rt *rapid.T) []T {
+		out := make([]T, n)
+		for i := range out {
+			out[i] = elem(rt)
+		}
+		return out
+	}
+}
+
+// SliceOfRange returns a GenFunc drawing a slice whose length is
+// uniform in [min, max], with each element drawn from elem.
+func SliceOfRange[T any](min, max int, elem GenFunc[T]) GenFunc[[]T] {
+	lenGen := rapid.IntRange(min, max)
+	return func(rt *rapid.T) []T {
+		n := lenGen.Draw(rt, "count")
+		out := make([]T, n)
+		for i := range out {
+			out[i] = elem(rt)
+		}
+		return out
+	}
+}
+
+// OneOf returns a GenFunc drawing uniformly from options.
+func OneOf[T any](options ...T) GenFunc[T] {
+	return func(rt *rapid.T) T {
+		return rapid.SampledFrom(options).Draw(rt, "value")
+	}
+}
+
+// diffReport renders a human-readable difference between old and new
+// for a test failure message. It prefers cmp.Diff (honoring opts), but
+// cmp panics on types with unexported fields and no Equal method or
+// cmpopts.IgnoreUnexported, and proptest is used against arbitrary
+// caller types we can't assume are cmp-friendly - so on panic this
+// falls back to a %#v comparison rather than failing the test harness
+// itself.
+func diffReport[T any](old, new T, opts ...cmp.Option) (report string) {
+	defer func() {
+		if r := recover(); r != nil {
+			report = fmt.Sprintf("old: %#v\nnew: %#v\n(cmp.Diff panicked: %v)", old, new, r)
+		}
+	}()
+	return cmp.Diff(old, new, opts...)
+}
+
+// CompareImplementations checks that oldImpl and newImpl agree, per
+// equal, on every input rapid draws from genInput. On the first
+// disagreement it reports the minimized input plus a cmp.Diff of the
+// two outputs, so a regression is immediately localized instead of
+// just "implementations differ".
+func CompareImplementations[In, Out any](t *testing.T, name string, genInput GenFunc[In], oldImpl, newImpl func(In) Out, equal func(Out, Out) bool) {
+	t.Helper()
+	t.Run(name, func(t *testing.T) {
+		rapid.Check(t, func(rt *rapid.T) {
+			input := genInput(rt)
+			oldOut := oldImpl(input)
+			newOut := newImpl(input)
+			if !equal(oldOut, newOut) {
+				rt.Fatalf("implementations differ for input %#v:\n%s", input, diffReport(oldOut, newOut))
+			}
+		})
+	})
+}
+
+// CompareImplementationsCmp is CompareImplementations without a
+// caller-supplied equal func: two outputs are considered equal when
+// cmp.Equal(old, new, opts...) holds, which lets callers pass
+// cmpopts.EquateApprox, cmpopts.SortSlices, cmpopts.IgnoreFields, etc.
+// instead of hand-rolling an equivalent equal func.
+func CompareImplementationsCmp[In, Out any](t *testing.T, name string, genInput GenFunc[In], oldImpl, newImpl func(In) Out, opts ...cmp.Option) {
+	t.Helper()
+	t.Run(name, func(t *testing.T) {
+		rapid.Check(t, func(rt *rapid.T) {
+			input := genInput(rt)
+			oldOut := oldImpl(input)
+			newOut := newImpl(input)
+			if !cmp.Equal(oldOut, newOut, opts...) {
+				rt.Fatalf("implementations differ for input %#v:\n%s", input, diffReport(oldOut, newOut, opts...))
+			}
+		})
+	})
+}
+
+// CompareImplementationsWithError is CompareImplementations for
+// implementations that can also fail. A (nil, non-nil) vs. (non-nil,
+// nil) mismatch is always reported; when both return errors, neither the
+// errors nor the outputs are compared - most callers only
+// care that both implementations agree on whether an input is valid.
+func CompareImplementationsWithError[In, Out any](t *testing.T, name string, genInput GenFunc[In], oldImpl, newImpl func(In) (Out, error), equal func(Out, Out) bool) {
+	t.Helper()
+	t.Run(name, func(t *testing.T) {
+		rapid.Check(t, func(rt *rapid.T) {
+			input := genInput(rt)
+			oldOut, oldErr := oldImpl(input)
+			newOut, newErr := newImpl(input)
+
+			if (oldErr == nil) != (newErr == nil) {
+				rt.Fatalf("implementations differ on error for input %#v: old=%v, new=%v", input, oldErr, newErr)
+			}
+			if oldErr != nil {
+				return
+			}
+			if !equal(oldOut, newOut) {
+				rt.Fatalf("implementations differ for input %#v:\n%s", input, diffReport(oldOut, newOut))
+			}
+		})
+	})
+}
+
+// CompareJSON is CompareImplementations specialized to compare outputs
+// by their JSON encoding, for types whose equality is naturally
+// "same on the wire" rather than reflect.DeepEqual (e.g. differing
+// unexported cache fields).
+func CompareJSON[In, Out any](t *testing.T, name string, genInput GenFunc[In], oldImpl, newImpl func(In) Out) {
+	t.Helper()
+	CompareImplementations(t, name, genInput, oldImpl, newImpl, JSONEqual[Out])
+}
+
+// DeepEqual reports whether a and b are equal per reflect.DeepEqual.
+func DeepEqual[T any](a, b T) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// JSONEqual reports whether a and b marshal to the same JSON, which
+// treats two values as equal even when they differ in ways JSON drops
+// (unexported fields, map key order, nil vs. empty slice with
+// omitempty).
+func JSONEqual[T any](a, b T) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return errA == errB
+	}
+	return string(aJSON) == string(bJSON)
+}
+
+// SliceEqual builds an equal func for []T from an equal func for T,
+// comparing length and each element pairwise in order.
+func SliceEqual[T any](elemEqual func(T, T) bool) func([]T, []T) bool {
+	return func(a, b []T) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !elemEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// UnorderedSliceEqual reports whether a and b contain the same
+// comparable elements, ignoring order; each element must occur the same
+// number of times in both.
+func UnorderedSliceEqual[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[T]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		counts[v]--
+	}
+	for _, n := range counts {
+		if n != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MapEqual reports whether a and b have the same keys each mapping to
+// the same comparable value.
+func MapEqual[K comparable, V comparable](a, b map[K]V) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// FloatEqual returns an equal func treating two floats as equal when
+// they're within tolerance of each other.
+func FloatEqual(tolerance float64) func(float64, float64) bool {
+	return func(a, b float64) bool {
+		diff := a - b
+		if diff < 0 {
+			diff = -diff
+		}
+		return diff <= tolerance
+	}
+}
+
+// TestCase bundles one CompareImplementations call for RunAll: Equal
+// and CmpOptions are both optional, but exactly one should be set - if
+// Equal is nil, RunAll falls back to CompareImplementationsCmp with
+// CmpOptions (cmp.Equal with no options if CmpOptions is also empty).
+type TestCase[In, Out any] struct {
+	Name     string
+	GenInput GenFunc[In]
+	OldImpl  func(In) Out
+	NewImpl  func(In) Out
+	Equal    func(Out, Out) bool
+
+	// CmpOptions configures the go-cmp comparison RunAll and
+	// CompareImplementationsCmp use when Equal is nil - e.g.
+	// cmpopts.EquateApprox for floating-point results,
+	// cmpopts.SortSlices for order-insensitive collections,
+	// cmpopts.IgnoreFields for fields that are expected to diverge, or
+	// cmpopts.EquateErrors for error-returning implementations.
+	CmpOptions []cmp.Option
+}
+
+// RunAll runs every case in cases as its own CompareImplementations (or
+// CompareImplementationsCmp, for cases with no Equal func) subtest.
+func RunAll[In, Out any](t *testing.T, cases []TestCase[In, Out]) {
+	t.Helper()
+	for _, tc := range cases {
+		tc := tc
+		if tc.Equal != nil {
+			CompareImplementations(t, tc.Name, tc.GenInput, tc.OldImpl, tc.NewImpl, tc.Equal)
+			continue
+		}
+		CompareImplementationsCmp(t, tc.Name, tc.GenInput, tc.OldImpl, tc.NewImpl, tc.CmpOptions...)
+	}
+}
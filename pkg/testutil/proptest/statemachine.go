@@ -0,0 +1,143 @@
+package proptest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"pgregory.net/rapid"
+)
+
+// Command is one operation a StateMachine can perform against both the
+// system under test and its reference model, modeled after rapid's own
+// stateful-testing actions (see rapid.T.Repeat) but typed so Run, Next,
+// and Postcondition don't need type assertions back out of any.
+//
+// Args is shared by every Command in a given StateMachine rather than
+// being its own type parameter per command, since a Go map can't hold
+// values of Command[S, M, Args] instantiated at different Args types -
+// callers that need per-command argument shapes should make Args a
+// sum type (e.g. a small interface or a struct with a Kind field) and
+// have Gen/Run/Next switch on it.
+type Command[S, M, Args any] struct {
+	// Gen draws the arguments for one invocation of this command.
+	Gen func(rt *rapid.T) Args
+
+	// Precondition reports whether this command may run against the
+	// current model state. Nil means always.
+	Precondition func(model M) bool
+
+	// Run executes the command against the system under test and
+	// returns its result.
+	Run func(sut S, args Args) any
+
+	// Next returns the model state after applying this command. It
+	// should track whatever effect Run has (or should have) on sut,
+	// without touching sut itself.
+	Next func(model M, args Args) M
+
+	// Postcondition checks Run's result against the model state after
+	// Next has been applied, returning a non-nil error on mismatch.
+	// Nil means no check beyond Run not panicking.
+	Postcondition func(model M, result any) error
+}
+
+// StateMachine describes a property test comparing a system under test
+// S against a reference model M across sequences of Commands: InitSUT
+// builds a fresh S, InitModel the matching fresh M, and RunStateMachine
+// drives rapid to generate and execute random command sequences against
+// both, checking Postcondition after every step.
+type StateMachine[S, M, Args any] struct {
+	InitSUT   func() S
+	InitModel func() M
+	Commands  map[string]Command[S, M, Args]
+}
+
+// step records one executed command for the trace RunStateMachine
+// prints on failure.
+type step[M, Args any] struct {
+	name  string
+	args  Args
+	model M
+}
+
+// RunStateMachine drives rapid to generate sequences of up to maxSteps
+// commands from sm.Commands, executing each against a fresh SUT/model
+// pair (from sm.InitSUT/sm.InitModel) and checking Postcondition after
+// every step. A command whose Precondition rejects the current model
+// is skipped, and the skipped draw still counts toward maxSteps.
+//
+// On failure, rapid's own shrinking (same mechanism as rapid.Check)
+// minimizes the command sequence, and the failure message includes the
+// minimized trace with a cmp.Diff of the model between each step, so
+// the regression is localized to the step that first diverges.
+func RunStateMachine[S, M, Args any](t TB, sm StateMachine[S, M, Args], maxSteps int) {
+	t.Helper()
+
+	names := make([]string, 0, len(sm.Commands))
+	for name := range sm.Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	nameGen := rapid.SampledFrom(names)
+
+	rapid.Check(t, func(rt *rapid.T) {
+		sut := sm.InitSUT()
+		model := sm.InitModel()
+		trace := make([]step[M, Args], 0, maxSteps)
+
+		for i := 0; i < maxSteps; i++ {
+			name := nameGen.Draw(rt, "command")
+			cmd := sm.Commands[name]
+			if cmd.Precondition != nil && !cmd.Precondition(model) {
+				continue
+			}
+
+			args := cmd.Gen(rt)
+			result := cmd.Run(sut, args)
+			nextModel := cmd.Next(model, args)
+
+			if cmd.Postcondition != nil {
+				if err := cmd.Postcondition(nextModel, result); err != nil {
+					rt.Fatalf("step %d (%s) failed postcondition: %v\n%s",
+						len(trace), name, err, formatTrace(append(trace, step[M, Args]{name: name, args: args, model: nextModel})))
+				}
+			}
+
+			trace = append(trace, step[M, Args]{name: name, args: args, model: nextModel})
+			model = nextModel
+		}
+	})
+}
+
+// TB is the subset of testing.TB rapid.Check needs, letting
+// RunStateMachine be called from a rapid.T-driven subtest as well as a
+// top-level *testing.T.
+type TB = rapid.TB
+
+// formatTrace renders trace as one line per step, each showing the
+// command name, its drawn args, and a cmp.Diff of the model against the
+// previous step - so a failure report shows exactly which step first
+// diverged the model rather than just the final state.
+func formatTrace[M, Args any](trace []step[M, Args]) string {
+	var b strings.Builder
+	var prev M
+	for i, s := range trace {
+		fmt.Fprintf(&b, "  [%d] %s(%#v)\n", i, s.name, s.args)
+		if diff := diffReport(prev, s.model); diff != "" {
+			fmt.Fprintf(&b, "      model diff:\n%s\n", indent(diff, "      "))
+		}
+		prev = s.model
+	}
+	return b.String()
+}
+
+// indent prefixes every line of s with prefix, for nesting diffReport's
+// output under formatTrace's per-step lines.
+func indent(s, prefix string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,63 @@
+package proptest
+
+import (
+	"fmt"
+	"testing"
+
+	"pgregory.net/rapid"
+)
+
+// counterArgs is the shared Args type for the counter example below:
+// only Add needs a value, but every Command in a StateMachine shares
+// one Args type (see Command's doc comment).
+type counterArgs struct {
+	delta int
+}
+
+func TestRunStateMachine_CounterMatchesModel(t *testing.T) {
+	sm := StateMachine[*int, int, counterArgs]{
+		InitSUT:   func() *int { return new(int) },
+		InitModel: func() int { return 0 },
+		Commands: map[string]Command[*int, int, counterArgs]{
+			"add": {
+				Gen: func(rt *rapid.T) counterArgs {
+					return counterArgs{delta: rapid.IntRange(-10, 10).Draw(rt, "delta")}
+				},
+				Run: func(sut *int, args counterArgs) any {
+					*sut += args.delta
+					return *sut
+				},
+				Next: func(model int, args counterArgs) int {
+					return model + args.delta
+				},
+				Postcondition: func(model int, result any) error {
+					if result.(int) != model {
+						return fmt.Errorf("sut=%d model=%d", result, model)
+					}
+					return nil
+				},
+			},
+			"reset": {
+				Gen: func(rt *rapid.T) counterArgs { return counterArgs{} },
+				Precondition: func(model int) bool {
+					return model != 0
+				},
+				Run: func(sut *int, _ counterArgs) any {
+					*sut = 0
+					return *sut
+				},
+				Next: func(_ int, _ counterArgs) int {
+					return 0
+				},
+				Postcondition: func(model int, result any) error {
+					if result.(int) != model {
+						return fmt.Errorf("sut=%d model=%d", result, model)
+					}
+					return nil
+				},
+			},
+		},
+	}
+
+	RunStateMachine(t, sm, 50)
+}
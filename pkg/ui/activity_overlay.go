@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/activity"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// activityState attaches an activity.Store and the burndown overlay's
+// visibility to a BoardModel, attached by identity the same way
+// lintState is (see BoardModel's side-table doc comment in board.go).
+type activityState struct {
+	mu      sync.Mutex
+	store   *activity.Store
+	visible bool
+}
+
+var (
+	activityStatesMu sync.Mutex
+	activityStates   = map[*BoardModel]*activityState{}
+)
+
+func init() {
+	registerBoardCloseHook(func(b *BoardModel) {
+		activityStatesMu.Lock()
+		delete(activityStates, b)
+		activityStatesMu.Unlock()
+	})
+}
+
+func activityStateFor(b *BoardModel) *activityState {
+	activityStatesMu.Lock()
+	defer activityStatesMu.Unlock()
+	st, ok := activityStates[b]
+	if !ok {
+		st = &activityState{}
+		activityStates[b] = st
+	}
+	return st
+}
+
+// SetActivity wires store into b, enabling column-header sparklines,
+// ColumnThroughput/IssueVelocity queries, and BurndownView.
+func (b *BoardModel) SetActivity(store *activity.Store) {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.store = store
+}
+
+// Activity returns b's activity.Store, or nil if SetActivity hasn't been
+// called yet.
+func (b *BoardModel) Activity() *activity.Store {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.store
+}
+
+// columnLabel maps a destination column index, under the board's current
+// swim-lane mode, to the string activity events are recorded against,
+// using the same LayoutConfig preset applyColumnValue mutates issues
+// against. Priority columns keep their own "pN" label since the raw
+// priority digit isn't distinctive enough as an activity key on its own.
+func columnLabel(layout LayoutConfig, swimLaneMode string, col int) (string, bool) {
+	preset, ok := layout.Lookup(swimLaneMode)
+	if !ok || col < 0 || col >= len(preset.Columns) {
+		return "", false
+	}
+	if swimLaneMode == "Priority" {
+		return fmt.Sprintf("p%s", preset.Columns[col].Value), true
+	}
+	return preset.Columns[col].Value, true
+}
+
+// ColumnThroughput returns the number of activity events recorded against
+// column col (under the board's current swim-lane mode) within the last
+// window. Returns 0 if no activity.Store is set or col is out of range.
+func (b *BoardModel) ColumnThroughput(col int, window time.Duration) int {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	store := st.store
+	st.mu.Unlock()
+	if store == nil {
+		return 0
+	}
+
+	label, ok := columnLabel(b.ActiveLayout(), b.GetSwimLaneModeName(), col)
+	if !ok {
+		return 0
+	}
+	return store.ColumnThroughput(label, window)
+}
+
+// IssueVelocity returns issueID's recent event rate in events/day, or 0
+// if no activity.Store is set.
+func (b *BoardModel) IssueVelocity(issueID string) float64 {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	store := st.store
+	st.mu.Unlock()
+	if store == nil {
+		return 0
+	}
+	return store.IssueVelocity(issueID)
+}
+
+// sparklineGlyphs renders counts as a compact unicode bar per entry,
+// scaled against the largest count in the slice.
+func sparklineGlyphs(counts []int) string {
+	bars := []rune(" ▁▂▃▄▅▆▇█")
+	max := 0
+	for _, c := range counts {
+		if c > max {
+			max = c
+		}
+	}
+	var sb strings.Builder
+	for _, c := range counts {
+		if max == 0 {
+			sb.WriteRune(bars[0])
+			continue
+		}
+		idx := c * (len(bars) - 1) / max
+		sb.WriteRune(bars[idx])
+	}
+	return sb.String()
+}
+
+// ColumnSparkline renders col's throughput over the last 14 days as a
+// compact unicode sparkline, plus a delta indicator (▲/▼/▬) versus the
+// prior 14-day period, for display in that column's header.
+func (b *BoardModel) ColumnSparkline(col int) string {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	store := st.store
+	st.mu.Unlock()
+	if store == nil {
+		return ""
+	}
+
+	label, ok := columnLabel(b.ActiveLayout(), b.GetSwimLaneModeName(), col)
+	if !ok {
+		return ""
+	}
+
+	snap := store.Snapshot()
+	daily := snap.ColumnDaily[label]
+	if len(daily) == 0 {
+		return ""
+	}
+
+	spark := sparklineGlyphs(daily)
+	delta := snap.ColumnDelta[label]
+
+	indicator, color := "▬", b.theme.Secondary
+	switch {
+	case delta > 0.01:
+		indicator, color = "▲", b.theme.Open
+	case delta < -0.01:
+		indicator, color = "▼", b.theme.Blocked
+	}
+
+	return spark + " " + b.theme.Renderer.NewStyle().Foreground(color).Render(indicator)
+}
+
+// ShowBurndownView opens the full-screen burndown overlay.
+func (b *BoardModel) ShowBurndownView() {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.visible = true
+}
+
+// HideBurndownView closes the burndown overlay.
+func (b *BoardModel) HideBurndownView() {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.visible = false
+}
+
+// BurndownViewVisible reports whether the burndown overlay is shown.
+func (b *BoardModel) BurndownViewVisible() bool {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.visible
+}
+
+// BurndownView renders a full-screen horizon chart stacking completed
+// issues (bottom, filled) against the board's current open count (top,
+// a flat reference bar), one column per day over the last 14 days.
+func (b *BoardModel) BurndownView(width, height int) string {
+	st := activityStateFor(b)
+	st.mu.Lock()
+	store := st.store
+	st.mu.Unlock()
+	if store == nil {
+		return "No activity data to display."
+	}
+
+	snap := store.Snapshot()
+	closedPerDay := make([]int, sparklineWindowDays)
+	for _, counts := range snap.ColumnDaily {
+		for i, c := range counts {
+			if i < len(closedPerDay) {
+				closedPerDay[i] += c
+			}
+		}
+	}
+
+	open := 0
+	for _, iss := range b.AllIssues() {
+		if iss.Status != model.StatusClosed {
+			open++
+		}
+	}
+
+	maxClosed := 0
+	for _, c := range closedPerDay {
+		if c > maxClosed {
+			maxClosed = c
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("Burndown (last %d days) - open: %d", sparklineWindowDays, open),
+		b.theme.Renderer.NewStyle().Foreground(b.theme.Open).Render(strings.Repeat("█", width)) + fmt.Sprintf(" open (%d)", open),
+	}
+	for day := 0; day < len(closedPerDay); day++ {
+		barLen := 0
+		if maxClosed > 0 {
+			barLen = closedPerDay[day] * width / maxClosed
+		}
+		bar := b.theme.Renderer.NewStyle().Foreground(b.theme.Closed).Render(strings.Repeat("█", barLen))
+		lines = append(lines, fmt.Sprintf("%s day-%d: %d closed", bar, len(closedPerDay)-day, closedPerDay[day]))
+	}
+
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sparklineWindowDays mirrors the activity package's sparkline window so
+// BurndownView's day axis lines up with ColumnSparkline's.
+const sparklineWindowDays = 14
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/activity"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestColumnThroughputDelegatesToActivityStore(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := []model.Issue{{ID: "bv-1", Status: model.StatusClosed}}
+	b := NewBoardModel(issues, theme)
+
+	if got := b.ColumnThroughput(3, 24*time.Hour); got != 0 {
+		t.Fatalf("expected 0 throughput with no activity.Store set, got %d", got)
+	}
+
+	store := activity.NewStore()
+	store.RecordEvent("bv-1", string(model.StatusClosed), activity.EventClosed, time.Now())
+	b.SetActivity(store)
+
+	if got := b.ColumnThroughput(3, 24*time.Hour); got != 1 {
+		t.Errorf("expected 1 event in the closed column, got %d", got)
+	}
+}
+
+func TestColumnSparklineEmptyWithoutActivity(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	b := NewBoardModel([]model.Issue{}, theme)
+
+	if got := b.ColumnSparkline(0); got != "" {
+		t.Errorf("expected empty sparkline with no activity.Store set, got %q", got)
+	}
+}
+
+func TestBurndownViewOverlayVisibility(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	b := NewBoardModel([]model.Issue{}, theme)
+
+	if b.BurndownViewVisible() {
+		t.Fatal("expected burndown overlay hidden by default")
+	}
+	b.ShowBurndownView()
+	if !b.BurndownViewVisible() {
+		t.Fatal("expected burndown overlay visible after ShowBurndownView")
+	}
+	b.HideBurndownView()
+	if b.BurndownViewVisible() {
+		t.Fatal("expected burndown overlay hidden after HideBurndownView")
+	}
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// BoardModel is the board's core state: the loaded issue set, the
+// single-cursor selection, the raw search-prompt string, and the active
+// swim-lane mode every column-bucketing helper reads.
+//
+// This checkout never received the board's full rendering/Update/View
+// implementation - pkg/ui/board_test.go's BoardModel scenarios predate
+// every commit in this package and still can't run without it, since
+// they exercise far more (SetSwimLaneMode, Init/Update/View, detail-panel
+// toggling) than any request in this backlog asked for. This file only
+// supplies the bootstrap state and accessors those later commits assumed
+// were already present when they extended BoardModel (board_mutate.go,
+// graph.go, layout_config.go, layout_preset.go, lint_overlay.go,
+// plugins.go, search_query.go, search_suggest.go, activity_overlay.go),
+// so pkg/ui - and pkg/export, which depends on it - builds again.
+type BoardModel struct {
+	theme Theme
+
+	mu         sync.RWMutex
+	issues     []model.Issue
+	selectedID string
+
+	searchMode  bool
+	searchQuery string
+}
+
+// NewBoardModel creates a BoardModel over issues, selecting the first
+// one (if any) and defaulting to the "Status" swim-lane mode.
+func NewBoardModel(issues []model.Issue, theme Theme) *BoardModel {
+	b := &BoardModel{theme: theme}
+	b.SetIssues(issues)
+	return b
+}
+
+// AllIssues returns a copy of the issues currently loaded.
+func (b *BoardModel) AllIssues() []model.Issue {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	out := make([]model.Issue, len(b.issues))
+	copy(out, b.issues)
+	return out
+}
+
+// SetIssues replaces the loaded issue set, preserving the current
+// selection by ID when it still exists and falling back to the first
+// issue (or no selection, for an empty set) otherwise.
+func (b *BoardModel) SetIssues(issues []model.Issue) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.issues = make([]model.Issue, len(issues))
+	copy(b.issues, issues)
+
+	if b.selectedID != "" && indexByID(b.issues, b.selectedID) >= 0 {
+		return
+	}
+	if len(b.issues) > 0 {
+		b.selectedID = b.issues[0].ID
+	} else {
+		b.selectedID = ""
+	}
+}
+
+// SelectedIssue returns a copy of the currently selected issue, or nil
+// if the board holds no issues.
+func (b *BoardModel) SelectedIssue() *model.Issue {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	idx := indexByID(b.issues, b.selectedID)
+	if idx < 0 {
+		return nil
+	}
+	issue := b.issues[idx]
+	return &issue
+}
+
+// SelectByID selects the issue with the given ID, reporting whether it
+// was found.
+func (b *BoardModel) SelectByID(id string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if indexByID(b.issues, id) < 0 {
+		return false
+	}
+	b.selectedID = id
+	return true
+}
+
+// swimLaneModeMu and swimLaneModeStates carry the active swim-lane mode
+// name for a BoardModel, keyed by identity like layoutConfigStates and
+// layoutPresetStates, so NewBoardModel doesn't need a field for it.
+var (
+	swimLaneModeMu     sync.Mutex
+	swimLaneModeStates = map[*BoardModel]string{}
+)
+
+func init() {
+	registerBoardCloseHook(func(b *BoardModel) {
+		swimLaneModeMu.Lock()
+		delete(swimLaneModeStates, b)
+		swimLaneModeMu.Unlock()
+	})
+}
+
+// GetSwimLaneModeName reports the name of b's active swim-lane mode
+// (e.g. "Status", "Priority", "Type"), defaulting to the first entry in
+// b.ActiveLayout() if no mode has been set yet.
+func (b *BoardModel) GetSwimLaneModeName() string {
+	swimLaneModeMu.Lock()
+	mode, ok := swimLaneModeStates[b]
+	swimLaneModeMu.Unlock()
+	if ok {
+		return mode
+	}
+	if lanes := b.ActiveLayout().SwimLanes; len(lanes) > 0 {
+		return lanes[0].Name
+	}
+	return ""
+}
+
+// ColumnCount returns how many loaded issues fall into column col of
+// the board's active swim lane.
+func (b *BoardModel) ColumnCount(col int) int {
+	layout := b.ActiveLayout()
+	preset, ok := layout.Lookup(b.GetSwimLaneModeName())
+	if !ok || col < 0 || col >= len(preset.Columns) {
+		return 0
+	}
+	value := preset.Columns[col].Value
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	count := 0
+	for _, issue := range b.issues {
+		if columnValueOf(issue, preset.Name) == value {
+			count++
+		}
+	}
+	return count
+}
+
+// columnValueOf returns issue's stringified value for swimLaneMode - the
+// read-only counterpart to applyColumnValue's mutation, used by
+// ColumnCount to bucket issues without mutating them.
+func columnValueOf(issue model.Issue, swimLaneMode string) string {
+	switch swimLaneMode {
+	case "Status":
+		return string(issue.Status)
+	case "Priority":
+		return fmt.Sprintf("%d", issue.Priority)
+	case "Type":
+		return string(issue.IssueType)
+	default:
+		return ""
+	}
+}
+
+// StartSearch enters search mode with an empty query.
+func (b *BoardModel) StartSearch() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.searchMode = true
+	b.searchQuery = ""
+}
+
+// CancelSearch exits search mode and clears the query.
+func (b *BoardModel) CancelSearch() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.searchMode = false
+	b.searchQuery = ""
+}
+
+// AppendSearchChar appends r to the in-progress search query.
+func (b *BoardModel) AppendSearchChar(r rune) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.searchQuery += string(r)
+}
+
+// SearchQuery returns the current search prompt's raw text.
+func (b *BoardModel) SearchQuery() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.searchQuery
+}
+
+// boardCloseHooks lets feature files that attach extra state to a
+// BoardModel by identity (activityStates, lintStates,
+// searchSuggestStates, and the rest of BoardModel's side tables) release
+// that state when Close is called, without board.go needing to know
+// about every such map. A side table keyed by identity that never
+// registers a hook here leaks one entry per discarded BoardModel for the
+// life of the process.
+var (
+	boardCloseHooksMu sync.Mutex
+	boardCloseHooks   []func(*BoardModel)
+)
+
+// registerBoardCloseHook adds fn to the set Close runs when a BoardModel
+// is discarded. Call it from an init() alongside any new map[*BoardModel]...
+// side table.
+func registerBoardCloseHook(fn func(*BoardModel)) {
+	boardCloseHooksMu.Lock()
+	defer boardCloseHooksMu.Unlock()
+	boardCloseHooks = append(boardCloseHooks, fn)
+}
+
+// Close releases b's identity-keyed side-table state. Callers that
+// discard a BoardModel before process exit (closing a tab, reloading a
+// board) must call Close, or that state outlives b indefinitely.
+func (b *BoardModel) Close() {
+	boardCloseHooksMu.Lock()
+	hooks := make([]func(*BoardModel), len(boardCloseHooks))
+	copy(hooks, boardCloseHooks)
+	boardCloseHooksMu.Unlock()
+	for _, fn := range hooks {
+		fn(b)
+	}
+}
@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ColumnConfig customizes per-column behavior for interactive card
+// mutation, such as a WIP limit that blocks further moves into the column.
+type ColumnConfig struct {
+	WIPLimit int // 0 means unlimited
+}
+
+// MutationKind identifies the field a MutationEvent changed.
+type MutationKind string
+
+const (
+	MutationStatusChanged   MutationKind = "status_changed"
+	MutationPriorityChanged MutationKind = "priority_changed"
+	MutationTypeChanged     MutationKind = "type_changed"
+)
+
+// MutationEvent describes a single card mutation the host app should
+// persist to the beads store.
+type MutationEvent struct {
+	Kind     MutationKind
+	IssueID  string
+	OldValue string
+	NewValue string
+}
+
+// boardMutationState carries the WIP-limit config and mutation event
+// channel for a BoardModel, keyed by its identity rather than added as a
+// field so existing callers of NewBoardModel are unaffected.
+type boardMutationState struct {
+	mu      sync.Mutex
+	configs []ColumnConfig
+	events  chan MutationEvent
+}
+
+var (
+	mutationStateMu sync.Mutex
+	mutationStates  = map[*BoardModel]*boardMutationState{}
+)
+
+func init() {
+	registerBoardCloseHook(func(b *BoardModel) {
+		mutationStateMu.Lock()
+		delete(mutationStates, b)
+		mutationStateMu.Unlock()
+	})
+}
+
+func mutationStateFor(b *BoardModel) *boardMutationState {
+	mutationStateMu.Lock()
+	defer mutationStateMu.Unlock()
+	st, ok := mutationStates[b]
+	if !ok {
+		st = &boardMutationState{events: make(chan MutationEvent, 32)}
+		mutationStates[b] = st
+	}
+	return st
+}
+
+// SetColumnConfig sets the WIP-limit configuration for the board's columns
+// under its current swim-lane mode. configs[i] applies to column i; a
+// shorter slice leaves trailing columns unlimited.
+func (b *BoardModel) SetColumnConfig(configs []ColumnConfig) {
+	st := mutationStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.configs = configs
+}
+
+func (b *BoardModel) wipLimit(col int) int {
+	st := mutationStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if col < 0 || col >= len(st.configs) {
+		return 0
+	}
+	return st.configs[col].WIPLimit
+}
+
+// Mutations returns the channel that MoveSelectedTo and SetSelectedPriority
+// publish MutationEvents to, so the host app can persist them to the beads
+// store. The channel is buffered; a full channel drops the oldest pending
+// event rather than blocking the render loop.
+func (b *BoardModel) Mutations() <-chan MutationEvent {
+	return mutationStateFor(b).events
+}
+
+func (b *BoardModel) publishMutation(ev MutationEvent) {
+	events := mutationStateFor(b).events
+	select {
+	case events <- ev:
+		return
+	default:
+	}
+	select {
+	case <-events:
+	default:
+	}
+	select {
+	case events <- ev:
+	default:
+	}
+}
+
+// MoveSelectedTo moves the selected card into column col under the
+// board's current swim-lane grouping (Status, Priority bucket, or
+// IssueType), changing the field applyColumnValue maps col to. Nothing
+// is re-sorted: the issue keeps its place in AllIssues. The move is
+// rejected, without changing the issue, if col is already at its
+// configured WIP limit.
+func (b *BoardModel) MoveSelectedTo(col int) error {
+	issue := b.SelectedIssue()
+	if issue == nil {
+		return nil
+	}
+
+	if limit := b.wipLimit(col); limit > 0 && b.ColumnCount(col) >= limit {
+		return fmt.Errorf("column %d is at its WIP limit of %d", col, limit)
+	}
+
+	all := b.AllIssues()
+	idx := indexByID(all, issue.ID)
+	if idx < 0 {
+		return fmt.Errorf("issue %q not found", issue.ID)
+	}
+
+	kind, oldValue, newValue, err := applyColumnValue(&all[idx], b.ActiveLayout(), b.GetSwimLaneModeName(), col)
+	if err != nil {
+		return err
+	}
+
+	b.SetIssues(all)
+	b.SelectByID(issue.ID)
+
+	b.publishMutation(MutationEvent{Kind: kind, IssueID: issue.ID, OldValue: oldValue, NewValue: newValue})
+	return nil
+}
+
+// SetSelectedPriority sets the selected card's priority directly,
+// independent of the current swim-lane mode. Nothing is re-sorted: the
+// issue keeps its place in AllIssues.
+func (b *BoardModel) SetSelectedPriority(p int) error {
+	issue := b.SelectedIssue()
+	if issue == nil {
+		return nil
+	}
+
+	all := b.AllIssues()
+	idx := indexByID(all, issue.ID)
+	if idx < 0 {
+		return fmt.Errorf("issue %q not found", issue.ID)
+	}
+
+	old := all[idx].Priority
+	all[idx].Priority = p
+	b.SetIssues(all)
+	b.SelectByID(issue.ID)
+
+	b.publishMutation(MutationEvent{
+		Kind:     MutationPriorityChanged,
+		IssueID:  issue.ID,
+		OldValue: fmt.Sprintf("%d", old),
+		NewValue: fmt.Sprintf("%d", p),
+	})
+	return nil
+}
+
+// applyColumnValue maps a destination column index, under the given
+// swim-lane mode, to the model.Issue field it represents and mutates it
+// in place. The column's value comes from layout's preset for
+// swimLaneMode rather than a hard-coded list, so a board with a custom
+// LayoutConfig (see LoadLayout) reorders or renames columns without
+// changing this mapping logic.
+func applyColumnValue(issue *model.Issue, layout LayoutConfig, swimLaneMode string, col int) (kind MutationKind, oldValue, newValue string, err error) {
+	preset, ok := layout.Lookup(swimLaneMode)
+	if !ok {
+		return "", "", "", fmt.Errorf("unknown swim-lane mode %q", swimLaneMode)
+	}
+	if col < 0 || col >= len(preset.Columns) {
+		return "", "", "", fmt.Errorf("no %s column %d", swimLaneMode, col)
+	}
+	value := preset.Columns[col].Value
+
+	switch swimLaneMode {
+	case "Status":
+		oldValue = string(issue.Status)
+		issue.Status = model.Status(value)
+		return MutationStatusChanged, oldValue, value, nil
+	case "Priority":
+		p, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return "", "", "", fmt.Errorf("priority column %d has non-numeric value %q", col, value)
+		}
+		oldValue = fmt.Sprintf("%d", issue.Priority)
+		issue.Priority = p
+		return MutationPriorityChanged, oldValue, fmt.Sprintf("%d", issue.Priority), nil
+	case "Type":
+		oldValue = string(issue.IssueType)
+		issue.IssueType = model.IssueType(value)
+		return MutationTypeChanged, oldValue, value, nil
+	default:
+		return "", "", "", fmt.Errorf("swim-lane mode %q has no mutable field mapping", swimLaneMode)
+	}
+}
+
+func indexByID(issues []model.Issue, id string) int {
+	for i := range issues {
+		if issues[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,67 @@
+package ui_test
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSetSelectedPriorityUpdatesIssue(t *testing.T) {
+	theme := ui.DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen, Priority: 2},
+	}
+	b := ui.NewBoardModel(issues, theme)
+
+	if err := b.SetSelectedPriority(0); err != nil {
+		t.Fatalf("SetSelectedPriority returned error: %v", err)
+	}
+
+	sel := b.SelectedIssue()
+	if sel == nil || sel.Priority != 0 {
+		t.Fatalf("expected selected issue priority 0, got %+v", sel)
+	}
+}
+
+func TestMoveSelectedToRespectsWIPLimit(t *testing.T) {
+	theme := ui.DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Two", Status: model.StatusInProgress},
+	}
+	b := ui.NewBoardModel(issues, theme)
+	b.SetColumnConfig([]ui.ColumnConfig{{}, {WIPLimit: 1}})
+
+	if err := b.MoveSelectedTo(1); err == nil {
+		t.Fatal("expected MoveSelectedTo to reject a move into a column at its WIP limit")
+	}
+
+	sel := b.SelectedIssue()
+	if sel == nil || sel.Status != model.StatusOpen {
+		t.Fatalf("expected rejected move to leave issue unchanged, got %+v", sel)
+	}
+}
+
+func TestMoveSelectedToPublishesMutation(t *testing.T) {
+	theme := ui.DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen},
+	}
+	b := ui.NewBoardModel(issues, theme)
+
+	if err := b.MoveSelectedTo(1); err != nil {
+		t.Fatalf("MoveSelectedTo returned error: %v", err)
+	}
+
+	select {
+	case ev := <-b.Mutations():
+		if ev.IssueID != "bv-1" || ev.Kind != ui.MutationStatusChanged {
+			t.Errorf("unexpected mutation event: %+v", ev)
+		}
+	default:
+		t.Fatal("expected a MutationEvent to be published")
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui/testkit"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -947,17 +948,17 @@ func TestDetailPanelRenderWithWidth(t *testing.T) {
 
 	b.ShowDetail()
 
-	// At narrow width (80), detail panel shouldn't show
+	// At narrow width (80), the detail pane's border doesn't fit and is
+	// dropped in favor of the full-width board.
 	output80 := b.View(80, 30)
+	buf80 := testkit.ParseANSI(output80, 80, 30)
+	testkit.AssertNotContainsText(t, buf80, "╭")
 
-	// At wide width (160), detail panel should show
+	// At wide width (160), the detail pane renders with its rounded
+	// border alongside the board.
 	output160 := b.View(160, 30)
-
-	// Wide output should be longer (includes detail panel)
-	// This is a heuristic - the exact behavior depends on implementation
-	if len(output160) < len(output80) {
-		t.Log("Note: Detail panel may not show at 160 width depending on implementation threshold")
-	}
+	buf160 := testkit.ParseANSI(output160, 160, 30)
+	testkit.AssertContainsText(t, buf160, "╭")
 }
 
 // â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•â•
@@ -975,13 +976,10 @@ func TestLayoutNarrow80(t *testing.T) {
 	b := ui.NewBoardModel(issues, theme)
 
 	output := b.View(80, 24)
-	if output == "" {
-		t.Error("Board should render at 80 cols")
-	}
-	// Cards should still be readable
-	if len(output) < 100 {
-		t.Error("Output seems too short for 80 col view")
-	}
+	buf := testkit.ParseANSI(output, 80, 24)
+	testkit.AssertContainsText(t, buf, "Task 1")
+	testkit.AssertContainsText(t, buf, "Task 2")
+	testkit.AssertContainsText(t, buf, "Task 3")
 }
 
 // TestLayoutMedium120 verifies board renders at medium terminal
@@ -1224,13 +1222,11 @@ func TestColumnStatsNarrowWidth(t *testing.T) {
 	}
 	b := ui.NewBoardModel(issues, theme)
 
-	// At narrow width (<100), should just show count without P0/P1 indicators
+	// At narrow width (<100), should just show the count, no P0/P1 breakdown.
 	output := b.View(80, 24)
-	if output == "" {
-		t.Error("Should render at narrow width")
-	}
-	// The header should include the count "(3)" but not necessarily P0/P1 indicators
-	// (Visual verification - output rendering depends on exact implementation)
+	buf := testkit.ParseANSI(output, 80, 24)
+	testkit.AssertContainsText(t, buf, "(3)")
+	testkit.AssertNotContainsText(t, buf, "P0:")
 }
 
 // TestColumnStatsMediumWidth verifies P0/P1 counts at medium width (100-140)
@@ -1244,12 +1240,11 @@ func TestColumnStatsMediumWidth(t *testing.T) {
 	}
 	b := ui.NewBoardModel(issues, theme)
 
-	// At medium width (100-140), should show P0/P1 indicators
+	// At medium width (100-140), the header breaks the count down by
+	// priority for the top priorities present.
 	output := b.View(120, 30)
-	if output == "" {
-		t.Error("Should render at medium width")
-	}
-	// Should include priority indicators in header
+	buf := testkit.ParseANSI(output, 120, 30)
+	testkit.AssertContainsText(t, buf, "(4) P0:2 P1:1")
 }
 
 // TestColumnStatsWideWidth verifies full stats at wide width (>140)
@@ -1262,12 +1257,12 @@ func TestColumnStatsWideWidth(t *testing.T) {
 	}
 	b := ui.NewBoardModel(issues, theme)
 
-	// At wide width (>140), should show P0/P1 + oldest age
+	// At wide width (>140), the header adds an oldest-card age badge
+	// alongside the priority breakdown.
 	output := b.View(160, 30)
-	if output == "" {
-		t.Error("Should render at wide width")
-	}
-	// Should include age indicator in header
+	buf := testkit.ParseANSI(output, 160, 30)
+	testkit.AssertContainsText(t, buf, "P0:1 P1:1")
+	testkit.AssertContainsText(t, buf, "60d")
 }
 
 // TestColumnStatsBlockedCountInProgress verifies blocked count shows in In Progress column
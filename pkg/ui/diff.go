@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Diff renders a unified diff, mirroring Code's left-accent-border
+// style: added ("+"), removed ("-"), and hunk-header ("@@") lines get
+// theme-driven foreground colors, and Language, if set, additionally
+// token-colors each line's code content with a small built-in lexer
+// for keywords/strings/comments - no chroma dependency required.
+type Diff struct {
+	Text     string
+	Language string
+}
+
+func (d Diff) Render(theme Theme, width int) string {
+	r := theme.Renderer
+
+	addedStyle := r.NewStyle().Foreground(theme.Open)
+	removedStyle := r.NewStyle().Foreground(theme.Blocked)
+	hunkStyle := r.NewStyle().Foreground(theme.Muted).Bold(true)
+	contextStyle := r.NewStyle().Foreground(theme.Base.GetForeground())
+
+	lex := lexerFor(d.Language)
+
+	lines := strings.Split(strings.TrimRight(d.Text, "\n"), "\n")
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "@@"),
+			strings.HasPrefix(line, "+++"),
+			strings.HasPrefix(line, "---"):
+			rendered[i] = hunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			rendered[i] = addedStyle.Render("+") + renderDiffContent(line[1:], lex, theme, addedStyle)
+		case strings.HasPrefix(line, "-"):
+			rendered[i] = removedStyle.Render("-") + renderDiffContent(line[1:], lex, theme, removedStyle)
+		default:
+			rendered[i] = " " + renderDiffContent(strings.TrimPrefix(line, " "), lex, theme, contextStyle)
+		}
+	}
+
+	accentBorder := lipgloss.Border{Left: "│"}
+	container := r.NewStyle().
+		Background(lipgloss.AdaptiveColor{Light: "#F5F5F5", Dark: "#282A36"}).
+		Border(accentBorder).
+		BorderForeground(theme.Primary).
+		PaddingLeft(1).
+		PaddingRight(1).
+		Width(width - 4)
+
+	return container.Render(strings.Join(rendered, "\n"))
+}
+
+// renderDiffContent renders one diff line's content (the marker already
+// stripped) in baseStyle, overlaying lex's keyword/string/comment token
+// colors on top when lex is non-nil.
+func renderDiffContent(content string, lex *diffLexer, theme Theme, baseStyle lipgloss.Style) string {
+	if lex == nil {
+		return baseStyle.Render(content)
+	}
+	r := theme.Renderer
+	var sb strings.Builder
+	for _, tok := range tokenizeLine(content, lex) {
+		switch tok.kind {
+		case diffTokenKeyword:
+			sb.WriteString(r.NewStyle().Foreground(theme.Primary).Bold(true).Render(tok.text))
+		case diffTokenString:
+			sb.WriteString(r.NewStyle().Foreground(theme.Feature).Render(tok.text))
+		case diffTokenComment:
+			sb.WriteString(r.NewStyle().Foreground(theme.Muted).Italic(true).Render(tok.text))
+		default:
+			sb.WriteString(baseStyle.Render(tok.text))
+		}
+	}
+	return sb.String()
+}
+
+type diffTokenKind int
+
+const (
+	diffTokenOther diffTokenKind = iota
+	diffTokenKeyword
+	diffTokenString
+	diffTokenComment
+)
+
+type diffToken struct {
+	text string
+	kind diffTokenKind
+}
+
+// diffLexer is a small, line-at-a-time lexer: enough to color keywords,
+// string literals, and line comments for a tutorial code sample, not a
+// full language grammar (no multi-line strings/comments, no escapes
+// beyond a backslash skipping the next rune).
+type diffLexer struct {
+	keywords    map[string]bool
+	lineComment string
+}
+
+func lexerFor(language string) *diffLexer {
+	switch strings.ToLower(language) {
+	case "go", "golang":
+		return &diffLexer{keywords: goKeywords, lineComment: "//"}
+	case "python", "py":
+		return &diffLexer{keywords: pythonKeywords, lineComment: "#"}
+	case "javascript", "js", "typescript", "ts":
+		return &diffLexer{keywords: jsKeywords, lineComment: "//"}
+	default:
+		return nil
+	}
+}
+
+var goKeywords = wordSet(
+	"break", "case", "chan", "const", "continue", "default", "defer", "else",
+	"fallthrough", "for", "func", "go", "goto", "if", "import", "interface",
+	"map", "package", "range", "return", "select", "struct", "switch", "type", "var",
+)
+
+var pythonKeywords = wordSet(
+	"and", "as", "assert", "class", "def", "del", "elif", "else", "except",
+	"finally", "for", "from", "global", "if", "import", "in", "is", "lambda",
+	"None", "not", "or", "pass", "raise", "return", "True", "False", "try",
+	"while", "with", "yield",
+)
+
+var jsKeywords = wordSet(
+	"async", "await", "break", "case", "catch", "class", "const", "continue",
+	"default", "delete", "do", "else", "export", "extends", "false", "finally",
+	"for", "function", "if", "import", "in", "instanceof", "let", "new", "null",
+	"return", "super", "switch", "this", "throw", "true", "try", "typeof", "var",
+	"void", "while", "with", "yield",
+)
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// tokenizeLine splits line into runs of comment, string, keyword, and
+// plain text, in source order.
+func tokenizeLine(line string, lex *diffLexer) []diffToken {
+	if lex == nil {
+		return nil
+	}
+	var tokens []diffToken
+	var plain strings.Builder
+	flushPlain := func() {
+		if plain.Len() > 0 {
+			tokens = append(tokens, classifyWords(plain.String(), lex.keywords)...)
+			plain.Reset()
+		}
+	}
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		rest := string(runes[i:])
+		if lex.lineComment != "" && strings.HasPrefix(rest, lex.lineComment) {
+			flushPlain()
+			tokens = append(tokens, diffToken{text: rest, kind: diffTokenComment})
+			return tokens
+		}
+		if c := runes[i]; c == '"' || c == '\'' || c == '`' {
+			flushPlain()
+			str, consumed := scanString(runes[i:], c)
+			tokens = append(tokens, diffToken{text: str, kind: diffTokenString})
+			i += consumed - 1
+			continue
+		}
+		plain.WriteRune(runes[i])
+	}
+	flushPlain()
+	return tokens
+}
+
+// scanString reads a quoted string literal starting at runes[0] (the
+// opening quote), honoring a trailing backslash as escaping the next
+// rune. It returns the literal (including both quotes, or running to
+// the end of line if unterminated) and how many runes were consumed.
+func scanString(runes []rune, quote rune) (string, int) {
+	var sb strings.Builder
+	sb.WriteRune(runes[0])
+	for i := 1; i < len(runes); i++ {
+		sb.WriteRune(runes[i])
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				sb.WriteRune(runes[i])
+			}
+		case quote:
+			return sb.String(), i + 1
+		}
+	}
+	return sb.String(), len(runes)
+}
+
+// classifyWords splits a plain-text run on word boundaries, tagging
+// each identifier-shaped word found in keywords as diffTokenKeyword and
+// leaving everything else (including whitespace and punctuation) as a
+// single diffTokenOther run.
+func classifyWords(text string, keywords map[string]bool) []diffToken {
+	var tokens []diffToken
+	var other strings.Builder
+	var word strings.Builder
+	flushOther := func() {
+		if other.Len() > 0 {
+			tokens = append(tokens, diffToken{text: other.String(), kind: diffTokenOther})
+			other.Reset()
+		}
+	}
+	flushWord := func() {
+		if word.Len() == 0 {
+			return
+		}
+		kind := diffTokenOther
+		if keywords[word.String()] {
+			kind = diffTokenKeyword
+		}
+		tokens = append(tokens, diffToken{text: word.String(), kind: kind})
+		word.Reset()
+	}
+
+	for _, c := range text {
+		if isWordRune(c) {
+			flushOther()
+			word.WriteRune(c)
+			continue
+		}
+		flushWord()
+		other.WriteRune(c)
+	}
+	flushWord()
+	flushOther()
+	return tokens
+}
+
+func isWordRune(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenizeLineNoLexerReturnsNil(t *testing.T) {
+	if tokens := tokenizeLine(`x := "hi"`, nil); tokens != nil {
+		t.Fatalf("expected nil lexer to be handled by the caller, not tokenizeLine itself, got %v", tokens)
+	}
+}
+
+func TestTokenizeLineSplitsKeywordStringAndComment(t *testing.T) {
+	lex := lexerFor("go")
+	tokens := tokenizeLine(`func main() { return "ok" // done`, lex)
+
+	var kinds []diffTokenKind
+	var texts []string
+	for _, tok := range tokens {
+		kinds = append(kinds, tok.kind)
+		texts = append(texts, tok.text)
+	}
+
+	wantHasKeyword := false
+	wantHasString := false
+	wantHasComment := false
+	for i, k := range kinds {
+		switch k {
+		case diffTokenKeyword:
+			if texts[i] == "func" || texts[i] == "return" {
+				wantHasKeyword = true
+			}
+		case diffTokenString:
+			if texts[i] == `"ok"` {
+				wantHasString = true
+			}
+		case diffTokenComment:
+			if texts[i] == "// done" {
+				wantHasComment = true
+			}
+		}
+	}
+	if !wantHasKeyword || !wantHasString || !wantHasComment {
+		t.Fatalf("expected keyword, string, and comment tokens, got kinds=%v texts=%v", kinds, texts)
+	}
+}
+
+func TestTokenizeLineStopsAtCommentForRestOfLine(t *testing.T) {
+	lex := lexerFor("python")
+	tokens := tokenizeLine(`x = 1 # a comment with "quotes"`, lex)
+	last := tokens[len(tokens)-1]
+	if last.kind != diffTokenComment || last.text != `# a comment with "quotes"` {
+		t.Fatalf("expected the trailing comment to swallow the rest of the line, got %#v", last)
+	}
+}
+
+func TestScanStringHandlesEscapedQuote(t *testing.T) {
+	str, consumed := scanString([]rune(`"a\"b"` + "rest"), '"')
+	if str != `"a\"b"` {
+		t.Fatalf("expected the escaped quote to stay inside the string, got %q", str)
+	}
+	if consumed != len([]rune(`"a\"b"`)) {
+		t.Fatalf("expected consumed to match the string's rune length, got %d", consumed)
+	}
+}
+
+func TestLexerForUnknownLanguageIsNil(t *testing.T) {
+	if lexerFor("cobol") != nil {
+		t.Fatal("expected an unrecognized language to return a nil lexer")
+	}
+	if lexerFor("") != nil {
+		t.Fatal("expected an empty language hint to return a nil lexer")
+	}
+}
+
+func TestClassifyWordsTagsOnlyKnownKeywords(t *testing.T) {
+	tokens := classifyWords("for x in range", pythonKeywords)
+	got := map[string]diffTokenKind{}
+	for _, tok := range tokens {
+		got[tok.text] = tok.kind
+	}
+	if got["for"] != diffTokenKeyword || got["in"] != diffTokenKeyword {
+		t.Fatalf("expected 'for' and 'in' to be keywords, got %v", got)
+	}
+	if kind, ok := got["x"]; ok && kind == diffTokenKeyword {
+		t.Fatal("expected 'x' to not be classified as a keyword")
+	}
+}
+
+func TestWordSetContainsGivenWords(t *testing.T) {
+	set := wordSet("a", "b")
+	want := map[string]bool{"a": true, "b": true}
+	if !reflect.DeepEqual(set, want) {
+		t.Fatalf("expected %v, got %v", want, set)
+	}
+}
@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// useGradientProfile reports whether r's renderer can show a smooth
+// multi-stop gradient. 16-color (ANSI) and uncolored (Ascii) profiles
+// can't distinguish enough shades for a gradient to read as anything
+// but noise, so callers fall back to a single solid color on those.
+func useGradientProfile(r *lipgloss.Renderer) bool {
+	switch r.ColorProfile() {
+	case termenv.ANSI, termenv.Ascii:
+		return false
+	default:
+		return true
+	}
+}
+
+// gradientColor returns the color at t (0..1) along a multi-stop
+// gradient, interpolating in HSL between the two stops t falls between.
+// stops pairs 1:1 with colors; if stops is empty (or mismatched in
+// length) the colors are spread evenly across [0, 1]. A t outside the
+// first/last stop clamps to that end color.
+func gradientColor(colors []lipgloss.Color, stops []float64, t float64) lipgloss.Color {
+	switch len(colors) {
+	case 0:
+		return ""
+	case 1:
+		return colors[0]
+	}
+	if len(stops) != len(colors) {
+		stops = evenStops(len(colors))
+	}
+	if t <= stops[0] {
+		return colors[0]
+	}
+	if t >= stops[len(stops)-1] {
+		return colors[len(colors)-1]
+	}
+	for i := 1; i < len(stops); i++ {
+		if t > stops[i] {
+			continue
+		}
+		span := stops[i] - stops[i-1]
+		local := 0.0
+		if span > 0 {
+			local = (t - stops[i-1]) / span
+		}
+		return lerpHSL(string(colors[i-1]), string(colors[i]), local)
+	}
+	return colors[len(colors)-1]
+}
+
+// evenStops spreads n stops evenly across [0, 1].
+func evenStops(n int) []float64 {
+	stops := make([]float64, n)
+	for i := range stops {
+		stops[i] = float64(i) / float64(n-1)
+	}
+	return stops
+}
+
+// lerpHSL blends from the #rrggbb hex colors aHex to bHex at t (0..1),
+// converting through HSL so the blend sweeps through intermediate hues
+// (e.g. green -> amber -> red) instead of interpolating the RGB channels
+// independently, which tends to pass through a muddy gray at the
+// midpoint. Every color this package gradients over (Gradient entries,
+// and FlowStep.Color's Dark variant) is authored as a hex string, so
+// anything else just passes aHex through unchanged.
+func lerpHSL(aHex, bHex string, t float64) lipgloss.Color {
+	r1, g1, b1, ok1 := parseHexColor(aHex)
+	r2, g2, b2, ok2 := parseHexColor(bHex)
+	if !ok1 || !ok2 {
+		return lipgloss.Color(aHex)
+	}
+
+	h1, s1, l1 := rgbToHSL(r1, g1, b1)
+	h2, s2, l2 := rgbToHSL(r2, g2, b2)
+	h := lerpHue(h1, h2, t)
+	s := s1 + (s2-s1)*t
+	l := l1 + (l2-l1)*t
+	r, g, b := hslToRGB(h, s, l)
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x", r, g, b))
+}
+
+// parseHexColor parses a "#rrggbb" (or "rrggbb") string into 8-bit RGB
+// channels.
+func parseHexColor(s string) (r, g, b uint8, ok bool) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v), true
+}
+
+// lerpHue blends two hues (degrees, 0..360) at t along whichever
+// direction around the color wheel is shorter.
+func lerpHue(h1, h2, t float64) float64 {
+	delta := math.Mod(h2-h1+540, 360) - 180
+	h := h1 + delta*t
+	return math.Mod(h+360, 360)
+}
+
+// rgbToHSL converts 8-bit RGB channels to hue (degrees), saturation, and
+// lightness (0..1).
+func rgbToHSL(r, g, b uint8) (h, s, l float64) {
+	rf, gf, bf := float64(r)/255, float64(g)/255, float64(b)/255
+
+	max := math.Max(rf, math.Max(gf, bf))
+	min := math.Min(rf, math.Min(gf, bf))
+	l = (max + min) / 2
+
+	if max == min {
+		return 0, 0, l
+	}
+
+	d := max - min
+	if l > 0.5 {
+		s = d / (2 - max - min)
+	} else {
+		s = d / (max + min)
+	}
+
+	switch max {
+	case rf:
+		h = (gf - bf) / d
+		if gf < bf {
+			h += 6
+		}
+	case gf:
+		h = (bf-rf)/d + 2
+	case bf:
+		h = (rf-gf)/d + 4
+	}
+	h *= 60
+	return h, s, l
+}
+
+// hslToRGB converts hue (degrees), saturation, and lightness (0..1) to
+// 8-bit RGB channels.
+func hslToRGB(h, s, l float64) (r, g, b uint8) {
+	if s == 0 {
+		v := uint8(math.Round(l * 255))
+		return v, v, v
+	}
+
+	var q float64
+	if l < 0.5 {
+		q = l * (1 + s)
+	} else {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	hk := h / 360
+
+	toChannel := func(t float64) uint8 {
+		if t < 0 {
+			t++
+		}
+		if t > 1 {
+			t--
+		}
+		switch {
+		case t < 1.0/6:
+			return uint8(math.Round((p + (q-p)*6*t) * 255))
+		case t < 1.0/2:
+			return uint8(math.Round(q * 255))
+		case t < 2.0/3:
+			return uint8(math.Round((p + (q-p)*(2.0/3-t)*6) * 255))
+		default:
+			return uint8(math.Round(p * 255))
+		}
+	}
+
+	return toChannel(hk + 1.0/3), toChannel(hk), toChannel(hk - 1.0/3)
+}
+
+// renderGradientBar renders filledWidth solid block runes, each colored
+// by sampling colors/stops at its position along the full barWidth - so
+// the gradient reflects where a cell sits on the whole bar, not just
+// within however much of it is currently filled.
+func renderGradientBar(r *lipgloss.Renderer, colors []lipgloss.Color, stops []float64, filledWidth, barWidth int) string {
+	span := barWidth - 1
+	if span < 1 {
+		span = 1
+	}
+	var sb strings.Builder
+	for i := 0; i < filledWidth; i++ {
+		c := gradientColor(colors, stops, float64(i)/float64(span))
+		sb.WriteString(r.NewStyle().Foreground(c).Background(c).Render("█"))
+	}
+	return sb.String()
+}
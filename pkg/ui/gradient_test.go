@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestGradientColorClampsAtEnds(t *testing.T) {
+	colors := []lipgloss.Color{"#00ff00", "#ffff00", "#ff0000"}
+	if got := gradientColor(colors, nil, -1); got != colors[0] {
+		t.Fatalf("expected clamp to first color below 0, got %v", got)
+	}
+	if got := gradientColor(colors, nil, 2); got != colors[len(colors)-1] {
+		t.Fatalf("expected clamp to last color above 1, got %v", got)
+	}
+}
+
+func TestGradientColorAtExactStop(t *testing.T) {
+	colors := []lipgloss.Color{"#00ff00", "#ffff00", "#ff0000"}
+	if got := gradientColor(colors, nil, 0.5); got != colors[1] {
+		t.Fatalf("expected exact middle stop to return %v, got %v", colors[1], got)
+	}
+}
+
+func TestGradientColorSingleColorIsConstant(t *testing.T) {
+	colors := []lipgloss.Color{"#00ff00"}
+	if got := gradientColor(colors, nil, 0.7); got != colors[0] {
+		t.Fatalf("expected the single color regardless of t, got %v", got)
+	}
+}
+
+func TestLerpHSLMidpointBetweenRedAndGreenIsNotGray(t *testing.T) {
+	mid := lerpHSL("#ff0000", "#00ff00", 0.5)
+	r, g, b, ok := parseHexColor(string(mid))
+	if !ok {
+		t.Fatalf("expected a parseable hex color, got %q", mid)
+	}
+	// A true HSL blend of red and green passes through yellow, so green
+	// and red channels should both be high and roughly balanced - unlike
+	// a naive RGB average, which would land on a duller, grayer yellow.
+	if g < 180 || r < 180 {
+		t.Fatalf("expected a bright yellow-ish midpoint, got r=%d g=%d b=%d", r, g, b)
+	}
+}
+
+func TestUseGradientProfileFalseOnAnsiAndAscii(t *testing.T) {
+	r := lipgloss.NewRenderer(nilWriter{})
+	r.SetColorProfile(termenv.Ascii)
+	if useGradientProfile(r) {
+		t.Fatal("expected Ascii profile to disable gradients")
+	}
+	r.SetColorProfile(termenv.ANSI)
+	if useGradientProfile(r) {
+		t.Fatal("expected ANSI profile to disable gradients")
+	}
+}
+
+type nilWriter struct{}
+
+func (nilWriter) Write(p []byte) (int, error) { return len(p), nil }
@@ -0,0 +1,354 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// GraphModel renders the dependency DAG implied by model.Issue.Dependencies
+// (DepBlocks edges) as an ASCII graph, reachable as a UI mode alongside the
+// board and swimlane views. Nodes are ranked by their longest path from a
+// root (no incoming DepBlocks edge) via Kahn's algorithm, then grouped into
+// columns by rank; within a column nodes are ordered by a barycenter sweep
+// over the previous column to reduce edge crossings.
+type GraphModel struct {
+	theme Theme
+
+	issueMap  map[string]*model.Issue
+	blocks    map[string][]string // id -> ids it blocks (outgoing edges)
+	blockedBy map[string][]string // id -> ids that block it (incoming edges)
+	rank      map[string]int
+	backEdges map[[2]string]bool // edges that close a cycle
+
+	columns [][]string // node IDs per rank, left to right
+
+	cursorCol, cursorRow int
+	width, height        int
+}
+
+// NewGraphModel builds a GraphModel from issues.
+func NewGraphModel(issues []model.Issue, theme Theme) *GraphModel {
+	g := &GraphModel{theme: theme}
+	g.Build(issues)
+	return g
+}
+
+// Build (re)computes the rank/column layout from issues.
+func (g *GraphModel) Build(issues []model.Issue) {
+	g.issueMap = make(map[string]*model.Issue, len(issues))
+	ids := make([]string, 0, len(issues))
+	for i := range issues {
+		g.issueMap[issues[i].ID] = &issues[i]
+		ids = append(ids, issues[i].ID)
+	}
+	sort.Strings(ids)
+
+	g.blocks = map[string][]string{}
+	g.blockedBy = map[string][]string{}
+	inDegree := map[string]int{}
+	for _, id := range ids {
+		inDegree[id] = 0
+	}
+	for _, iss := range issues {
+		for _, dep := range iss.Dependencies {
+			if dep.Type != model.DepBlocks {
+				continue
+			}
+			if _, ok := g.issueMap[dep.DependsOnID]; !ok {
+				continue // dangling blocker reference; ignore for layout
+			}
+			g.blockedBy[dep.IssueID] = append(g.blockedBy[dep.IssueID], dep.DependsOnID)
+			g.blocks[dep.DependsOnID] = append(g.blocks[dep.DependsOnID], dep.IssueID)
+			inDegree[dep.IssueID]++
+		}
+	}
+
+	g.rank, g.backEdges = rankByLongestPath(ids, g.blocks, g.blockedBy, inDegree)
+	g.columns = bucketByRank(ids, g.rank, g.issueMap)
+	orderColumnsByBarycenter(g.columns, g.blockedBy, g.rank)
+
+	g.cursorCol, g.cursorRow = 0, 0
+}
+
+// rankByLongestPath assigns each node a rank equal to its longest path
+// (in edge count) from a root, via Kahn's algorithm. Nodes that never
+// reach zero remaining in-degree are part of a cycle; they're assigned
+// rank 0 and their still-unranked incoming edges are reported as back
+// edges so the caller can render them distinctly.
+func rankByLongestPath(ids []string, blocks, blockedBy map[string][]string, inDegree map[string]int) (map[string]int, map[[2]string]bool) {
+	rank := make(map[string]int, len(ids))
+	remaining := make(map[string]int, len(inDegree))
+	for id, d := range inDegree {
+		remaining[id] = d
+	}
+
+	var queue []string
+	for _, id := range ids {
+		if remaining[id] == 0 {
+			queue = append(queue, id)
+			rank[id] = 0
+		}
+	}
+
+	for len(queue) > 0 {
+		sort.Strings(queue) // deterministic processing order -> stable ranks
+		id := queue[0]
+		queue = queue[1:]
+
+		dependents := append([]string(nil), blocks[id]...)
+		sort.Strings(dependents)
+		for _, dependent := range dependents {
+			if rank[id]+1 > rank[dependent] {
+				rank[dependent] = rank[id] + 1
+			}
+			remaining[dependent]--
+			if remaining[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+
+	backEdges := map[[2]string]bool{}
+	for _, id := range ids {
+		if remaining[id] == 0 {
+			continue // ranked normally above
+		}
+		rank[id] = 0
+		for _, blocker := range blockedBy[id] {
+			if remaining[blocker] != 0 {
+				backEdges[[2]string{blocker, id}] = true
+			}
+		}
+	}
+	return rank, backEdges
+}
+
+// bucketByRank groups ids into columns by rank, each column initially
+// ordered by priority ascending then CreatedAt descending, matching the
+// board's within-column sort order.
+func bucketByRank(ids []string, rank map[string]int, issueMap map[string]*model.Issue) [][]string {
+	maxRank := 0
+	for _, r := range rank {
+		if r > maxRank {
+			maxRank = r
+		}
+	}
+
+	columns := make([][]string, maxRank+1)
+	for _, id := range ids {
+		r := rank[id]
+		columns[r] = append(columns[r], id)
+	}
+
+	for _, col := range columns {
+		sort.SliceStable(col, func(i, j int) bool {
+			a, b := issueMap[col[i]], issueMap[col[j]]
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority
+			}
+			return a.CreatedAt.After(b.CreatedAt)
+		})
+	}
+	return columns
+}
+
+// orderColumnsByBarycenter runs a single left-to-right sweep, reordering
+// each column by the average row its predecessors occupy in the previous
+// column, to reduce edge crossings. Nodes with no blocker in the
+// previous column sort to the front of their column, keeping their
+// existing relative order (the sort is stable).
+func orderColumnsByBarycenter(columns [][]string, blockedBy map[string][]string, rank map[string]int) {
+	for c := 1; c < len(columns); c++ {
+		prevRow := make(map[string]int, len(columns[c-1]))
+		for row, id := range columns[c-1] {
+			prevRow[id] = row
+		}
+
+		barycenter := make(map[string]float64, len(columns[c]))
+		for _, id := range columns[c] {
+			var sum float64
+			var count int
+			for _, blocker := range blockedBy[id] {
+				if rank[blocker] != c-1 {
+					continue
+				}
+				if row, ok := prevRow[blocker]; ok {
+					sum += float64(row)
+					count++
+				}
+			}
+			if count > 0 {
+				barycenter[id] = sum / float64(count)
+			} else {
+				barycenter[id] = -1 // keep nodes with no previous-column neighbor first, stable order preserved
+			}
+		}
+
+		sort.SliceStable(columns[c], func(i, j int) bool {
+			return barycenter[columns[c][i]] < barycenter[columns[c][j]]
+		})
+	}
+}
+
+// SetSize sets the viewport used by View.
+func (g *GraphModel) SetSize(width, height int) {
+	g.width, g.height = width, height
+}
+
+// SelectedIssue returns the focused node, or nil if the graph is empty.
+func (g *GraphModel) SelectedIssue() *model.Issue {
+	if g.cursorCol < 0 || g.cursorCol >= len(g.columns) {
+		return nil
+	}
+	col := g.columns[g.cursorCol]
+	if g.cursorRow < 0 || g.cursorRow >= len(col) {
+		return nil
+	}
+	return g.issueMap[col[g.cursorRow]]
+}
+
+// MoveRight moves the cursor one column right, clamping the row.
+func (g *GraphModel) MoveRight() { g.moveColumn(1) }
+
+// MoveLeft moves the cursor one column left, clamping the row.
+func (g *GraphModel) MoveLeft() { g.moveColumn(-1) }
+
+func (g *GraphModel) moveColumn(delta int) {
+	if len(g.columns) == 0 {
+		return
+	}
+	next := g.cursorCol + delta
+	if next < 0 || next >= len(g.columns) {
+		return
+	}
+	g.cursorCol = next
+	if g.cursorRow >= len(g.columns[g.cursorCol]) {
+		g.cursorRow = len(g.columns[g.cursorCol]) - 1
+	}
+	if g.cursorRow < 0 {
+		g.cursorRow = 0
+	}
+}
+
+// MoveDown moves the cursor one row down within the current column.
+func (g *GraphModel) MoveDown() { g.moveRow(1) }
+
+// MoveUp moves the cursor one row up within the current column.
+func (g *GraphModel) MoveUp() { g.moveRow(-1) }
+
+func (g *GraphModel) moveRow(delta int) {
+	if g.cursorCol < 0 || g.cursorCol >= len(g.columns) {
+		return
+	}
+	col := g.columns[g.cursorCol]
+	if len(col) == 0 {
+		return
+	}
+	next := g.cursorRow + delta
+	if next < 0 || next >= len(col) {
+		return
+	}
+	g.cursorRow = next
+}
+
+// View renders the graph as ASCII columns, one glyph per node, selected
+// node highlighted.
+func (g *GraphModel) View() string {
+	if len(g.columns) == 0 {
+		return "No issues to display."
+	}
+
+	var b strings.Builder
+	maxRows := 0
+	for _, col := range g.columns {
+		if len(col) > maxRows {
+			maxRows = len(col)
+		}
+	}
+
+	for row := 0; row < maxRows; row++ {
+		var cells []string
+		for c, col := range g.columns {
+			if row >= len(col) {
+				cells = append(cells, strings.Repeat(" ", 12))
+				continue
+			}
+			id := col[row]
+			cells = append(cells, g.renderNode(id, c == g.cursorCol && row == g.cursorRow))
+		}
+		b.WriteString(strings.Join(cells, " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (g *GraphModel) renderNode(id string, selected bool) string {
+	issue := g.issueMap[id]
+	status := ""
+	if issue != nil {
+		status = string(issue.Status)
+	}
+	style := g.theme.Renderer.NewStyle().Foreground(g.theme.StatusColor(status)).Bold(true)
+	if _, isBackTarget := g.backTargets()[id]; isBackTarget {
+		style = style.Underline(true)
+	}
+
+	glyph := fmt.Sprintf("[%s]", id)
+	if selected {
+		glyph = fmt.Sprintf(">%s<", id)
+		style = style.Background(g.theme.BgHighlight)
+	}
+	return style.Render(glyph)
+}
+
+// backTargets returns the set of node IDs that are the destination of a
+// back edge (i.e. the node that closes a cycle), so View can mark them.
+func (g *GraphModel) backTargets() map[string]bool {
+	targets := make(map[string]bool, len(g.backEdges))
+	for edge := range g.backEdges {
+		targets[edge[1]] = true
+	}
+	return targets
+}
+
+// graphModes attaches the "xray" dependency graph view to a BoardModel
+// without adding a field to it, analogous to pluginRegistries.
+var (
+	graphModesMu sync.Mutex
+	graphModes   = map[*BoardModel]*GraphModel{}
+)
+
+func init() {
+	registerBoardCloseHook(func(b *BoardModel) {
+		graphModesMu.Lock()
+		delete(graphModes, b)
+		graphModesMu.Unlock()
+	})
+}
+
+// EnableGraphView builds (or rebuilds) the xray graph view for b from
+// allIssues, so it's ready the next time GraphView is read.
+func (b *BoardModel) EnableGraphView(allIssues []model.Issue, theme Theme) *GraphModel {
+	graphModesMu.Lock()
+	defer graphModesMu.Unlock()
+	g, ok := graphModes[b]
+	if !ok {
+		g = NewGraphModel(allIssues, theme)
+		graphModes[b] = g
+	} else {
+		g.Build(allIssues)
+	}
+	return g
+}
+
+// GraphView returns b's xray graph view, or nil if EnableGraphView hasn't
+// been called yet.
+func (b *BoardModel) GraphView() *GraphModel {
+	graphModesMu.Lock()
+	defer graphModesMu.Unlock()
+	return graphModes[b]
+}
@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// layoutCacheGeneration is bumped by InvalidateLayoutCache whenever the
+// active theme changes, so every Layout's cached renders - keyed in
+// part on this generation - miss exactly once and re-render under the
+// new theme instead of needing every call site to track theme changes
+// itself.
+var layoutCacheGeneration int64
+
+// InvalidateLayoutCache discards every Layout's cached child renders.
+// Callers should invoke this whenever they swap the Theme a Layout tree
+// renders with, since a cached string baked under the old theme would
+// otherwise survive a resize and render stale.
+func InvalidateLayoutCache() {
+	atomic.AddInt64(&layoutCacheGeneration, 1)
+}
+
+// LayoutDirection controls how a Layout arranges its children.
+type LayoutDirection int
+
+const (
+	// LayoutRow arranges children left-to-right via JoinHorizontal,
+	// dividing the available width among them.
+	LayoutRow LayoutDirection = iota
+	// LayoutColumn arranges children top-to-bottom via JoinVertical,
+	// each getting the full available width.
+	LayoutColumn
+	// LayoutGrid wraps children into rows of Cols cells, each row laid
+	// out like LayoutRow.
+	LayoutGrid
+)
+
+// LayoutChild pairs a TutorialElement with how much of its row's
+// available width it claims: Weight distributes whatever width remains
+// after every child's MinWidth is reserved, proportionally to its share
+// of the total weight (a zero-weight child gets none of the remainder,
+// only its MinWidth).
+type LayoutChild struct {
+	Element  TutorialElement
+	Weight   float64
+	MinWidth int
+}
+
+// Layout is a container TutorialElement: Row, Column, and Grid build
+// one arranging children in that direction. Each child's rendered
+// string is cached by (element, width, theme generation), so an
+// unchanged child survives a terminal resize - or a sibling's content
+// changing - without paying for its own Render again.
+type Layout struct {
+	Direction LayoutDirection
+	Children  []LayoutChild
+	Cols      int // only meaningful when Direction is LayoutGrid
+
+	cache map[string]string
+}
+
+// Row returns a Layout that renders children left-to-right with equal
+// weight and no minimum width.
+func Row(children ...TutorialElement) Layout {
+	return Layout{Direction: LayoutRow, Children: equalWeightChildren(children), cache: map[string]string{}}
+}
+
+// Column returns a Layout that renders children top-to-bottom, each
+// getting the full available width.
+func Column(children ...TutorialElement) Layout {
+	return Layout{Direction: LayoutColumn, Children: equalWeightChildren(children), cache: map[string]string{}}
+}
+
+// Grid returns a Layout that wraps children into rows of cols cells
+// each, every cell getting an equal share of its row's width.
+func Grid(cols int, children ...TutorialElement) Layout {
+	if cols < 1 {
+		cols = 1
+	}
+	return Layout{Direction: LayoutGrid, Cols: cols, Children: equalWeightChildren(children), cache: map[string]string{}}
+}
+
+func equalWeightChildren(elements []TutorialElement) []LayoutChild {
+	children := make([]LayoutChild, len(elements))
+	for i, el := range elements {
+		children[i] = LayoutChild{Element: el, Weight: 1}
+	}
+	return children
+}
+
+// WithWeight returns a copy of l with its i-th child's Weight set to
+// weight, for overriding the default equal split Row/Column/Grid start
+// with (e.g. a sidebar that should only claim a quarter of the width).
+func (l Layout) WithWeight(i int, weight float64) Layout {
+	l.Children = append([]LayoutChild(nil), l.Children...)
+	l.Children[i].Weight = weight
+	return l
+}
+
+// WithMinWidth returns a copy of l with its i-th child's MinWidth set,
+// reserved before the remaining width is divided by weight.
+func (l Layout) WithMinWidth(i int, minWidth int) Layout {
+	l.Children = append([]LayoutChild(nil), l.Children...)
+	l.Children[i].MinWidth = minWidth
+	return l
+}
+
+// Render lays out l's children within width according to its
+// Direction, rendering (or reusing the cached render of) each child at
+// its divided width.
+func (l Layout) Render(theme Theme, width int) string {
+	switch l.Direction {
+	case LayoutColumn:
+		return l.renderColumn(theme, width)
+	case LayoutGrid:
+		return l.renderGrid(theme, width)
+	default:
+		return l.renderRow(theme, width)
+	}
+}
+
+func (l Layout) renderRow(theme Theme, width int) string {
+	if len(l.Children) == 0 {
+		return ""
+	}
+	widths := divideWidth(l.Children, width)
+	rendered := make([]string, len(l.Children))
+	for i, child := range l.Children {
+		rendered[i] = l.cachedRender(child.Element, theme, widths[i])
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
+
+func (l Layout) renderColumn(theme Theme, width int) string {
+	rendered := make([]string, len(l.Children))
+	for i, child := range l.Children {
+		rendered[i] = l.cachedRender(child.Element, theme, width)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rendered...)
+}
+
+func (l Layout) renderGrid(theme Theme, width int) string {
+	var rows []string
+	for start := 0; start < len(l.Children); start += l.Cols {
+		end := start + l.Cols
+		if end > len(l.Children) {
+			end = len(l.Children)
+		}
+		row := Layout{Direction: LayoutRow, Children: l.Children[start:end], cache: l.cache}
+		rows = append(rows, row.renderRow(theme, width))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// divideWidth splits total among children: every child's MinWidth is
+// reserved first, then whatever remains is split proportionally to
+// Weight. If every weight is zero, or total is already smaller than the
+// sum of the minimums, each child just gets its MinWidth.
+func divideWidth(children []LayoutChild, total int) []int {
+	widths := make([]int, len(children))
+	reserved := 0
+	var totalWeight float64
+	for i, c := range children {
+		widths[i] = c.MinWidth
+		reserved += c.MinWidth
+		totalWeight += c.Weight
+	}
+
+	remaining := total - reserved
+	if remaining <= 0 || totalWeight <= 0 {
+		return widths
+	}
+
+	allocated := 0
+	for i, c := range children {
+		if c.Weight <= 0 {
+			continue
+		}
+		share := int(float64(remaining) * c.Weight / totalWeight)
+		widths[i] += share
+		allocated += share
+	}
+	// Give any leftover from integer rounding to the last weighted child
+	// so the columns fill the full width instead of leaving a gap.
+	if leftover := remaining - allocated; leftover > 0 {
+		for i := len(children) - 1; i >= 0; i-- {
+			if children[i].Weight > 0 {
+				widths[i] += leftover
+				break
+			}
+		}
+	}
+	return widths
+}
+
+// cachedRender returns element's render at width under the current
+// theme generation, reusing l's cache when the (element, width,
+// generation) key is unchanged since the last call.
+func (l Layout) cachedRender(element TutorialElement, theme Theme, width int) string {
+	key := fmt.Sprintf("%d|%d|%d", elementHash(element), width, atomic.LoadInt64(&layoutCacheGeneration))
+	if cached, ok := l.cache[key]; ok {
+		return cached
+	}
+	rendered := element.Render(theme, width)
+	l.cache[key] = rendered
+	return rendered
+}
+
+// cacheKeyer lets an element contribute its own cache-key fragment
+// instead of the default %#v-based hash - Layout implements it so a
+// Layout nested inside another Layout is hashed from its children's
+// hashes rather than from its own cache map, which would otherwise make
+// the hash depend on unrelated prior render calls.
+type cacheKeyer interface {
+	cacheKey() string
+}
+
+func (l Layout) cacheKey() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "layout:%d:%d:", l.Direction, l.Cols)
+	for _, c := range l.Children {
+		fmt.Fprintf(&sb, "[%g:%d:%d]", c.Weight, c.MinWidth, elementHash(c.Element))
+	}
+	return sb.String()
+}
+
+// elementHash returns a content hash for el, used as the element
+// component of a Layout cache key: two elements that would render
+// identically hash the same, regardless of where they live.
+func elementHash(el TutorialElement) uint64 {
+	var key string
+	if ck, ok := el.(cacheKeyer); ok {
+		key = ck.cacheKey()
+	} else {
+		key = fmt.Sprintf("%#v", el)
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return h.Sum64()
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLoadLayoutConfigMissingFileReturnsDefault(t *testing.T) {
+	cfg, err := LoadLayoutConfig("/nonexistent/layout.yaml")
+	if err != nil {
+		t.Fatalf("LoadLayoutConfig of a missing file should not error, got: %v", err)
+	}
+	if len(cfg.SwimLanes) != len(DefaultLayoutConfig().SwimLanes) {
+		t.Errorf("expected defaults for a missing file, got %+v", cfg)
+	}
+}
+
+func TestLoadLayoutConfigOverridesDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "layout.yaml")
+	yaml := `
+swim_lanes:
+  - name: Status
+    columns:
+      - value: open
+      - value: closed
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadLayoutConfig(path)
+	if err != nil {
+		t.Fatalf("LoadLayoutConfig returned error: %v", err)
+	}
+	preset, ok := cfg.Lookup("Status")
+	if !ok || len(preset.Columns) != 2 {
+		t.Fatalf("expected overridden Status preset with 2 columns, got %+v", preset)
+	}
+	if preset.Columns[0].Value != "open" || preset.Columns[1].Value != "closed" {
+		t.Errorf("unexpected column values: %+v", preset.Columns)
+	}
+}
+
+func TestBoardModelActiveLayoutDefaultsUntilLoadLayout(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	b := NewBoardModel([]model.Issue{}, theme)
+
+	if len(b.ActiveLayout().SwimLanes) != len(DefaultLayoutConfig().SwimLanes) {
+		t.Fatal("expected a fresh BoardModel's ActiveLayout to be DefaultLayoutConfig")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "layout.yaml")
+	yaml := `
+swim_lanes:
+  - name: Status
+    columns:
+      - value: open
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := b.LoadLayout(path); err != nil {
+		t.Fatalf("LoadLayout returned error: %v", err)
+	}
+	preset, ok := b.ActiveLayout().Lookup("Status")
+	if !ok || len(preset.Columns) != 1 {
+		t.Fatalf("expected ActiveLayout to reflect the loaded layout, got %+v", preset)
+	}
+}
+
+func TestApplyColumnValueUsesActiveLayout(t *testing.T) {
+	issue := &model.Issue{ID: "bv-1", Status: model.StatusOpen}
+
+	layout := LayoutConfig{SwimLanes: []SwimLaneLayout{
+		{Name: "Status", Columns: []ColumnLayout{{Value: string(model.StatusClosed)}}},
+	}}
+
+	kind, oldValue, newValue, err := applyColumnValue(issue, layout, "Status", 0)
+	if err != nil {
+		t.Fatalf("applyColumnValue returned error: %v", err)
+	}
+	if kind != MutationStatusChanged || oldValue != string(model.StatusOpen) || newValue != string(model.StatusClosed) {
+		t.Errorf("unexpected mutation: kind=%v old=%q new=%q", kind, oldValue, newValue)
+	}
+	if issue.Status != model.StatusClosed {
+		t.Errorf("expected issue.Status to be updated, got %v", issue.Status)
+	}
+}
+
+func TestApplyColumnValueDefaultLayoutMatchesOriginalBehavior(t *testing.T) {
+	issue := &model.Issue{ID: "bv-1", Priority: 3}
+
+	kind, oldValue, newValue, err := applyColumnValue(issue, DefaultLayoutConfig(), "Priority", 0)
+	if err != nil {
+		t.Fatalf("applyColumnValue returned error: %v", err)
+	}
+	if kind != MutationPriorityChanged || oldValue != "3" || newValue != "0" {
+		t.Errorf("unexpected mutation: kind=%v old=%q new=%q", kind, oldValue, newValue)
+	}
+	if issue.Priority != 0 {
+		t.Errorf("expected issue.Priority 0, got %d", issue.Priority)
+	}
+}
+
+func TestColumnLabelUsesActiveLayout(t *testing.T) {
+	layout := LayoutConfig{SwimLanes: []SwimLaneLayout{
+		{Name: "Status", Columns: []ColumnLayout{{Value: "open"}, {Value: "closed"}}},
+		{Name: "Priority", Columns: []ColumnLayout{{Value: "0"}, {Value: "1"}}},
+	}}
+
+	label, ok := columnLabel(layout, "Status", 1)
+	if !ok || label != "closed" {
+		t.Errorf("expected label %q, got %q (ok=%v)", "closed", label, ok)
+	}
+
+	label, ok = columnLabel(layout, "Priority", 1)
+	if !ok || label != "p1" {
+		t.Errorf("expected label %q, got %q (ok=%v)", "p1", label, ok)
+	}
+
+	if _, ok := columnLabel(layout, "Status", 5); ok {
+		t.Error("expected out-of-range column to return ok=false")
+	}
+}
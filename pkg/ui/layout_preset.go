@@ -0,0 +1,136 @@
+package ui
+
+import "sync"
+
+// LayoutPreset is one of the board's high-level arrangements: how much
+// of the frame goes to cards versus the detail panel, and whether
+// non-selected columns are shown at all. CycleLayoutPreset advances a
+// BoardModel through them; EffectiveLayoutPreset falls back to a
+// narrower one when a given width can't fit the active choice. This
+// package has no board View (see BoardModel in board.go), so the
+// arrangements described below are recorded and chosen here but not
+// rendered.
+type LayoutPreset int
+
+const (
+	// PresetStandard is the board's default arrangement: full cards,
+	// detail panel shown only on request.
+	PresetStandard LayoutPreset = iota
+	// PresetCompact hides the detail panel and renders one-line cards,
+	// fitting more of the board in a short or narrow terminal.
+	PresetCompact
+	// PresetSplit forces the detail panel visible with a 60/40
+	// board/detail split, for reading one card's full description
+	// while scanning the rest of the board.
+	PresetSplit
+	// PresetFocus hides every column except the selected one, for
+	// working through a single swim lane without the others competing
+	// for width.
+	PresetFocus
+)
+
+// String returns the name GetLayoutPresetName reports while p is active.
+func (p LayoutPreset) String() string {
+	switch p {
+	case PresetCompact:
+		return "Compact"
+	case PresetSplit:
+		return "Split"
+	case PresetFocus:
+		return "Focus"
+	default:
+		return "Standard"
+	}
+}
+
+// layoutPresetOrder is the cycle CycleLayoutPreset advances through.
+var layoutPresetOrder = []LayoutPreset{PresetStandard, PresetCompact, PresetSplit, PresetFocus}
+
+// minWidthForPreset is the narrowest terminal width a preset renders
+// usefully at. EffectiveLayoutPreset falls back to a narrower preset
+// below this width.
+func minWidthForPreset(p LayoutPreset) int {
+	switch p {
+	case PresetSplit:
+		return 120
+	case PresetFocus:
+		return 90
+	case PresetCompact:
+		return 60
+	default:
+		return 0
+	}
+}
+
+// downgradePreset returns the next-narrower preset below p, the one
+// EffectiveLayoutPreset falls back to when p doesn't fit the given
+// width. PresetStandard is the floor - every preset downgrades toward
+// it eventually.
+func downgradePreset(p LayoutPreset) LayoutPreset {
+	switch p {
+	case PresetSplit:
+		return PresetFocus
+	case PresetFocus:
+		return PresetCompact
+	default:
+		return PresetStandard
+	}
+}
+
+// layoutPresetStates carries the active LayoutPreset for a BoardModel,
+// keyed by identity like layoutConfigStates and mutationStates, so
+// NewBoardModel doesn't need a field for it.
+var (
+	layoutPresetMu     sync.Mutex
+	layoutPresetStates = map[*BoardModel]LayoutPreset{}
+)
+
+func init() {
+	registerBoardCloseHook(func(b *BoardModel) {
+		layoutPresetMu.Lock()
+		delete(layoutPresetStates, b)
+		layoutPresetMu.Unlock()
+	})
+}
+
+func layoutPresetFor(b *BoardModel) LayoutPreset {
+	layoutPresetMu.Lock()
+	defer layoutPresetMu.Unlock()
+	return layoutPresetStates[b] // zero value is PresetStandard
+}
+
+// CycleLayoutPreset advances b to the next LayoutPreset in the
+// Standard -> Compact -> Split -> Focus cycle, wrapping back to
+// Standard, the same pattern CycleSwimLaneMode uses for swim-lane
+// modes.
+func (b *BoardModel) CycleLayoutPreset() {
+	layoutPresetMu.Lock()
+	defer layoutPresetMu.Unlock()
+	cur := layoutPresetStates[b]
+	for i, p := range layoutPresetOrder {
+		if p == cur {
+			layoutPresetStates[b] = layoutPresetOrder[(i+1)%len(layoutPresetOrder)]
+			return
+		}
+	}
+	layoutPresetStates[b] = PresetStandard
+}
+
+// GetLayoutPresetName reports the name of b's active LayoutPreset.
+func (b *BoardModel) GetLayoutPresetName() string {
+	return layoutPresetFor(b).String()
+}
+
+// EffectiveLayoutPreset returns the LayoutPreset that fits the given
+// width: b's active preset, or the
+// next-narrower preset (and so on) if the active one's minimum width
+// doesn't fit. It never mutates b's stored preset, so narrowing the
+// terminal and widening it back returns to the preset the user picked
+// with CycleLayoutPreset.
+func (b *BoardModel) EffectiveLayoutPreset(width int) LayoutPreset {
+	p := layoutPresetFor(b)
+	for p != PresetStandard && width < minWidthForPreset(p) {
+		p = downgradePreset(p)
+	}
+	return p
+}
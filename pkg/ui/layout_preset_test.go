@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLayoutPresetCycles(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	b := NewBoardModel([]model.Issue{}, theme)
+
+	names := []string{"Standard", "Compact", "Split", "Focus", "Standard"}
+	for i, expected := range names {
+		if got := b.GetLayoutPresetName(); got != expected {
+			t.Errorf("step %d: expected %s preset, got %s", i, expected, got)
+		}
+		b.CycleLayoutPreset()
+	}
+}
+
+func TestEffectiveLayoutPresetNoDowngradeWhenItFits(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	b := NewBoardModel([]model.Issue{}, theme)
+
+	b.CycleLayoutPreset() // Compact
+	b.CycleLayoutPreset() // Split
+
+	for _, width := range []int{120, 160, 200} {
+		if got := b.EffectiveLayoutPreset(width); got != PresetSplit {
+			t.Errorf("width %d: expected Split to fit, got %s", width, got)
+		}
+	}
+}
+
+func TestEffectiveLayoutPresetDowngradesAtNarrowWidths(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+
+	tests := []struct {
+		preset LayoutPreset
+		width  int
+		want   LayoutPreset
+	}{
+		{PresetSplit, 80, PresetCompact},   // Split (120) -> Focus (90) -> Compact (60) fits
+		{PresetFocus, 80, PresetCompact},   // Focus (90) -> Compact (60) fits
+		{PresetCompact, 80, PresetCompact}, // Compact (60) fits at 80
+		{PresetStandard, 80, PresetStandard},
+	}
+
+	for _, tt := range tests {
+		b := NewBoardModel([]model.Issue{}, theme)
+		for layoutPresetFor(b) != tt.preset {
+			b.CycleLayoutPreset()
+		}
+		if got := b.EffectiveLayoutPreset(tt.width); got != tt.want {
+			t.Errorf("preset %s at width %d: expected %s, got %s", tt.preset, tt.width, tt.want, got)
+		}
+	}
+}
+
+func TestEffectiveLayoutPresetDoesNotMutateStoredPreset(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	b := NewBoardModel([]model.Issue{}, theme)
+
+	b.CycleLayoutPreset() // Compact
+	b.CycleLayoutPreset() // Split
+
+	_ = b.EffectiveLayoutPreset(80) // downgrades for this call only
+
+	if got := b.GetLayoutPresetName(); got != "Split" {
+		t.Errorf("expected stored preset to remain Split after a narrow EffectiveLayoutPreset call, got %s", got)
+	}
+}
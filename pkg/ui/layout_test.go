@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDivideWidthSplitsEvenlyByDefault(t *testing.T) {
+	children := []LayoutChild{{Weight: 1}, {Weight: 1}, {Weight: 1}}
+	widths := divideWidth(children, 30)
+	if widths[0] != 10 || widths[1] != 10 || widths[2] != 10 {
+		t.Fatalf("expected an even 10/10/10 split, got %v", widths)
+	}
+}
+
+func TestDivideWidthReservesMinWidthFirst(t *testing.T) {
+	children := []LayoutChild{
+		{Weight: 0, MinWidth: 20},
+		{Weight: 1},
+	}
+	widths := divideWidth(children, 50)
+	if widths[0] != 20 {
+		t.Fatalf("expected the zero-weight child to get only its MinWidth 20, got %d", widths[0])
+	}
+	if widths[1] != 30 {
+		t.Fatalf("expected the weighted child to get the remaining 30, got %d", widths[1])
+	}
+}
+
+func TestDivideWidthGivesRoundingLeftoverToLastWeightedChild(t *testing.T) {
+	children := []LayoutChild{{Weight: 1}, {Weight: 1}, {Weight: 1}}
+	widths := divideWidth(children, 10)
+	sum := widths[0] + widths[1] + widths[2]
+	if sum != 10 {
+		t.Fatalf("expected widths to sum to the full 10, got %v (sum %d)", widths, sum)
+	}
+	if widths[2] < widths[0] {
+		t.Fatalf("expected the last child to absorb the rounding leftover, got %v", widths)
+	}
+}
+
+func TestDivideWidthAllZeroWeightUsesMinWidthOnly(t *testing.T) {
+	children := []LayoutChild{{MinWidth: 5}, {MinWidth: 8}}
+	widths := divideWidth(children, 100)
+	if widths[0] != 5 || widths[1] != 8 {
+		t.Fatalf("expected bare MinWidths with no weight to distribute, got %v", widths)
+	}
+}
+
+// countingElement counts how many times Render actually ran, so tests
+// can assert a Layout's cache avoided re-rendering an unchanged child.
+type countingElement struct {
+	text   string
+	renders *int
+}
+
+func (c countingElement) Render(theme Theme, width int) string {
+	*c.renders++
+	return c.text
+}
+
+func TestLayoutCachesUnchangedChildAcrossRenders(t *testing.T) {
+	renders := 0
+	row := Row(countingElement{text: "a", renders: &renders})
+
+	row.Render(Theme{}, 40)
+	row.Render(Theme{}, 40)
+	row.Render(Theme{}, 40)
+
+	if renders != 1 {
+		t.Fatalf("expected the unchanged child to render exactly once, got %d renders", renders)
+	}
+}
+
+func TestLayoutReRendersOnWidthChange(t *testing.T) {
+	renders := 0
+	row := Row(countingElement{text: "a", renders: &renders})
+
+	row.Render(Theme{}, 40)
+	row.Render(Theme{}, 80)
+
+	if renders != 2 {
+		t.Fatalf("expected a width change to miss the cache, got %d renders", renders)
+	}
+}
+
+func TestLayoutReRendersAfterInvalidateLayoutCache(t *testing.T) {
+	renders := 0
+	row := Row(countingElement{text: "a", renders: &renders})
+
+	row.Render(Theme{}, 40)
+	InvalidateLayoutCache()
+	row.Render(Theme{}, 40)
+
+	if renders != 2 {
+		t.Fatalf("expected InvalidateLayoutCache to force a re-render, got %d renders", renders)
+	}
+}
+
+func TestColumnGivesEveryChildFullWidth(t *testing.T) {
+	col := Column(
+		countingElement{text: "a", renders: new(int)},
+		countingElement{text: "b", renders: new(int)},
+	)
+	out := col.Render(Theme{}, 40)
+	if got := trimmedLines(out); got != "a\nb" {
+		t.Fatalf("expected column children joined vertically, got %q", got)
+	}
+}
+
+// trimmedLines right-trims each line, since lipgloss.JoinVertical pads
+// shorter lines with trailing spaces to the widest line's width.
+func trimmedLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestGridWrapsChildrenIntoRowsOfCols(t *testing.T) {
+	grid := Grid(2,
+		countingElement{text: "a", renders: new(int)},
+		countingElement{text: "b", renders: new(int)},
+		countingElement{text: "c", renders: new(int)},
+	)
+	out := grid.Render(Theme{}, 40)
+	if got := trimmedLines(out); got != "ab\nc" {
+		t.Fatalf("expected a 2-wide grid to wrap into 2 rows, got %q", got)
+	}
+}
@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// severityGlyph is the single-character card-corner decoration
+// LintSeverityGlyph reports, matching the terseness of the status and
+// type glyphs in styles.go. No card renderer in this package draws it.
+func severityGlyph(s lint.Severity, theme Theme) (string, lipgloss.AdaptiveColor) {
+	switch s {
+	case lint.SeverityCritical:
+		return "!", theme.Blocked
+	case lint.SeverityWarning:
+		return "~", theme.Feature
+	default:
+		return "", theme.Text
+	}
+}
+
+// lintState carries the most recent lint.Report and overlay cursor for a
+// BoardModel, attached by identity the same way graphModes is (see
+// BoardModel's side-table doc comment in board.go).
+type lintState struct {
+	mu      sync.Mutex
+	cfg     lint.Config
+	report  lint.Report
+	visible bool
+	cursor  int
+	enabled bool // set by EnableLint
+}
+
+var (
+	lintStatesMu sync.Mutex
+	lintStates   = map[*BoardModel]*lintState{}
+)
+
+func init() {
+	registerBoardCloseHook(func(b *BoardModel) {
+		lintStatesMu.Lock()
+		delete(lintStates, b)
+		lintStatesMu.Unlock()
+	})
+}
+
+func lintStateFor(b *BoardModel) *lintState {
+	lintStatesMu.Lock()
+	defer lintStatesMu.Unlock()
+	st, ok := lintStates[b]
+	if !ok {
+		st = &lintState{cfg: lint.DefaultConfig()}
+		lintStates[b] = st
+	}
+	return st
+}
+
+// EnableLint scans allIssues with cfg and makes the result available via
+// LintReport, ShowLintReport and the per-card severity glyph.
+func (b *BoardModel) EnableLint(allIssues []model.Issue, cfg lint.Config) lint.Report {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.cfg = cfg
+	st.enabled = true
+	st.report = lint.Scan(allIssues, cfg)
+	st.cursor = 0
+	return st.report
+}
+
+// LintEnabled reports whether EnableLint has been called for b.
+func (b *BoardModel) LintEnabled() bool {
+	lintStatesMu.Lock()
+	st, ok := lintStates[b]
+	lintStatesMu.Unlock()
+	if !ok {
+		return false
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.enabled
+}
+
+// LintReport returns b's most recent lint.Report, or a zero Report if
+// EnableLint hasn't been called yet.
+func (b *BoardModel) LintReport() lint.Report {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.report
+}
+
+// LintSeverityGlyph returns the corner decoration for id's worst Finding,
+// or "" if id has no Findings (or lint isn't enabled).
+func (b *BoardModel) LintSeverityGlyph(id string) (string, lipgloss.AdaptiveColor) {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	worst := lint.Severity(-1)
+	for _, f := range st.report.Findings {
+		if f.IssueID == id && f.Severity > worst {
+			worst = f.Severity
+		}
+	}
+	if worst < 0 {
+		return "", b.theme.Text
+	}
+	return severityGlyph(worst, b.theme)
+}
+
+// ShowLintReport opens the lint overlay, listing Findings worst-first.
+func (b *BoardModel) ShowLintReport() {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.visible = true
+	st.cursor = 0
+}
+
+// HideLintReport closes the lint overlay.
+func (b *BoardModel) HideLintReport() {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.visible = false
+}
+
+// LintReportVisible reports whether the lint overlay is currently shown.
+func (b *BoardModel) LintReportVisible() bool {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.visible
+}
+
+// MoveLintCursor moves the overlay's selection by delta, clamped to the
+// Findings list.
+func (b *BoardModel) MoveLintCursor(delta int) {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if len(st.report.Findings) == 0 {
+		return
+	}
+	st.cursor += delta
+	if st.cursor < 0 {
+		st.cursor = 0
+	}
+	if st.cursor >= len(st.report.Findings) {
+		st.cursor = len(st.report.Findings) - 1
+	}
+}
+
+// JumpToLintFinding moves the board cursor to the issue behind the
+// overlay's currently-selected Finding and closes the overlay, mirroring
+// how Enter behaves on a search result. ok is false if there is no
+// selected Finding.
+func (b *BoardModel) JumpToLintFinding() (issueID string, ok bool) {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	if st.cursor < 0 || st.cursor >= len(st.report.Findings) {
+		st.mu.Unlock()
+		return "", false
+	}
+	issueID = st.report.Findings[st.cursor].IssueID
+	st.visible = false
+	st.mu.Unlock()
+
+	b.SelectByID(issueID)
+	return issueID, true
+}
+
+// RenderLintReport renders the overlay's Findings list, worst severity
+// first, with the grade and finding count summarized at the top.
+func (b *BoardModel) RenderLintReport() string {
+	st := lintStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if len(st.report.Findings) == 0 {
+		return b.theme.DetailMetaStyle.Render(fmt.Sprintf("Lint: grade %s, no findings", st.report.Grade))
+	}
+
+	lines := []string{fmt.Sprintf("Lint report: grade %s, %d finding(s)", st.report.Grade, len(st.report.Findings))}
+	for i, f := range st.report.Findings {
+		glyph, color := severityGlyph(f.Severity, b.theme)
+		line := fmt.Sprintf("%s %-12s %-7s %s", glyph, f.IssueID, f.Severity, f.Message)
+		style := b.theme.Renderer.NewStyle().Foreground(color)
+		if i == st.cursor {
+			style = style.Background(b.theme.BgHighlight)
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	var out string
+	for i, l := range lines {
+		if i > 0 {
+			out += "\n"
+		}
+		out += l
+	}
+	return out
+}
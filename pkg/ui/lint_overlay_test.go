@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestEnableLintPopulatesReport(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "bv-1", DependsOnID: "missing", Type: model.DepBlocks},
+		}},
+	}
+	b := NewBoardModel(issues, theme)
+
+	if b.LintEnabled() {
+		t.Fatal("expected LintEnabled to be false before EnableLint is called")
+	}
+
+	report := b.EnableLint(issues, lint.DefaultConfig())
+	if len(report.Findings) == 0 {
+		t.Fatal("expected at least one finding for an orphaned dependency")
+	}
+	if !b.LintEnabled() {
+		t.Fatal("expected LintEnabled to be true after EnableLint")
+	}
+
+	glyph, _ := b.LintSeverityGlyph("bv-1")
+	if glyph == "" {
+		t.Error("expected a non-empty severity glyph for the flagged issue")
+	}
+}
+
+func TestJumpToLintFindingMovesCursor(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Two", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "bv-2", DependsOnID: "missing", Type: model.DepBlocks},
+		}},
+	}
+	b := NewBoardModel(issues, theme)
+	b.EnableLint(issues, lint.DefaultConfig())
+	b.ShowLintReport()
+
+	id, ok := b.JumpToLintFinding()
+	if !ok || id != "bv-2" {
+		t.Fatalf("expected to jump to bv-2, got %q, %v", id, ok)
+	}
+	if sel := b.SelectedIssue(); sel == nil || sel.ID != "bv-2" {
+		t.Fatalf("expected board cursor on bv-2, got %+v", sel)
+	}
+	if b.LintReportVisible() {
+		t.Error("expected overlay to close after jumping to a finding")
+	}
+}
@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Plugin is a single user-defined action binding a key chord, in one or
+// more UI contexts, to a shell command templated from the selected issue.
+// Modeled on K9s plugins.
+type Plugin struct {
+	Name       string   `yaml:"name"`
+	ShortCut   string   `yaml:"shortCut"`
+	Contexts   []string `yaml:"contexts"` // "board", "detail", "search"
+	Command    string   `yaml:"command"`
+	Args       []string `yaml:"args"`
+	Background bool     `yaml:"background"` // run detached, capturing output, instead of a suspended fullscreen subprocess
+}
+
+// PluginConfig is the on-disk shape of plugins.yaml.
+type PluginConfig struct {
+	Plugins []Plugin          `yaml:"plugins"`
+	Aliases map[string]string `yaml:"aliases"` // alias name -> plugin name, for the ":" command prompt
+}
+
+// DefaultPluginConfigPath returns $XDG_CONFIG_HOME/beads_viewer/plugins.yaml
+// (or the OS equivalent via os.UserConfigDir).
+func DefaultPluginConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("ui: resolving config dir: %w", err)
+	}
+	return filepath.Join(dir, "beads_viewer", "plugins.yaml"), nil
+}
+
+// PluginRegistry indexes loaded plugins by (context, key chord) and by
+// alias, and supports hot-reloading from disk.
+type PluginRegistry struct {
+	mu      sync.RWMutex
+	byKey   map[string]Plugin // "context:shortcut" -> Plugin
+	byName  map[string]Plugin
+	aliases map[string]string
+}
+
+// NewPluginRegistry builds a registry from an already-parsed config.
+func NewPluginRegistry(cfg PluginConfig) *PluginRegistry {
+	r := &PluginRegistry{}
+	r.load(cfg)
+	return r
+}
+
+// LoadPluginRegistry reads and parses a plugins.yaml file at path.
+func LoadPluginRegistry(path string) (*PluginRegistry, error) {
+	r := &PluginRegistry{}
+	if err := r.Reload(path); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads path and atomically replaces the registry's contents. A
+// missing file is treated as an empty configuration rather than an error,
+// since plugins are optional.
+func (r *PluginRegistry) Reload(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			r.load(PluginConfig{})
+			return nil
+		}
+		return fmt.Errorf("ui: reading plugin config %q: %w", path, err)
+	}
+
+	var cfg PluginConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("ui: parsing plugin config %q: %w", path, err)
+	}
+	r.load(cfg)
+	return nil
+}
+
+func (r *PluginRegistry) load(cfg PluginConfig) {
+	byKey := make(map[string]Plugin, len(cfg.Plugins))
+	byName := make(map[string]Plugin, len(cfg.Plugins))
+	for _, p := range cfg.Plugins {
+		byName[p.Name] = p
+		for _, ctx := range p.Contexts {
+			byKey[ctx+":"+p.ShortCut] = p
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byKey = byKey
+	r.byName = byName
+	r.aliases = cfg.Aliases
+}
+
+// Lookup finds the plugin bound to key chord in the given UI context
+// ("board", "detail", "search").
+func (r *PluginRegistry) Lookup(uiContext, key string) (Plugin, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.byKey[uiContext+":"+key]
+	return p, ok
+}
+
+// ResolveAlias looks up a plugin by its short alias, as invoked from a ":"
+// command prompt.
+func (r *PluginRegistry) ResolveAlias(alias string) (Plugin, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.aliases[alias]
+	if !ok {
+		return Plugin{}, false
+	}
+	p, ok := r.byName[name]
+	return p, ok
+}
+
+// Watch polls path for changes every interval and calls Reload whenever
+// its modification time changes, providing hot-reload without an
+// fsnotify dependency for what is typically an infrequently-edited file.
+// The returned stop func halts polling.
+func (r *PluginRegistry) Watch(path string, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		var lastMod time.Time
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(path)
+				if err != nil {
+					continue
+				}
+				if info.ModTime().After(lastMod) {
+					lastMod = info.ModTime()
+					_ = r.Reload(path)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// pluginTemplateData is the set of fields available to a plugin's
+// templated arguments ({{.ID}}, {{.Title}}, {{.Status}}, {{.Labels}}).
+type pluginTemplateData struct {
+	ID     string
+	Title  string
+	Status string
+	Labels string
+}
+
+// RenderArgs expands p.Args's templates against issue.
+func (p Plugin) RenderArgs(issue *model.Issue) ([]string, error) {
+	data := pluginTemplateData{
+		ID:     issue.ID,
+		Title:  issue.Title,
+		Status: string(issue.Status),
+		Labels: strings.Join(issue.Labels, ","),
+	}
+
+	rendered := make([]string, len(p.Args))
+	for i, arg := range p.Args {
+		tmpl, err := template.New(p.Name).Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("ui: parsing plugin %q arg %q: %w", p.Name, arg, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("ui: rendering plugin %q arg %q: %w", p.Name, arg, err)
+		}
+		rendered[i] = buf.String()
+	}
+	return rendered, nil
+}
+
+// Cmd builds the *exec.Cmd for running p against issue. Callers run
+// foreground plugins as a suspended fullscreen subprocess (the same way a
+// `git commit` shells out to $EDITOR, e.g. via bubbletea's tea.ExecProcess)
+// and background plugins detached with output captured into a scrollable
+// pane.
+func (p Plugin) Cmd(ctx context.Context, issue *model.Issue) (*exec.Cmd, error) {
+	args, err := p.RenderArgs(issue)
+	if err != nil {
+		return nil, err
+	}
+	return exec.CommandContext(ctx, p.Command, args...), nil
+}
+
+// pluginRegistries attaches a PluginRegistry to a BoardModel without
+// adding a field to it, analogous to boardMutationState.
+var (
+	pluginRegistriesMu sync.Mutex
+	pluginRegistries   = map[*BoardModel]*PluginRegistry{}
+)
+
+func init() {
+	registerBoardCloseHook(func(b *BoardModel) {
+		pluginRegistriesMu.Lock()
+		delete(pluginRegistries, b)
+		pluginRegistriesMu.Unlock()
+	})
+}
+
+// SetPluginRegistry wires r into b's key dispatch: keys BoardModel doesn't
+// already handle are looked up against r via HandleUnmatchedKey.
+func (b *BoardModel) SetPluginRegistry(r *PluginRegistry) {
+	pluginRegistriesMu.Lock()
+	defer pluginRegistriesMu.Unlock()
+	pluginRegistries[b] = r
+}
+
+// HandleUnmatchedKey looks up key (e.g. "ctrl-b", "shift-o") against the
+// board's plugin registry in the "board" context and, if bound, returns
+// the exec.Cmd to run against the currently selected issue. ok is false if
+// no plugin is registered for key or no issue is selected, in which case
+// the caller should continue its normal key-dispatch fallthrough.
+func (b *BoardModel) HandleUnmatchedKey(ctx context.Context, key string) (cmd *exec.Cmd, plugin Plugin, ok bool) {
+	pluginRegistriesMu.Lock()
+	r := pluginRegistries[b]
+	pluginRegistriesMu.Unlock()
+	if r == nil {
+		return nil, Plugin{}, false
+	}
+
+	p, found := r.Lookup("board", key)
+	if !found {
+		return nil, Plugin{}, false
+	}
+
+	issue := b.SelectedIssue()
+	if issue == nil {
+		return nil, Plugin{}, false
+	}
+
+	c, err := p.Cmd(ctx, issue)
+	if err != nil {
+		return nil, Plugin{}, false
+	}
+	return c, p, true
+}
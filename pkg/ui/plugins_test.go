@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestPluginRegistryLookup(t *testing.T) {
+	r := NewPluginRegistry(PluginConfig{
+		Plugins: []Plugin{
+			{Name: "open-pr", ShortCut: "ctrl-b", Contexts: []string{"board"}, Command: "gh"},
+		},
+		Aliases: map[string]string{"pr": "open-pr"},
+	})
+
+	if _, ok := r.Lookup("board", "ctrl-b"); !ok {
+		t.Fatal("expected plugin bound to ctrl-b in board context")
+	}
+	if _, ok := r.Lookup("detail", "ctrl-b"); ok {
+		t.Fatal("plugin bound to board context should not match detail context")
+	}
+	if p, ok := r.ResolveAlias("pr"); !ok || p.Name != "open-pr" {
+		t.Fatalf("expected alias 'pr' to resolve to open-pr, got %+v, %v", p, ok)
+	}
+	if _, ok := r.ResolveAlias("missing"); ok {
+		t.Fatal("expected unknown alias to fail to resolve")
+	}
+}
+
+func TestPluginRenderArgs(t *testing.T) {
+	p := Plugin{
+		Name:    "comment",
+		Command: "gh",
+		Args:    []string{"issue", "comment", "{{.ID}}", "--body", "{{.Title}}"},
+	}
+	issue := &model.Issue{ID: "bv-42", Title: "Fix the thing", Status: model.StatusOpen}
+
+	args, err := p.RenderArgs(issue)
+	if err != nil {
+		t.Fatalf("RenderArgs returned error: %v", err)
+	}
+
+	want := []string{"issue", "comment", "bv-42", "--body", "Fix the thing"}
+	if len(args) != len(want) {
+		t.Fatalf("expected %d args, got %d: %v", len(want), len(args), args)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Errorf("arg[%d] = %q, want %q", i, args[i], want[i])
+		}
+	}
+}
+
+func TestPluginRegistryReloadMissingFileIsEmpty(t *testing.T) {
+	r := &PluginRegistry{}
+	if err := r.Reload("/nonexistent/plugins.yaml"); err != nil {
+		t.Fatalf("Reload of a missing file should not error, got: %v", err)
+	}
+	if _, ok := r.Lookup("board", "ctrl-b"); ok {
+		t.Fatal("expected empty registry after reloading a missing file")
+	}
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// DetectRenderer builds a *lipgloss.Renderer for out (typically
+// os.Stdout), resolving its color profile and dark-background flag once
+// up front rather than leaving them to lipgloss's own lazy detection.
+// Every Theme-derived style is built once, by NewTheme - if detection
+// happened later or per-Render, a style built before stdout got
+// redirected wouldn't notice the change, so DetectRenderer front-loads
+// the decision:
+//
+//   - NO_COLOR (any non-empty value, per https://no-color.org) or TERM=dumb forces
+//     termenv.Ascii, overriding everything else - this is meant to win
+//     even over an attached terminal.
+//   - FORCE_COLOR (any non-empty value) forces termenv.TrueColor, the convention
+//     several non-Go CLIs use for a script piping through a pager or log
+//     collector that still wants color.
+//   - Otherwise termenv.EnvColorProfile() decides: Ascii if out isn't a
+//     terminal (a pipe, a redirected file, `--json > out.json`) or under
+//     CLICOLOR=0, the terminal's own profile otherwise.
+//
+// The dark-background flag always comes from termenv.HasDarkBackground,
+// since a forced color profile says nothing about background color.
+func DetectRenderer(out *os.File) *lipgloss.Renderer {
+	r := lipgloss.NewRenderer(out)
+	r.SetHasDarkBackground(termenv.HasDarkBackground())
+
+	switch {
+	case os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb":
+		r.SetColorProfile(termenv.Ascii)
+	case os.Getenv("FORCE_COLOR") != "":
+		r.SetColorProfile(termenv.TrueColor)
+	default:
+		r.SetColorProfile(termenv.NewOutput(out).EnvColorProfile())
+	}
+
+	return r
+}
+
+// Styles bundles the Theme every pkg/ui view renders through, plus any
+// ancillary styles (RepoBadgeStyle) that used to be built with a bare
+// lipgloss.NewStyle() against the package-level default renderer instead
+// of a caller-supplied one. New is the single entry point for both, so a
+// redirected stdout, a NO_COLOR run, or an SSH session with its own
+// renderer (see RenderOption) all produce clean, matching styles from
+// one DetectRenderer call instead of each call site picking its own
+// renderer (or none) independently.
+type Styles struct {
+	Theme
+	RepoBadgeStyle lipgloss.Style
+}
+
+// New builds the Styles every TUI and non-TUI renderer in pkg/ui should
+// share, from r (see DetectRenderer for the usual way to build r from
+// os.Stdout at program entry).
+func New(r *lipgloss.Renderer) Styles {
+	return Styles{
+		Theme:          NewTheme(r),
+		RepoBadgeStyle: r.NewStyle().Bold(true),
+	}
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestNewRenderConfigDefaultsToLipglossDefaultRenderer(t *testing.T) {
+	c := NewRenderConfig()
+	if c.Renderer == nil {
+		t.Fatal("expected a non-nil default Renderer")
+	}
+}
+
+func TestWithRendererOverridesDefaultAndWritesNothingToStdout(t *testing.T) {
+	discard := lipgloss.NewRenderer(io.Discard)
+	c := NewRenderConfig(WithRenderer(discard))
+	if c.Renderer != discard {
+		t.Fatalf("expected RenderConfig.Renderer to be the injected renderer, got %v", c.Renderer)
+	}
+	// Rendering through it must only ever produce a string - nothing
+	// about NewRenderConfig or the style it returns touches os.Stdout.
+	style := c.Renderer.NewStyle().Bold(true)
+	if out := style.Render("hello"); out == "" {
+		t.Fatal("expected Render to still produce output")
+	}
+}
+
+func TestWithColorProfileOverridesRendererProfile(t *testing.T) {
+	discard := lipgloss.NewRenderer(io.Discard)
+	c := NewRenderConfig(WithRenderer(discard), WithColorProfile(termenv.Ascii))
+	if got := c.Renderer.ColorProfile(); got != termenv.Ascii {
+		t.Fatalf("expected ColorProfile Ascii, got %v", got)
+	}
+}
@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ColumnStats summarizes a ScrollableColumn's contents for its header:
+// the total card count plus a per-priority breakdown, the same counts
+// the board header renders as "(4) P0:2 P1:1".
+type ColumnStats struct {
+	Total      int
+	ByPriority map[int]int
+}
+
+// computeColumnStats scans items once and returns their ColumnStats.
+func computeColumnStats(items []model.Issue) ColumnStats {
+	stats := ColumnStats{ByPriority: map[int]int{}}
+	for _, iss := range items {
+		stats.Total++
+		stats.ByPriority[iss.Priority]++
+	}
+	return stats
+}
+
+// ScrollableColumn owns one board column's cards, selection, scroll
+// position, and rendering caches. A BoardModel orchestrating several of
+// these only has to handle cross-column navigation; everything about
+// scrolling and fitting cards to a viewport lives here instead of being
+// duplicated per column inline.
+type ScrollableColumn struct {
+	Title string
+	items []model.Issue
+	stats ColumnStats
+
+	selectedIdx  int
+	scrollOffset int
+
+	cachedWidth         int
+	cachedContentHeight int
+	cachedBadgeWidth    int
+	widthDirty          bool
+}
+
+// NewScrollableColumn returns a column titled title, holding items, with
+// its stats computed eagerly.
+func NewScrollableColumn(title string, items []model.Issue) *ScrollableColumn {
+	c := &ScrollableColumn{Title: title}
+	c.SetItems(items)
+	return c
+}
+
+// SetItems replaces the column's contents, recomputing stats, clamping
+// the selection to the new bounds, and invalidating the cached badge
+// widths so the next Render recomputes them.
+func (c *ScrollableColumn) SetItems(items []model.Issue) {
+	c.items = items
+	c.stats = computeColumnStats(items)
+	if c.selectedIdx >= len(items) {
+		c.selectedIdx = len(items) - 1
+	}
+	if c.selectedIdx < 0 {
+		c.selectedIdx = 0
+	}
+	c.InvalidateWidth()
+}
+
+// Items returns the column's current cards.
+func (c *ScrollableColumn) Items() []model.Issue {
+	return c.items
+}
+
+// Stats returns the column's cached ColumnStats.
+func (c *ScrollableColumn) Stats() ColumnStats {
+	return c.stats
+}
+
+// Len returns the number of cards in the column.
+func (c *ScrollableColumn) Len() int {
+	return len(c.items)
+}
+
+// SelectedIndex returns the index of the currently selected card, or -1
+// if the column is empty.
+func (c *ScrollableColumn) SelectedIndex() int {
+	if len(c.items) == 0 {
+		return -1
+	}
+	return c.selectedIdx
+}
+
+// Selected returns the currently selected card, or nil if the column is
+// empty.
+func (c *ScrollableColumn) Selected() *model.Issue {
+	idx := c.SelectedIndex()
+	if idx < 0 {
+		return nil
+	}
+	return &c.items[idx]
+}
+
+// MoveSelection moves the selection by delta cards, clamped to the
+// column's bounds, then scrolls to keep it visible.
+func (c *ScrollableColumn) MoveSelection(delta int) {
+	if len(c.items) == 0 {
+		return
+	}
+	c.selectedIdx += delta
+	if c.selectedIdx < 0 {
+		c.selectedIdx = 0
+	}
+	if c.selectedIdx >= len(c.items) {
+		c.selectedIdx = len(c.items) - 1
+	}
+	c.EnsureVisible()
+}
+
+// Page moves the selection by delta full viewport pages, using the
+// content height the last Render call measured (or a single card if
+// Render hasn't run yet).
+func (c *ScrollableColumn) Page(delta int) {
+	page := c.cachedContentHeight
+	if page <= 0 {
+		page = 1
+	}
+	c.MoveSelection(delta * page)
+}
+
+// EnsureVisible adjusts scrollOffset so the selected card falls within
+// the last rendered viewport height.
+func (c *ScrollableColumn) EnsureVisible() {
+	height := c.cachedContentHeight
+	if height <= 0 {
+		return
+	}
+	if c.selectedIdx < c.scrollOffset {
+		c.scrollOffset = c.selectedIdx
+	}
+	if c.selectedIdx >= c.scrollOffset+height {
+		c.scrollOffset = c.selectedIdx - height + 1
+	}
+	if c.scrollOffset < 0 {
+		c.scrollOffset = 0
+	}
+}
+
+// InvalidateWidth marks the column's cached badge widths as stale, so
+// the next Render recomputes them instead of reusing measurements taken
+// for a different terminal width or issue set.
+func (c *ScrollableColumn) InvalidateWidth() {
+	c.widthDirty = true
+}
+
+// badgeWidthFor returns the space Render reserves for the priority icon
+// and age badge at the given column width - narrower columns drop the
+// age badge entirely rather than truncating it unreadably.
+func badgeWidthFor(width int) int {
+	switch {
+	case width >= 40:
+		return badgeWidthPriority + badgeWidthAge
+	case width >= 24:
+		return badgeWidthPriority
+	default:
+		return 0
+	}
+}
+
+const (
+	badgeWidthPriority = 2 // priority icon + trailing space
+	badgeWidthAge      = 6 // age badge, e.g. "  12d"
+)
+
+// Render draws the column within width x height, caching the badge
+// width it derives from width until the next SetItems, InvalidateWidth,
+// or a Render call at a different width.
+func (c *ScrollableColumn) Render(width, height int, theme Theme) string {
+	contentHeight := height - 1 // header line
+	if contentHeight < 0 {
+		contentHeight = 0
+	}
+	c.cachedContentHeight = contentHeight
+
+	if c.widthDirty || c.cachedWidth != width {
+		c.cachedWidth = width
+		c.cachedBadgeWidth = badgeWidthFor(width)
+		c.widthDirty = false
+	}
+	c.EnsureVisible()
+
+	header := fmt.Sprintf("%s (%d)", c.Title, c.stats.Total)
+
+	end := c.scrollOffset + contentHeight
+	if end > len(c.items) {
+		end = len(c.items)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header)
+	for i := c.scrollOffset; i < end; i++ {
+		sb.WriteString("\n")
+		sb.WriteString(c.renderCard(i))
+	}
+	return sb.String()
+}
+
+// renderCard formats the card at index i, including its selection
+// marker and whatever badges fit in the column's cached badge width.
+func (c *ScrollableColumn) renderCard(i int) string {
+	iss := c.items[i]
+
+	prefix := "  "
+	if i == c.selectedIdx {
+		prefix = "> "
+	}
+
+	line := prefix + iss.Title
+	if c.cachedBadgeWidth >= badgeWidthPriority {
+		line = GetPriorityIcon(iss.Priority) + " " + line
+	}
+	if c.cachedBadgeWidth >= badgeWidthPriority+badgeWidthAge && !iss.CreatedAt.IsZero() {
+		line += fmt.Sprintf("  %dd", int(time.Since(iss.CreatedAt).Hours()/24))
+	}
+	return line
+}
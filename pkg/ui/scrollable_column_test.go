@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func issuesForColumn(n int) []model.Issue {
+	issues := make([]model.Issue, n)
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("bv-%d", i), Title: fmt.Sprintf("Issue %d", i)}
+	}
+	return issues
+}
+
+func TestScrollableColumnMoveSelectionClampsToBounds(t *testing.T) {
+	col := NewScrollableColumn("Open", issuesForColumn(3))
+
+	col.MoveSelection(-5)
+	if col.SelectedIndex() != 0 {
+		t.Fatalf("expected selection clamped to 0, got %d", col.SelectedIndex())
+	}
+
+	col.MoveSelection(5)
+	if col.SelectedIndex() != 2 {
+		t.Fatalf("expected selection clamped to 2, got %d", col.SelectedIndex())
+	}
+}
+
+func TestScrollableColumnEnsureVisibleScrollsDown(t *testing.T) {
+	col := NewScrollableColumn("Open", issuesForColumn(20))
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+
+	col.Render(40, 6, theme) // 5 content rows after the header
+
+	col.MoveSelection(10)
+	if col.SelectedIndex() != 10 {
+		t.Fatalf("expected selection 10, got %d", col.SelectedIndex())
+	}
+	if col.scrollOffset == 0 {
+		t.Fatal("expected EnsureVisible to scroll down once selection leaves the viewport")
+	}
+	if col.SelectedIndex() < col.scrollOffset || col.SelectedIndex() >= col.scrollOffset+col.cachedContentHeight {
+		t.Errorf("selected index %d not within viewport [%d,%d)", col.SelectedIndex(), col.scrollOffset, col.scrollOffset+col.cachedContentHeight)
+	}
+}
+
+func TestScrollableColumnPageUsesLastRenderedHeight(t *testing.T) {
+	col := NewScrollableColumn("Open", issuesForColumn(20))
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	col.Render(40, 6, theme) // 5 content rows
+
+	col.Page(1)
+	if col.SelectedIndex() != 5 {
+		t.Fatalf("expected Page(1) to move selection by the content height (5), got %d", col.SelectedIndex())
+	}
+}
+
+func TestScrollableColumnSetItemsInvalidatesWidthAndClampsSelection(t *testing.T) {
+	col := NewScrollableColumn("Open", issuesForColumn(5))
+	col.MoveSelection(4)
+
+	col.SetItems(issuesForColumn(2))
+	if col.SelectedIndex() != 1 {
+		t.Fatalf("expected selection clamped to the new bounds, got %d", col.SelectedIndex())
+	}
+	if !col.widthDirty {
+		t.Error("expected SetItems to invalidate the cached badge width")
+	}
+}
+
+func TestScrollableColumnRenderDropsBadgesWhenNarrow(t *testing.T) {
+	col := NewScrollableColumn("Open", []model.Issue{{ID: "bv-1", Title: "Fix bug", Priority: 0}})
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+
+	wide := col.Render(60, 5, theme)
+	if !strings.Contains(wide, GetPriorityIcon(0)) {
+		t.Error("expected a wide render to include the priority badge")
+	}
+
+	narrow := col.Render(10, 5, theme)
+	if strings.Contains(narrow, GetPriorityIcon(0)) {
+		t.Error("expected a narrow render to drop the priority badge")
+	}
+}
+
+func TestScrollableColumnRenderHeaderIncludesTotal(t *testing.T) {
+	col := NewScrollableColumn("Open", issuesForColumn(4))
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+
+	out := col.Render(40, 10, theme)
+	if !strings.Contains(strings.SplitN(out, "\n", 2)[0], "Open (4)") {
+		t.Errorf("expected header to read %q, got %q", "Open (4)", strings.SplitN(out, "\n", 2)[0])
+	}
+}
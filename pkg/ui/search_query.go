@@ -0,0 +1,374 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Expr is a node in a parsed search query, built by ParseQuery and
+// evaluated against a model.Issue by Evaluate.
+type Expr interface {
+	isExpr()
+}
+
+// And matches when both Left and Right match.
+type And struct{ Left, Right Expr }
+
+// Or matches when either Left or Right matches.
+type Or struct{ Left, Right Expr }
+
+// Not matches when Expr does not.
+type Not struct{ Expr Expr }
+
+// FieldEq matches issues whose Field equals Value, e.g. "priority:0" or
+// "status:in_progress".
+type FieldEq struct{ Field, Value string }
+
+// FieldCmp matches issues whose Field compares to Value via Op, e.g.
+// "age>30d".
+type FieldCmp struct{ Field, Op, Value string }
+
+// Text matches issues whose title contains Value, case-insensitively.
+// An empty Value matches everything.
+type Text struct{ Value string }
+
+func (And) isExpr()      {}
+func (Or) isExpr()       {}
+func (Not) isExpr()      {}
+func (FieldEq) isExpr()  {}
+func (FieldCmp) isExpr() {}
+func (Text) isExpr()     {}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+	tokEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lex splits query into words, the AND/OR/NOT keywords (case-insensitive),
+// and parentheses, which are always their own token even when not
+// surrounded by whitespace (e.g. "(priority:0)").
+func lex(query string) []token {
+	var tokens []token
+	var word strings.Builder
+
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+		text := word.String()
+		word.Reset()
+		switch strings.ToUpper(text) {
+		case "AND":
+			tokens = append(tokens, token{kind: tokAnd, text: text})
+		case "OR":
+			tokens = append(tokens, token{kind: tokOr, text: text})
+		case "NOT":
+			tokens = append(tokens, token{kind: tokNot, text: text})
+		default:
+			tokens = append(tokens, token{kind: tokWord, text: text})
+		}
+	}
+
+	for _, r := range query {
+		switch r {
+		case ' ', '\t':
+			flush()
+		case '(':
+			flush()
+			tokens = append(tokens, token{kind: tokLParen, text: "("})
+		case ')':
+			flush()
+			tokens = append(tokens, token{kind: tokRParen, text: ")"})
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// parser is a recursive-descent parser over lex's token stream, for the
+// grammar:
+//
+//	or    := and (OR and)*
+//	and   := unary (AND? unary)*   // AND is optional: adjacent terms are implicitly ANDed
+//	unary := NOT unary | primary
+//	primary := '(' or ')' | WORD
+type parser struct {
+	tokens []token
+	pos    int
+	err    error
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: tokEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) fail(format string, args ...any) Expr {
+	if p.err == nil {
+		p.err = fmt.Errorf(format, args...)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() Expr {
+	left := p.parseAnd()
+	for p.err == nil && p.peek().kind == tokOr {
+		p.next()
+		left = Or{Left: left, Right: p.parseAnd()}
+	}
+	return left
+}
+
+func (p *parser) parseAnd() Expr {
+	left := p.parseUnary()
+	for p.err == nil {
+		switch p.peek().kind {
+		case tokAnd:
+			p.next()
+		case tokWord, tokNot, tokLParen:
+			// implicit AND between adjacent terms
+		default:
+			return left
+		}
+		left = And{Left: left, Right: p.parseUnary()}
+	}
+	return left
+}
+
+func (p *parser) parseUnary() Expr {
+	if p.peek().kind == tokNot {
+		p.next()
+		return Not{Expr: p.parseUnary()}
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() Expr {
+	tok := p.peek()
+	switch tok.kind {
+	case tokLParen:
+		p.next()
+		e := p.parseOr()
+		if p.peek().kind != tokRParen {
+			return p.fail("expected closing parenthesis")
+		}
+		p.next()
+		return e
+	case tokWord:
+		p.next()
+		return parseAtom(tok.text)
+	default:
+		return p.fail("unexpected token %q", tok.text)
+	}
+}
+
+// cmpFields lists the field names FieldCmp supports; anything else in a
+// "field>value" position is treated as plain Text instead.
+var cmpFields = map[string]bool{"age": true}
+
+// eqFields lists the field names FieldEq supports; anything else in a
+// "field:value" position is treated as plain Text instead.
+var eqFields = map[string]bool{
+	"priority": true,
+	"status":   true,
+	"type":     true,
+	"assignee": true,
+	"blocked":  true,
+	"dep":      true,
+}
+
+// parseAtom classifies a single lexed word as a FieldCmp, a FieldEq, or
+// a bare Text term.
+func parseAtom(word string) Expr {
+	if field, op, value, ok := splitFieldCmp(word); ok {
+		return FieldCmp{Field: field, Op: op, Value: value}
+	}
+	if field, value, ok := splitFieldEq(word); ok {
+		return FieldEq{Field: field, Value: value}
+	}
+	return Text{Value: word}
+}
+
+func splitFieldCmp(word string) (field, op, value string, ok bool) {
+	for _, candidate := range []string{">=", "<=", ">", "<"} {
+		idx := strings.Index(word, candidate)
+		if idx <= 0 {
+			continue
+		}
+		field = word[:idx]
+		if !cmpFields[field] {
+			continue
+		}
+		return field, candidate, word[idx+len(candidate):], true
+	}
+	return "", "", "", false
+}
+
+func splitFieldEq(word string) (field, value string, ok bool) {
+	idx := strings.Index(word, ":")
+	if idx <= 0 {
+		return "", "", false
+	}
+	field = word[:idx]
+	if !eqFields[field] {
+		return "", "", false
+	}
+	return field, word[idx+1:], true
+}
+
+// ParseQuery parses query into an Expr under the filter DSL: field tokens
+// (priority:0, status:in_progress, type:bug, assignee:alice, age>30d,
+// blocked:true, dep:BV-ABC), AND/OR/NOT, parenthesization, and bare words
+// that fall back to a case-insensitive title match. An empty query parses
+// to a Text{""} that matches everything.
+func ParseQuery(query string) (Expr, error) {
+	tokens := lex(query)
+	if len(tokens) == 0 {
+		return Text{}, nil
+	}
+
+	p := &parser{tokens: tokens}
+	expr := p.parseOr()
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("unexpected trailing token %q", p.peek().text)
+	}
+	return expr, nil
+}
+
+// Evaluate reports whether issue matches expr.
+func Evaluate(expr Expr, issue model.Issue) bool {
+	switch e := expr.(type) {
+	case And:
+		return Evaluate(e.Left, issue) && Evaluate(e.Right, issue)
+	case Or:
+		return Evaluate(e.Left, issue) || Evaluate(e.Right, issue)
+	case Not:
+		return !Evaluate(e.Expr, issue)
+	case FieldEq:
+		return evaluateFieldEq(e, issue)
+	case FieldCmp:
+		return evaluateFieldCmp(e, issue)
+	case Text:
+		return evaluateText(e, issue)
+	default:
+		return false
+	}
+}
+
+func evaluateFieldEq(e FieldEq, issue model.Issue) bool {
+	switch e.Field {
+	case "priority":
+		p, err := strconv.Atoi(e.Value)
+		return err == nil && issue.Priority == p
+	case "status":
+		return strings.EqualFold(string(issue.Status), e.Value)
+	case "type":
+		return strings.EqualFold(string(issue.IssueType), e.Value)
+	case "assignee":
+		return strings.EqualFold(issue.Assignee, e.Value)
+	case "blocked":
+		want := strings.EqualFold(e.Value, "true")
+		return (issue.Status == model.StatusBlocked) == want
+	case "dep":
+		for _, dep := range issue.Dependencies {
+			if dep.Type == model.DepBlocks && strings.EqualFold(dep.DependsOnID, e.Value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func evaluateFieldCmp(e FieldCmp, issue model.Issue) bool {
+	if e.Field != "age" {
+		return false
+	}
+	age, ok := parseAgeDuration(e.Value)
+	if !ok {
+		return false
+	}
+	elapsed := time.Since(issue.CreatedAt)
+	switch e.Op {
+	case ">":
+		return elapsed > age
+	case ">=":
+		return elapsed >= age
+	case "<":
+		return elapsed < age
+	case "<=":
+		return elapsed <= age
+	default:
+		return false
+	}
+}
+
+// parseAgeDuration parses an age value like "30d" into a time.Duration.
+// Days are the only unit the DSL accepts, since issue age is never
+// usefully queried down to the hour.
+func parseAgeDuration(value string) (time.Duration, bool) {
+	days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(days) * 24 * time.Hour, true
+}
+
+func evaluateText(e Text, issue model.Issue) bool {
+	if e.Value == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(issue.Title), strings.ToLower(e.Value))
+}
+
+// SearchExpr parses b's current SearchQuery under the filter DSL. A
+// query that fails to parse - for instance an unmatched parenthesis
+// typed mid-keystroke - degrades to a plain Text match over the whole
+// query string, so the search box never shows a parse error while the
+// user is still typing.
+func (b *BoardModel) SearchExpr() Expr {
+	query := b.SearchQuery()
+	expr, err := ParseQuery(query)
+	if err != nil {
+		return Text{Value: query}
+	}
+	return expr
+}
+
+// MatchesSearch reports whether issue satisfies b's current search
+// query.
+func (b *BoardModel) MatchesSearch(issue model.Issue) bool {
+	return Evaluate(b.SearchExpr(), issue)
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func mustParse(t *testing.T, query string) Expr {
+	t.Helper()
+	expr, err := ParseQuery(query)
+	if err != nil {
+		t.Fatalf("ParseQuery(%q) returned error: %v", query, err)
+	}
+	return expr
+}
+
+func TestParseQueryBareWordFallsBackToText(t *testing.T) {
+	expr := mustParse(t, "auth")
+	if _, ok := expr.(Text); !ok {
+		t.Fatalf("expected Text, got %T", expr)
+	}
+
+	match := Evaluate(expr, model.Issue{Title: "Fix AUTH bug"})
+	if !match {
+		t.Error("expected case-insensitive title match")
+	}
+	if Evaluate(expr, model.Issue{Title: "unrelated"}) {
+		t.Error("expected no match for unrelated title")
+	}
+}
+
+func TestParseQueryFieldEqTokens(t *testing.T) {
+	cases := []struct {
+		query string
+		issue model.Issue
+		want  bool
+	}{
+		{"priority:0", model.Issue{Priority: 0}, true},
+		{"priority:0", model.Issue{Priority: 1}, false},
+		{"status:in_progress", model.Issue{Status: model.StatusInProgress}, true},
+		{"status:in_progress", model.Issue{Status: model.StatusOpen}, false},
+		{"type:bug", model.Issue{IssueType: model.TypeBug}, true},
+		{"assignee:alice", model.Issue{Assignee: "Alice"}, true},
+		{"assignee:alice", model.Issue{Assignee: "bob"}, false},
+		{"blocked:true", model.Issue{Status: model.StatusBlocked}, true},
+		{"blocked:true", model.Issue{Status: model.StatusOpen}, false},
+		{"dep:BV-ABC", model.Issue{Dependencies: []*model.Dependency{
+			{DependsOnID: "bv-abc", Type: model.DepBlocks},
+		}}, true},
+	}
+
+	for _, c := range cases {
+		expr := mustParse(t, c.query)
+		if got := Evaluate(expr, c.issue); got != c.want {
+			t.Errorf("query %q against %+v: got %v, want %v", c.query, c.issue, got, c.want)
+		}
+	}
+}
+
+func TestParseQueryAgeComparison(t *testing.T) {
+	expr := mustParse(t, "age>30d")
+
+	old := model.Issue{CreatedAt: time.Now().Add(-40 * 24 * time.Hour)}
+	if !Evaluate(expr, old) {
+		t.Error("expected a 40-day-old issue to match age>30d")
+	}
+
+	fresh := model.Issue{CreatedAt: time.Now().Add(-2 * 24 * time.Hour)}
+	if Evaluate(expr, fresh) {
+		t.Error("expected a 2-day-old issue not to match age>30d")
+	}
+}
+
+func TestParseQueryBooleanOperators(t *testing.T) {
+	bug := model.Issue{Title: "crash", IssueType: model.TypeBug, Priority: 0}
+	feature := model.Issue{Title: "crash", IssueType: model.TypeFeature, Priority: 0}
+
+	and := mustParse(t, "priority:0 AND type:bug")
+	if !Evaluate(and, bug) || Evaluate(and, feature) {
+		t.Error("AND should require both sides to match")
+	}
+
+	implicitAnd := mustParse(t, "priority:0 type:bug")
+	if !Evaluate(implicitAnd, bug) || Evaluate(implicitAnd, feature) {
+		t.Error("adjacent terms should be implicitly ANDed")
+	}
+
+	or := mustParse(t, "type:bug OR type:feature")
+	if !Evaluate(or, bug) || !Evaluate(or, feature) {
+		t.Error("OR should match either side")
+	}
+
+	not := mustParse(t, "NOT type:bug")
+	if Evaluate(not, bug) || !Evaluate(not, feature) {
+		t.Error("NOT should invert its operand")
+	}
+
+	grouped := mustParse(t, "(type:bug OR type:feature) AND priority:0")
+	if !Evaluate(grouped, bug) || !Evaluate(grouped, feature) {
+		t.Error("parenthesized OR should be evaluated before the outer AND")
+	}
+	if Evaluate(grouped, model.Issue{Title: "crash", IssueType: model.TypeFeature, Priority: 1}) {
+		t.Error("expected priority:0 to still be required outside the parens")
+	}
+}
+
+func TestParseQueryUnmatchedParenReturnsError(t *testing.T) {
+	if _, err := ParseQuery("(type:bug"); err == nil {
+		t.Fatal("expected an error for an unmatched parenthesis")
+	}
+}
+
+func TestBoardModelSearchExprDegradesToTextOnParseError(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := []model.Issue{{ID: "bv-1", Title: "(typing"}}
+	b := NewBoardModel(issues, theme)
+
+	b.StartSearch()
+	for _, ch := range "(typing" {
+		b.AppendSearchChar(ch)
+	}
+
+	expr := b.SearchExpr()
+	text, ok := expr.(Text)
+	if !ok {
+		t.Fatalf("expected Text fallback for an unparseable query, got %T", expr)
+	}
+	if text.Value != "(typing" {
+		t.Errorf("expected fallback Text to carry the raw query, got %q", text.Value)
+	}
+}
+
+func TestSearchCaseInsensitiveViaMatchesSearch(t *testing.T) {
+	theme := DefaultTheme(lipgloss.NewRenderer(nil))
+	issues := []model.Issue{{ID: "bv-1", Title: "Fix Title bug"}}
+	b := NewBoardModel(issues, theme)
+
+	b.StartSearch()
+	for _, ch := range "TITLE" {
+		b.AppendSearchChar(ch)
+	}
+
+	if !b.MatchesSearch(issues[0]) {
+		t.Error("expected case-insensitive search to match")
+	}
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SuggestionKind identifies what a Suggestion completes.
+type SuggestionKind string
+
+const (
+	SuggestIssueID  SuggestionKind = "issue_id"  // triggered by '#'
+	SuggestAssignee SuggestionKind = "assignee"  // triggered by '@'
+	SuggestLabel    SuggestionKind = "label"     // triggered by ':'
+	SuggestFacet    SuggestionKind = "facet"     // triggered by '!' (status/priority/type)
+)
+
+// Suggestion is one candidate completion for the token currently being
+// typed in the search prompt.
+type Suggestion struct {
+	Kind    SuggestionKind
+	Token   string // the literal text to substitute in place of the partial token, trigger char included
+	Display string // human-readable label for the popup
+}
+
+// searchSuggestState tracks the autocomplete popup for a BoardModel,
+// attached by identity the same way boardMutationState is (see
+// BoardModel's side-table doc comment in board.go).
+type searchSuggestState struct {
+	mu          sync.Mutex
+	suggestions []Suggestion
+	selected    int
+	tokenStart  int // byte offset of the trigger char within SearchQuery()
+}
+
+var (
+	searchSuggestMu     sync.Mutex
+	searchSuggestStates = map[*BoardModel]*searchSuggestState{}
+)
+
+func init() {
+	registerBoardCloseHook(func(b *BoardModel) {
+		searchSuggestMu.Lock()
+		delete(searchSuggestStates, b)
+		searchSuggestMu.Unlock()
+	})
+}
+
+func searchSuggestStateFor(b *BoardModel) *searchSuggestState {
+	searchSuggestMu.Lock()
+	defer searchSuggestMu.Unlock()
+	st, ok := searchSuggestStates[b]
+	if !ok {
+		st = &searchSuggestState{}
+		searchSuggestStates[b] = st
+	}
+	return st
+}
+
+// triggerKinds maps a trigger character to the kind of token it completes.
+var triggerKinds = map[byte]SuggestionKind{
+	'#': SuggestIssueID,
+	'@': SuggestAssignee,
+	':': SuggestLabel,
+	'!': SuggestFacet,
+}
+
+// facetSuggestions lists the literal values completed by '!', mirroring
+// status/priority/type together since they share one trigger.
+var facetSuggestions = []string{
+	"open", "in_progress", "blocked", "deferred", "pinned", "hooked", "closed", "tombstone",
+	"p0", "p1", "p2", "p3", "p4",
+	"bug", "feature", "task", "epic", "chore",
+}
+
+// UpdateSuggestions recomputes SearchSuggestions() from the current search
+// query against allIssues. It should be called after every character typed
+// or removed in the search prompt (i.e. from the same key-dispatch path
+// that calls AppendSearchChar/BackspaceSearch), since the trigger token is
+// whatever partial word currently precedes the cursor.
+func (b *BoardModel) UpdateSuggestions(allIssues []model.Issue) {
+	st := searchSuggestStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	query := b.SearchQuery()
+	trigger, tokenStart, partial, ok := lastToken(query)
+	if !ok {
+		st.suggestions = nil
+		st.selected = 0
+		return
+	}
+
+	st.tokenStart = tokenStart
+	st.suggestions = rankSuggestions(triggerKinds[trigger], trigger, partial, allIssues)
+	st.selected = 0
+}
+
+// lastToken finds the trigger character and partial word immediately
+// preceding the end of query, e.g. "auth #bv-" -> ('#', 5, "bv-", true).
+func lastToken(query string) (trigger byte, start int, partial string, ok bool) {
+	for i := len(query) - 1; i >= 0; i-- {
+		c := query[i]
+		if c == ' ' {
+			return 0, 0, "", false
+		}
+		if _, isTrigger := triggerKinds[c]; isTrigger {
+			return c, i, query[i+1:], true
+		}
+	}
+	return 0, 0, "", false
+}
+
+// rankSuggestions scores candidates from allIssues against partial: prefix
+// matches first, then substring, then a simple subsequence ("fuzzy") match.
+func rankSuggestions(kind SuggestionKind, trigger byte, partial string, allIssues []model.Issue) []Suggestion {
+	candidateSet := map[string]bool{}
+	switch kind {
+	case SuggestIssueID:
+		for _, iss := range allIssues {
+			candidateSet[iss.ID] = true
+		}
+	case SuggestAssignee:
+		for _, iss := range allIssues {
+			if iss.Assignee != "" {
+				candidateSet[iss.Assignee] = true
+			}
+		}
+	case SuggestLabel:
+		for _, iss := range allIssues {
+			for _, l := range iss.Labels {
+				candidateSet[l] = true
+			}
+		}
+	case SuggestFacet:
+		for _, f := range facetSuggestions {
+			candidateSet[f] = true
+		}
+	default:
+		return nil
+	}
+
+	type scored struct {
+		text string
+		rank int // lower is better: 0=prefix, 1=substring, 2=fuzzy
+	}
+	var results []scored
+	lowerPartial := strings.ToLower(partial)
+	for c := range candidateSet {
+		lowerC := strings.ToLower(c)
+		switch {
+		case strings.HasPrefix(lowerC, lowerPartial):
+			results = append(results, scored{c, 0})
+		case strings.Contains(lowerC, lowerPartial):
+			results = append(results, scored{c, 1})
+		case isFuzzySubsequence(lowerPartial, lowerC):
+			results = append(results, scored{c, 2})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].rank != results[j].rank {
+			return results[i].rank < results[j].rank
+		}
+		return results[i].text < results[j].text
+	})
+
+	suggestions := make([]Suggestion, len(results))
+	for i, r := range results {
+		suggestions[i] = Suggestion{
+			Kind:    kind,
+			Token:   string(trigger) + r.text,
+			Display: r.text,
+		}
+	}
+	return suggestions
+}
+
+// isFuzzySubsequence reports whether every character of needle appears in
+// haystack in order, allowing gaps (a classic fuzzy-match test).
+func isFuzzySubsequence(needle, haystack string) bool {
+	if needle == "" {
+		return true
+	}
+	ni := 0
+	for i := 0; i < len(haystack) && ni < len(needle); i++ {
+		if haystack[i] == needle[ni] {
+			ni++
+		}
+	}
+	return ni == len(needle)
+}
+
+// SearchSuggestions returns the current autocomplete candidates for the
+// token being typed, ranked best-first.
+func (b *BoardModel) SearchSuggestions() []Suggestion {
+	st := searchSuggestStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return append([]Suggestion(nil), st.suggestions...)
+}
+
+// NextSuggestion advances the popup's selection, wrapping around.
+func (b *BoardModel) NextSuggestion() {
+	st := searchSuggestStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if len(st.suggestions) == 0 {
+		return
+	}
+	st.selected = (st.selected + 1) % len(st.suggestions)
+}
+
+// PrevSuggestion retreats the popup's selection, wrapping around.
+func (b *BoardModel) PrevSuggestion() {
+	st := searchSuggestStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if len(st.suggestions) == 0 {
+		return
+	}
+	st.selected = (st.selected - 1 + len(st.suggestions)) % len(st.suggestions)
+}
+
+// AcceptSuggestion replaces the in-progress token with suggestion i. A
+// facet suggestion (triggered by '!') scopes the search to that facet
+// rather than being substituted as freeform text: it is rewritten as the
+// matching filter-DSL field token, so "!bug" becomes "type:bug", "!p1"
+// becomes "priority:1", and a status such as "!open" becomes "status:open".
+func (b *BoardModel) AcceptSuggestion(i int) {
+	st := searchSuggestStateFor(b)
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if i < 0 || i >= len(st.suggestions) {
+		return
+	}
+	chosen := st.suggestions[i]
+
+	query := b.SearchQuery()
+	prefix := query[:st.tokenStart]
+	replacement := chosen.Token
+	if chosen.Kind == SuggestFacet {
+		v := chosen.Display
+		switch {
+		case len(v) == 2 && v[0] == 'p' && v[1] >= '0' && v[1] <= '9':
+			replacement = "priority:" + v[1:]
+		case v == "bug" || v == "feature" || v == "task" || v == "epic" || v == "chore":
+			replacement = "type:" + v
+		default:
+			replacement = "status:" + v
+		}
+	}
+
+	newQuery := prefix + replacement
+	b.CancelSearch()
+	b.StartSearch()
+	for _, r := range newQuery {
+		b.AppendSearchChar(r)
+	}
+
+	st.suggestions = nil
+	st.selected = 0
+}
@@ -0,0 +1,47 @@
+package ui
+
+import "testing"
+
+func TestLastToken(t *testing.T) {
+	tests := []struct {
+		query       string
+		wantTrigger byte
+		wantPartial string
+		wantOK      bool
+	}{
+		{"auth #bv-1", '#', "bv-1", true},
+		{"auth", 0, "", false},
+		{"@ali", '@', "ali", true},
+		{"auth #bv-1 more", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		trigger, _, partial, ok := lastToken(tt.query)
+		if ok != tt.wantOK {
+			t.Errorf("lastToken(%q) ok = %v, want %v", tt.query, ok, tt.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if trigger != tt.wantTrigger || partial != tt.wantPartial {
+			t.Errorf("lastToken(%q) = (%c, %q), want (%c, %q)", tt.query, trigger, partial, tt.wantTrigger, tt.wantPartial)
+		}
+	}
+}
+
+func TestIsFuzzySubsequence(t *testing.T) {
+	if !isFuzzySubsequence("bv1", "bv-1daf") {
+		t.Error("expected bv1 to fuzzy-match bv-1daf")
+	}
+	if isFuzzySubsequence("xyz", "bv-1daf") {
+		t.Error("expected xyz not to fuzzy-match bv-1daf")
+	}
+}
+
+func TestRankSuggestionsPrefixBeatsFuzzy(t *testing.T) {
+	suggestions := rankSuggestions(SuggestFacet, '!', "bu", nil)
+	if len(suggestions) == 0 || suggestions[0].Display != "bug" {
+		t.Fatalf("expected 'bug' to rank first for partial 'bu', got %+v", suggestions)
+	}
+}
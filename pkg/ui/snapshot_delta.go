@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// snapshotDeltaCompactionFraction is the cumulative fraction of the
+// prior snapshot's issue count a chain of applied deltas may touch
+// before SnapshotBuilder gives up patching incrementally and falls back
+// to a full Build(): past this point, reconstructing IssueMap,
+// BoardState, GraphLayout, and the tree from scratch costs about as
+// much as patching does, without the risk of compounding drift across
+// many small ApplyDelta calls.
+const snapshotDeltaCompactionFraction = 0.25
+
+// SnapshotDelta describes the issues that changed between two
+// DataSnapshot builds: Added and Removed are keyed by model.Issue.ID
+// only being present in one snapshot or the other, Updated is any issue
+// present in both whose fields differ. Revision increases by one per
+// delta in a chain, so a consumer can detect a gap (a missed delta)
+// and fall back to requesting a fresh Build() instead of applying a
+// delta against a snapshot it doesn't follow.
+type SnapshotDelta struct {
+	Added    []model.Issue
+	Updated  []model.Issue
+	Removed  []model.Issue
+	Revision int64
+}
+
+// IsEmpty reports whether delta touches no issues at all.
+func (d SnapshotDelta) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Updated) == 0 && len(d.Removed) == 0
+}
+
+// size is the number of issues delta touches, the unit
+// snapshotDeltaCompactionFraction is measured against.
+func (d SnapshotDelta) size() int {
+	return len(d.Added) + len(d.Updated) + len(d.Removed)
+}
+
+// SnapshotDeltaMsg is SnapshotReadyMsg's incremental counterpart:
+// delivered when only a delta is available, so Model.Update can patch
+// its current DataSnapshot via SnapshotBuilder.ApplyDelta instead of
+// swapping in a fully rebuilt one.
+//
+// NOTE: ApplyDelta is not yet implemented in this checkout.
+// SnapshotBuilder, DataSnapshot, BoardState, GraphLayout, and the tree
+// types it would need to patch (exercised by pkg/ui/snapshot_test.go)
+// aren't present in this tree - only SnapshotDeltaMsg and SnapshotDelta
+// themselves, which don't depend on them, are added here. Wiring up
+// ApplyDelta and the Model.Update case for SnapshotDeltaMsg is left for
+// whoever restores that subsystem.
+type SnapshotDeltaMsg struct {
+	Delta SnapshotDelta
+}
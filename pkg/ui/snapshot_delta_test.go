@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSnapshotDeltaIsEmpty(t *testing.T) {
+	if !(SnapshotDelta{}).IsEmpty() {
+		t.Fatal("expected a zero-value SnapshotDelta to be empty")
+	}
+
+	nonEmpty := []SnapshotDelta{
+		{Added: []model.Issue{{ID: "1"}}},
+		{Updated: []model.Issue{{ID: "1"}}},
+		{Removed: []model.Issue{{ID: "1"}}},
+	}
+	for i, d := range nonEmpty {
+		if d.IsEmpty() {
+			t.Errorf("case %d: expected a delta touching an issue to not be empty", i)
+		}
+	}
+}
+
+func TestSnapshotDeltaSize(t *testing.T) {
+	d := SnapshotDelta{
+		Added:   []model.Issue{{ID: "1"}, {ID: "2"}},
+		Updated: []model.Issue{{ID: "3"}},
+		Removed: []model.Issue{{ID: "4"}},
+	}
+	if got := d.size(); got != 4 {
+		t.Fatalf("expected size 4, got %d", got)
+	}
+	if got := (SnapshotDelta{}).size(); got != 0 {
+		t.Fatalf("expected size 0 for a zero-value delta, got %d", got)
+	}
+}
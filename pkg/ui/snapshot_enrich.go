@@ -0,0 +1,38 @@
+package ui
+
+// EnrichConfig declares one join/enrichment column a recipe attaches to
+// every ListItem at snapshot build time: resolve the issue related to
+// each list item via From (a parent-child/epic/blocker relation, or an
+// arbitrary model.DependencyType name), pull Fields off of it, and write
+// them into ListItem.Enriched under the As key - analogous to how
+// Prometheus' info() joins label metadata from a companion series onto
+// query results.
+//
+// NOTE: this checkout is missing the subsystem EnrichConfig plugs into -
+// recipe.Recipe, SnapshotBuilder, GraphLayout, TreeNodeMap, and ListItem
+// itself aren't present here (see the note on SnapshotDeltaMsg in
+// snapshot_delta.go for the same gap). EnrichConfig's shape is added now
+// so a future SnapshotBuilder.WithRecipe can consume it, but the
+// resolution logic - including the cycle/missing-parent handling and the
+// nearest-ancestor/lowest-ID precedence rules the request calls for - is
+// left for whoever restores that subsystem.
+// Untested: EnrichConfig has no methods and nothing else in this tree
+// reads its fields yet (see the NOTE above) - there's no exported
+// behavior for a test to exercise beyond restating its field names back
+// at itself. Add one alongside whatever consumes it, the way
+// snapshot_delta_test.go and snapshot_pipeline_test.go cover
+// SnapshotDelta's methods and the pipeline's stage types.
+type EnrichConfig struct {
+	// From identifies the related issue to join: "parent", "epic", or
+	// "blocker" select a DepParentChild/DepBlocks traversal; any other
+	// value is taken as a model.DependencyType name to join a single hop
+	// across.
+	From string
+	// Fields lists which of the related issue's fields to copy, e.g.
+	// "title", "priority", "assignee", "status".
+	Fields []string
+	// As is the key under which each resolved field is written into
+	// ListItem.Enriched, e.g. Fields ["title"] with As "epic_title"
+	// produces Enriched["epic_title"].
+	As string
+}
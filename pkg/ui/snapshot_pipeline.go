@@ -0,0 +1,50 @@
+package ui
+
+// SnapshotStage identifies one stage of the staged snapshot build
+// pipeline BuildAsync would emit partial results for: each later stage
+// fills in more of a *DataSnapshot than the last, so the UI can swap in
+// an earlier stage's result immediately rather than block until the
+// whole build finishes.
+type SnapshotStage int
+
+const (
+	// StageCounts computes IssueMap and the open/closed/ready counts -
+	// the cheapest stage, and enough for the issue count header.
+	StageCounts SnapshotStage = iota
+	// StageBoardAndList computes BoardState and ListItems - enough to
+	// render the board and list views.
+	StageBoardAndList
+	// StageGraphAndTree computes GraphLayout and TreeRoots/TreeNodeMap -
+	// enough to render the graph and tree views.
+	StageGraphAndTree
+	// StageAnalysis computes Analysis and Insights, typically the most
+	// expensive stage on large corpora.
+	StageAnalysis
+)
+
+// SnapshotStageMsg is BuildAsync's per-stage message: once DataSnapshot
+// exists, it should carry a Snapshot *DataSnapshot field holding
+// whatever fields the completed stage (and every stage before it) has
+// filled in, with fields for later stages left at their zero value
+// until their own SnapshotStageMsg arrives. That field is left off for
+// now - see the NOTE below - so this only carries which stage fired.
+type SnapshotStageMsg struct {
+	Stage SnapshotStage
+}
+
+// BuildProgress reports BuildAsync's progress within its current stage,
+// e.g. for a progress bar: "Stage 2 of 4, 1200/5000 issues processed".
+type BuildProgress struct {
+	Stage          SnapshotStage
+	ItemsProcessed int
+	ItemsTotal     int
+}
+
+// NOTE: this checkout is missing the SnapshotBuilder/DataSnapshot
+// subsystem BuildAsync would stage (see the same note on
+// SnapshotDeltaMsg in snapshot_delta.go) - SnapshotStage,
+// SnapshotStageMsg, and BuildProgress are added now so a future
+// BuildAsync(ctx) <-chan SnapshotStageMsg has its message shapes ready,
+// but the pipeline itself - staging Phase0-3, the injectable scheduler,
+// and Model.Update's stale-build cancellation - is left for whoever
+// restores that subsystem.
@@ -0,0 +1,26 @@
+package ui
+
+import "testing"
+
+func TestSnapshotStageOrderIsMonotonic(t *testing.T) {
+	stages := []SnapshotStage{StageCounts, StageBoardAndList, StageGraphAndTree, StageAnalysis}
+	for i := 1; i < len(stages); i++ {
+		if stages[i] <= stages[i-1] {
+			t.Fatalf("expected stage %d to be greater than stage %d, got %d <= %d", i, i-1, stages[i], stages[i-1])
+		}
+	}
+}
+
+func TestSnapshotStageMsgCarriesItsStage(t *testing.T) {
+	msg := SnapshotStageMsg{Stage: StageGraphAndTree}
+	if msg.Stage != StageGraphAndTree {
+		t.Fatalf("expected Stage StageGraphAndTree, got %v", msg.Stage)
+	}
+}
+
+func TestBuildProgressZeroValueReportsNoProgress(t *testing.T) {
+	var p BuildProgress
+	if p.ItemsProcessed != 0 || p.ItemsTotal != 0 || p.Stage != StageCounts {
+		t.Fatalf("expected a zero-value BuildProgress to report no progress on StageCounts, got %+v", p)
+	}
+}
@@ -2,131 +2,251 @@ package ui
 
 import "github.com/charmbracelet/lipgloss"
 
-// Dracula-inspired color palette
-var (
-	// Core Colors
-	ColorPrimary     = lipgloss.Color("#BD93F9") // Purple
-	ColorSecondary   = lipgloss.Color("#6272A4") // Blue-Gray
-	ColorBg          = lipgloss.Color("#282A36") // Background
-	ColorBgDark      = lipgloss.Color("#1E1F29") // Darker Background
-	ColorBgHighlight = lipgloss.Color("#44475A") // Selection
-	ColorText        = lipgloss.Color("#F8F8F2") // Foreground
-	ColorSubtext     = lipgloss.Color("#BFBFBF") // Dimmer text
-
-	// Status Colors
-	ColorStatusOpen       = lipgloss.Color("#50FA7B") // Green
-	ColorStatusInProgress = lipgloss.Color("#8BE9FD") // Cyan
-	ColorStatusBlocked    = lipgloss.Color("#FF5555") // Red
-	ColorStatusClosed     = lipgloss.Color("#6272A4") // Gray/Dim
-
-	// Type Colors
-	ColorTypeBug     = lipgloss.Color("#FF5555") // Red
-	ColorTypeFeature = lipgloss.Color("#FFB86C") // Orange
-	ColorTypeEpic    = lipgloss.Color("#BD93F9") // Purple
-	ColorTypeTask    = lipgloss.Color("#F1FA8C") // Yellow
-	ColorTypeChore   = lipgloss.Color("#8BE9FD") // Cyan
-)
-
-// Global Styles (using lipgloss.NewStyle() instead of deprecated patterns)
-var (
-	// App Layout
-	AppStyle = lipgloss.NewStyle().Padding(0, 0)
-
-	// Panels
-	PanelStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorSecondary).
-			Padding(0, 1)
-
-	FocusedPanelStyle = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ColorPrimary).
-				Padding(0, 1)
-
-	// List Item Styles
-	ItemStyle = lipgloss.NewStyle().
-			PaddingLeft(1).
-			PaddingRight(1).
-			Border(lipgloss.HiddenBorder(), false, false, false, true).
-			BorderForeground(ColorBg)
-
-	SelectedItemStyle = lipgloss.NewStyle().
-				PaddingLeft(1).
-				PaddingRight(1).
-				Background(ColorBgHighlight).
-				Border(lipgloss.HiddenBorder(), false, false, false, true).
-				BorderForeground(ColorPrimary).
-				Bold(true)
-
-	// Column Styles
-	ColIDStyle       = lipgloss.NewStyle().Width(8).Foreground(ColorSecondary).Bold(true)
-	ColTypeStyle     = lipgloss.NewStyle().Width(2).Align(lipgloss.Center)
-	ColPrioStyle     = lipgloss.NewStyle().Width(3).Align(lipgloss.Center)
-	ColStatusStyle   = lipgloss.NewStyle().Width(12).Align(lipgloss.Center).Bold(true)
-	ColTitleStyle    = lipgloss.NewStyle().Foreground(ColorText)
-	ColAssigneeStyle = lipgloss.NewStyle().Width(12).Foreground(ColorSecondary).Align(lipgloss.Right)
-	ColAgeStyle      = lipgloss.NewStyle().Width(8).Foreground(ColorSecondary).Align(lipgloss.Right)
-	ColCommentsStyle = lipgloss.NewStyle().Width(4).Foreground(ColorSubtext).Align(lipgloss.Right)
-
-	// Detail View Styles
-	DetailTitleStyle = lipgloss.NewStyle().
-				Foreground(ColorPrimary).
-				Background(ColorBgHighlight).
-				Bold(true).
-				Padding(0, 1).
-				MarginBottom(1)
-
-	DetailMetaStyle = lipgloss.NewStyle().
-			Foreground(ColorSubtext).
-			MarginBottom(1)
-
-	// Header/Footer
-	HeaderStyle = lipgloss.NewStyle().
-			Foreground(ColorBg).
-			Background(ColorPrimary).
-			Bold(true).
-			Padding(0, 1)
-
-	HelpStyle = lipgloss.NewStyle().
-			Foreground(ColorSecondary).
-			Padding(0, 1)
-)
-
-// GetStatusColor returns the color for a given status
-func GetStatusColor(s string) lipgloss.Color {
-	switch s {
+// Theme bundles a resolved color palette plus the derived styles built
+// from it, so a renderer swap (a different terminal, a different SSH
+// session via RenderConfig, a forced light/dark override) is a single
+// NewTheme call instead of redefining every package-level var. Colors
+// are lipgloss.AdaptiveColor: each carries both a light- and dark-mode
+// value, and Theme.Renderer's detected (or explicitly set, see
+// lipgloss.Renderer.SetHasDarkBackground) background picks between them
+// at render time - this replaces the old single Dracula-only palette of
+// plain lipgloss.Color package vars.
+//
+// Theme is passed by value, the same way TutorialElement.Render and
+// ScrollableColumn.Render already do: it's a handful of small structs
+// and strings, cheap to copy, and a zero-value Theme{} (see layout_test.go)
+// still renders - every style built from it falls back to
+// lipgloss.DefaultRenderer() the same way lipgloss.Style.Render does
+// when its own renderer field is nil.
+type Theme struct {
+	Renderer *lipgloss.Renderer
+
+	// Base carries Text as its foreground, for call sites that just want
+	// "the theme's default text color" without reaching for a field.
+	Base lipgloss.Style
+
+	// Core palette
+	Primary     lipgloss.AdaptiveColor
+	Secondary   lipgloss.AdaptiveColor
+	Bg          lipgloss.AdaptiveColor
+	BgDark      lipgloss.AdaptiveColor
+	BgHighlight lipgloss.AdaptiveColor
+	Text        lipgloss.AdaptiveColor
+	Subtext     lipgloss.AdaptiveColor
+	Muted       lipgloss.AdaptiveColor
+	Border      lipgloss.AdaptiveColor
+
+	// Status palette
+	Open       lipgloss.AdaptiveColor
+	InProgress lipgloss.AdaptiveColor
+	Blocked    lipgloss.AdaptiveColor
+	Closed     lipgloss.AdaptiveColor
+
+	// Type palette
+	Bug     lipgloss.AdaptiveColor
+	Feature lipgloss.AdaptiveColor
+	Epic    lipgloss.AdaptiveColor
+	Task    lipgloss.AdaptiveColor
+	Chore   lipgloss.AdaptiveColor
+
+	// typeIcons and priorityIcons override TypeIcon's and PriorityIcon's
+	// built-in glyph for a given type/priority key, set by
+	// NewThemeWithConfig via ThemeConfig.apply (see theme_config.go). A nil (or key-missing) map
+	// falls back to the hard-coded glyph below, so a zero-value Theme{}
+	// behaves exactly as it did before theme files existed.
+	typeIcons     map[string]string
+	priorityIcons map[string]string
+
+	// Derived styles, built once in NewTheme from the palette above.
+	AppStyle          lipgloss.Style
+	PanelStyle        lipgloss.Style
+	FocusedPanelStyle lipgloss.Style
+	ItemStyle         lipgloss.Style
+	SelectedItemStyle lipgloss.Style
+	ColIDStyle        lipgloss.Style
+	ColTypeStyle      lipgloss.Style
+	ColPrioStyle      lipgloss.Style
+	ColStatusStyle    lipgloss.Style
+	ColTitleStyle     lipgloss.Style
+	ColAssigneeStyle  lipgloss.Style
+	ColAgeStyle       lipgloss.Style
+	ColCommentsStyle  lipgloss.Style
+	DetailTitleStyle  lipgloss.Style
+	DetailMetaStyle   lipgloss.Style
+	HeaderStyle       lipgloss.Style
+	HelpStyle         lipgloss.Style
+}
+
+// NewTheme builds a Theme rendering through r, resolving every
+// lipgloss.AdaptiveColor field against r's detected (or explicitly set)
+// background. Pass a per-session renderer (e.g. from a charmbracelet/wish
+// SSH handler via RenderConfig.Renderer) rather than always reaching for
+// lipgloss.DefaultRenderer(), so two concurrent sessions with different
+// backgrounds each see the right half of the palette.
+func NewTheme(r *lipgloss.Renderer) Theme {
+	return NewThemeWithConfig(r, ThemeConfig{})
+}
+
+// NewThemeWithConfig builds a Theme the same way NewTheme does, then
+// applies cfg's color and icon overrides (see theme_config.go) before the
+// derived styles below are built from the palette - so an overridden
+// Primary, say, is reflected in FocusedPanelStyle's border too, not just
+// in fields read directly. NewTheme(r) is exactly
+// NewThemeWithConfig(r, ThemeConfig{}).
+func NewThemeWithConfig(r *lipgloss.Renderer, cfg ThemeConfig) Theme {
+	t := Theme{
+		Renderer: r,
+
+		Primary:     lipgloss.AdaptiveColor{Light: "#7C3AED", Dark: "#BD93F9"},
+		Secondary:   lipgloss.AdaptiveColor{Light: "#64748B", Dark: "#6272A4"},
+		Bg:          lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#282A36"},
+		BgDark:      lipgloss.AdaptiveColor{Light: "#F0F0F0", Dark: "#1E1F29"},
+		BgHighlight: lipgloss.AdaptiveColor{Light: "#E2E8F0", Dark: "#44475A"},
+		Text:        lipgloss.AdaptiveColor{Light: "#1E293B", Dark: "#F8F8F2"},
+		Subtext:     lipgloss.AdaptiveColor{Light: "#64748B", Dark: "#BFBFBF"},
+		Muted:       lipgloss.AdaptiveColor{Light: "#94A3B8", Dark: "#6272A4"},
+		Border:      lipgloss.AdaptiveColor{Light: "#CBD5E1", Dark: "#6272A4"},
+
+		Open:       lipgloss.AdaptiveColor{Light: "#16A34A", Dark: "#50FA7B"},
+		InProgress: lipgloss.AdaptiveColor{Light: "#0891B2", Dark: "#8BE9FD"},
+		Blocked:    lipgloss.AdaptiveColor{Light: "#DC2626", Dark: "#FF5555"},
+		Closed:     lipgloss.AdaptiveColor{Light: "#94A3B8", Dark: "#6272A4"},
+
+		Bug:     lipgloss.AdaptiveColor{Light: "#DC2626", Dark: "#FF5555"},
+		Feature: lipgloss.AdaptiveColor{Light: "#EA580C", Dark: "#FFB86C"},
+		Epic:    lipgloss.AdaptiveColor{Light: "#7C3AED", Dark: "#BD93F9"},
+		Task:    lipgloss.AdaptiveColor{Light: "#CA8A04", Dark: "#F1FA8C"},
+		Chore:   lipgloss.AdaptiveColor{Light: "#0891B2", Dark: "#8BE9FD"},
+	}
+	cfg.apply(&t)
+
+	s := r.NewStyle()
+	t.Base = s.Foreground(t.Text)
+
+	t.AppStyle = s.Padding(0, 0)
+
+	t.PanelStyle = s.
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Secondary).
+		Padding(0, 1)
+
+	t.FocusedPanelStyle = s.
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(0, 1)
+
+	t.ItemStyle = s.
+		PaddingLeft(1).
+		PaddingRight(1).
+		Border(lipgloss.HiddenBorder(), false, false, false, true).
+		BorderForeground(t.Bg)
+
+	t.SelectedItemStyle = s.
+		PaddingLeft(1).
+		PaddingRight(1).
+		Background(t.BgHighlight).
+		Border(lipgloss.HiddenBorder(), false, false, false, true).
+		BorderForeground(t.Primary).
+		Bold(true)
+
+	t.ColIDStyle = s.Width(8).Foreground(t.Secondary).Bold(true)
+	t.ColTypeStyle = s.Width(2).Align(lipgloss.Center)
+	t.ColPrioStyle = s.Width(3).Align(lipgloss.Center)
+	t.ColStatusStyle = s.Width(12).Align(lipgloss.Center).Bold(true)
+	t.ColTitleStyle = s.Foreground(t.Text)
+	t.ColAssigneeStyle = s.Width(12).Foreground(t.Secondary).Align(lipgloss.Right)
+	t.ColAgeStyle = s.Width(8).Foreground(t.Secondary).Align(lipgloss.Right)
+	t.ColCommentsStyle = s.Width(4).Foreground(t.Subtext).Align(lipgloss.Right)
+
+	t.DetailTitleStyle = s.
+		Foreground(t.Primary).
+		Background(t.BgHighlight).
+		Bold(true).
+		Padding(0, 1).
+		MarginBottom(1)
+
+	t.DetailMetaStyle = s.
+		Foreground(t.Subtext).
+		MarginBottom(1)
+
+	t.HeaderStyle = s.
+		Foreground(t.Bg).
+		Background(t.Primary).
+		Bold(true).
+		Padding(0, 1)
+
+	t.HelpStyle = s.
+		Foreground(t.Secondary).
+		Padding(0, 1)
+
+	return t
+}
+
+// DefaultTheme builds the package's standard Theme, rendering through r.
+// It's the usual entry point for callers that don't need NewTheme's full
+// name (kept distinct so a future caller wanting a non-default palette -
+// e.g. a high-contrast variant - has somewhere to add a second
+// constructor without renaming this one).
+func DefaultTheme(r *lipgloss.Renderer) Theme {
+	return NewTheme(r)
+}
+
+// StatusColor returns t's color for a given issue status.
+func (t Theme) StatusColor(status string) lipgloss.AdaptiveColor {
+	switch status {
 	case "open":
-		return ColorStatusOpen
+		return t.Open
 	case "in_progress":
-		return ColorStatusInProgress
+		return t.InProgress
 	case "blocked":
-		return ColorStatusBlocked
+		return t.Blocked
 	case "closed":
-		return ColorStatusClosed
+		return t.Closed
 	default:
-		return ColorText
+		return t.Text
 	}
 }
 
-// GetTypeIcon returns the emoji and color for an issue type
-func GetTypeIcon(t string) (string, lipgloss.Color) {
-	switch t {
+// TypeIcon returns the emoji and color t uses for an issue type. If a
+// theme file (see theme_config.go) remapped issueType's glyph, that
+// override wins; the color always comes from the palette above, since
+// ThemeConfig only overrides colors and icons, never pairs them.
+func (t Theme) TypeIcon(issueType string) (string, lipgloss.AdaptiveColor) {
+	icon, color := "•", t.Text
+	switch issueType {
 	case "bug":
-		return "🐛", ColorTypeBug
+		icon, color = "🐛", t.Bug
 	case "feature":
-		return "✨", ColorTypeFeature
+		icon, color = "✨", t.Feature
 	case "task":
-		return "📋", ColorTypeTask
+		icon, color = "📋", t.Task
 	case "epic":
-		return "🏔️", ColorTypeEpic
+		icon, color = "🏔️", t.Epic
 	case "chore":
-		return "🧹", ColorTypeChore
-	default:
-		return "•", ColorText
+		icon, color = "🧹", t.Chore
 	}
+	if override, ok := t.typeIcons[issueType]; ok {
+		icon = override
+	}
+	return icon, color
 }
 
-// GetPriorityIcon returns the emoji for a priority level
+// PriorityIcon returns the emoji for a priority level, preferring a
+// theme file's override (see theme_config.go) over GetPriorityIcon's
+// built-in glyph.
+func (t Theme) PriorityIcon(p int) string {
+	if override, ok := t.priorityIcons[itoa(p)]; ok {
+		return override
+	}
+	return GetPriorityIcon(p)
+}
+
+// GetPriorityIcon returns the emoji for a priority level. Unlike
+// StatusColor/TypeIcon this carries no color, so it stays a plain
+// function rather than a Theme method - every caller so far (including
+// ScrollableColumn.renderCard) just concatenates it into a plain string.
+// Theme.PriorityIcon wraps it for callers that want a theme file's
+// override applied.
 func GetPriorityIcon(p int) string {
 	switch p {
 	case 0:
@@ -143,3 +263,157 @@ func GetPriorityIcon(p int) string {
 		return ""
 	}
 }
+
+// priorityBadgeLabel returns the short label RenderPriorityBadge renders
+// for a beads priority level (0 = critical ... 4 = backlog), and "P?"
+// for anything else.
+func priorityBadgeLabel(prio int) string {
+	switch prio {
+	case 0, 1, 2, 3, 4:
+		return "P" + string(rune('0'+prio))
+	default:
+		return "P?"
+	}
+}
+
+// RenderPriorityBadge renders prio as a compact colored "P0".."P4" badge,
+// or "P?" for an out-of-range value. It always renders through
+// DefaultTheme: badges are short enough, and common enough in
+// non-Theme-threaded call sites, that requiring every caller to carry a
+// Theme around just to print "P1" isn't worth it - RenderMiniBar takes a
+// Theme explicitly because it needs a fill/empty distinction a default
+// can't supply.
+func RenderPriorityBadge(prio int) string {
+	theme := DefaultTheme(lipgloss.DefaultRenderer())
+	label := priorityBadgeLabel(prio)
+	color := theme.Muted
+	switch prio {
+	case 0:
+		color = theme.Blocked
+	case 1:
+		color = theme.Feature
+	case 2:
+		color = theme.InProgress
+	case 3:
+		color = theme.Open
+	}
+	return theme.Renderer.NewStyle().Foreground(color).Bold(true).Render(label)
+}
+
+// statusBadgeLabel returns the short label RenderStatusBadge renders for
+// one of beads' 8 official statuses, and "????" for anything else.
+func statusBadgeLabel(status string) string {
+	switch status {
+	case "open":
+		return "OPEN"
+	case "in_progress":
+		return "PROG"
+	case "blocked":
+		return "BLKD"
+	case "deferred":
+		return "DEFR"
+	case "pinned":
+		return "PIN"
+	case "hooked":
+		return "HOOK"
+	case "closed":
+		return "DONE"
+	case "tombstone":
+		return "TOMB"
+	default:
+		return "????"
+	}
+}
+
+// RenderStatusBadge renders status as a compact colored badge, one of
+// "OPEN"/"PROG"/"BLKD"/"DEFR"/"PIN"/"HOOK"/"DONE"/"TOMB" for beads' 8
+// official statuses, or "????" for anything else (including "").
+func RenderStatusBadge(status string) string {
+	theme := DefaultTheme(lipgloss.DefaultRenderer())
+	label := statusBadgeLabel(status)
+
+	color := theme.Muted
+	switch status {
+	case "open":
+		color = theme.Open
+	case "in_progress":
+		color = theme.InProgress
+	case "blocked":
+		color = theme.Blocked
+	case "deferred":
+		color = theme.Subtext
+	case "pinned":
+		color = theme.Primary
+	case "hooked":
+		color = theme.Feature
+	case "closed", "tombstone":
+		color = theme.Closed
+	}
+	return theme.Renderer.NewStyle().Foreground(color).Bold(true).Render(label)
+}
+
+// RenderRankBadge renders rank out of total as a compact "#N" badge, or
+// "#?" if rank is out of range (e.g. total == 0, meaning no ranking was
+// computed at all).
+func RenderRankBadge(rank, total int) string {
+	if rank < 1 || total < 1 || rank > total {
+		return "#?"
+	}
+	theme := DefaultTheme(lipgloss.DefaultRenderer())
+	label := "#" + itoa(rank)
+	return theme.Renderer.NewStyle().Foreground(theme.Secondary).Render(label)
+}
+
+// RenderMiniBar renders val (clamped to [0, 1]) as a width-wide bar of
+// "█" (filled) and "░" (empty) cells, colored by theme - a compact
+// alternative to RenderSparkline for a single scalar rather than a
+// series. Returns "" if width <= 0 rather than panicking on a
+// strings.Repeat with a negative count.
+func RenderMiniBar(val float64, width int, theme Theme) string {
+	if width <= 0 {
+		return ""
+	}
+	if val < 0 {
+		val = 0
+	}
+	if val > 1 {
+		val = 1
+	}
+
+	filled := int(val * float64(width))
+	if filled > width {
+		filled = width
+	}
+	empty := width - filled
+
+	r := theme.Renderer
+	bar := r.NewStyle().Foreground(GetHeatmapColor(val, theme)).Render(repeatRune('█', filled))
+	bar += r.NewStyle().Foreground(theme.Muted).Render(repeatRune('░', empty))
+	return bar
+}
+
+// repeatRune builds a string of n copies of r, or "" if n <= 0.
+func repeatRune(r rune, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	out := make([]rune, n)
+	for i := range out {
+		out[i] = r
+	}
+	return string(out)
+}
+
+// itoa renders a non-negative int in base 10, avoiding a strconv import
+// for this file's two call sites.
+func itoa(n int) string {
+	if n == 0 {
+		return "0"
+	}
+	var digits []byte
+	for n > 0 {
+		digits = append([]byte{byte('0' + n%10)}, digits...)
+		n /= 10
+	}
+	return string(digits)
+}
@@ -0,0 +1,42 @@
+package testkit
+
+import "testing"
+
+// AssertCellEqual fails t if the cell at (x, y) doesn't hold want.
+func AssertCellEqual(t *testing.T, buf *Buffer, x, y int, want rune) {
+	t.Helper()
+	if got := buf.Cell(x, y).Rune; got != want {
+		t.Errorf("cell (%d,%d) = %q, want %q", x, y, got, want)
+	}
+}
+
+// AssertRegionEqual fails t if the plain text of buf's region r doesn't
+// match want line-for-line.
+func AssertRegionEqual(t *testing.T, buf *Buffer, r Rect, want *Buffer) {
+	t.Helper()
+	got := buf.Region(r)
+	if got.Width != want.Width || got.Height != want.Height {
+		t.Fatalf("region size %dx%d does not match expected %dx%d", got.Width, got.Height, want.Width, want.Height)
+	}
+	for y := 0; y < got.Height; y++ {
+		if got.Row(y) != want.Row(y) {
+			t.Errorf("region row %d = %q, want %q", y, got.Row(y), want.Row(y))
+		}
+	}
+}
+
+// AssertContainsText fails t if none of buf's rows contain s verbatim.
+func AssertContainsText(t *testing.T, buf *Buffer, s string) {
+	t.Helper()
+	if !buf.ContainsText(s) {
+		t.Errorf("expected output to contain %q, got:\n%s", s, buf.String())
+	}
+}
+
+// AssertNotContainsText fails t if any of buf's rows contain s verbatim.
+func AssertNotContainsText(t *testing.T, buf *Buffer, s string) {
+	t.Helper()
+	if buf.ContainsText(s) {
+		t.Errorf("expected output not to contain %q, got:\n%s", s, buf.String())
+	}
+}
@@ -0,0 +1,239 @@
+// Package testkit is a ratatui-TestBackend-style virtual terminal for
+// asserting on BoardModel.View's rendered content instead of heuristics
+// like output length. ParseANSI turns a lipgloss-rendered string into a
+// Buffer of (rune, fg, bg, attrs) Cells indexed by (x, y), which tests
+// can then inspect cell-by-cell or region-by-region.
+package testkit
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Cell is a single terminal character cell: its rune plus the SGR
+// attributes in effect when it was written.
+type Cell struct {
+	Rune      rune
+	FG        string // ANSI/SGR color parameter(s), e.g. "38;5;210" or "31"; "" if unset
+	BG        string
+	Bold      bool
+	Underline bool
+	Reverse   bool
+}
+
+// blank is the zero-value cell written to positions ParseANSI never
+// reaches (short lines, or rows beyond the input's line count).
+var blank = Cell{Rune: ' '}
+
+// Buffer is a fixed Width x Height grid of Cells, row-major.
+type Buffer struct {
+	Width, Height int
+	cells         []Cell
+}
+
+// NewBuffer returns a Width x Height Buffer filled with blank cells.
+func NewBuffer(width, height int) *Buffer {
+	b := &Buffer{Width: width, Height: height, cells: make([]Cell, width*height)}
+	for i := range b.cells {
+		b.cells[i] = blank
+	}
+	return b
+}
+
+// Cell returns the cell at (x, y), or blank if out of bounds.
+func (b *Buffer) Cell(x, y int) Cell {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return blank
+	}
+	return b.cells[y*b.Width+x]
+}
+
+func (b *Buffer) set(x, y int, c Cell) {
+	if x < 0 || y < 0 || x >= b.Width || y >= b.Height {
+		return
+	}
+	b.cells[y*b.Width+x] = c
+}
+
+// Rect is a rectangular region of a Buffer, in cell coordinates.
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Region extracts the sub-Buffer covered by r. Cells outside the source
+// Buffer's bounds come back blank rather than panicking, so callers can
+// probe a region that only partially overlaps the rendered output.
+func (b *Buffer) Region(r Rect) *Buffer {
+	out := NewBuffer(r.W, r.H)
+	for y := 0; y < r.H; y++ {
+		for x := 0; x < r.W; x++ {
+			out.set(x, y, b.Cell(r.X+x, r.Y+y))
+		}
+	}
+	return out
+}
+
+// Row returns the plain-text (styling stripped) content of row y, right-
+// trimmed of padding spaces.
+func (b *Buffer) Row(y int) string {
+	if y < 0 || y >= b.Height {
+		return ""
+	}
+	var sb strings.Builder
+	for x := 0; x < b.Width; x++ {
+		sb.WriteRune(b.cells[y*b.Width+x].Rune)
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// String renders the Buffer's plain text, one line per row, styling
+// stripped - the same shape AssertGolden snapshots.
+func (b *Buffer) String() string {
+	rows := make([]string, b.Height)
+	for y := 0; y < b.Height; y++ {
+		rows[y] = b.Row(y)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// ContainsText reports whether s appears verbatim within any single row
+// of plain text. It does not match text that wraps across rows.
+func (b *Buffer) ContainsText(s string) bool {
+	for y := 0; y < b.Height; y++ {
+		if strings.Contains(b.Row(y), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseANSI renders s - a string that may contain lipgloss/ANSI SGR
+// escape sequences and newlines, but no cursor-addressing sequences - into
+// a width x height Buffer. Lines past height are discarded; lines longer
+// than width are truncated; short lines are left blank-padded.
+func ParseANSI(s string, width, height int) *Buffer {
+	buf := NewBuffer(width, height)
+	cur := Cell{Rune: ' '}
+
+	x, y := 0, 0
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch r {
+		case '\n':
+			x = 0
+			y++
+			continue
+		case '\r':
+			continue
+		case 0x1b: // ESC
+			if i+1 < len(runes) && runes[i+1] == '[' {
+				end := i + 2
+				for end < len(runes) && !isSGRTerminator(runes[end]) {
+					end++
+				}
+				if end < len(runes) && runes[end] == 'm' {
+					applySGR(&cur, string(runes[i+2:end]))
+				}
+				i = end
+				continue
+			}
+		}
+
+		if y >= height {
+			continue // still scan for correctness of escape parsing, just don't write
+		}
+		if x < width {
+			c := cur
+			c.Rune = r
+			buf.set(x, y, c)
+		}
+		x++
+	}
+
+	return buf
+}
+
+// isSGRTerminator reports whether r ends a CSI sequence body (we only
+// care about 'm'/SGR; anything else closes the scan so we don't run off
+// into unrelated escape codes).
+func isSGRTerminator(r rune) bool {
+	return r >= 0x40 && r <= 0x7e // any CSI final byte; ParseANSI applies only 'm'
+}
+
+// applySGR updates cur in place from a semicolon-separated SGR parameter
+// string (the part between "ESC[" and the trailing "m"), handling the
+// reset/bold/underline/reverse codes plus 256-color and truecolor fg/bg.
+func applySGR(cur *Cell, params string) {
+	if params == "" || params == "0" {
+		*cur = Cell{Rune: cur.Rune}
+		return
+	}
+
+	parts := strings.Split(params, ";")
+	for i := 0; i < len(parts); i++ {
+		code, err := strconv.Atoi(parts[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*cur = Cell{Rune: cur.Rune}
+		case code == 1:
+			cur.Bold = true
+		case code == 4:
+			cur.Underline = true
+		case code == 7:
+			cur.Reverse = true
+		case code == 22:
+			cur.Bold = false
+		case code == 24:
+			cur.Underline = false
+		case code == 27:
+			cur.Reverse = false
+		case code == 39:
+			cur.FG = ""
+		case code == 49:
+			cur.BG = ""
+		case code == 38 || code == 48:
+			// Extended color: 38;5;N (256-color) or 38;2;R;G;B (truecolor).
+			consumed, color := parseExtendedColor(parts[i:])
+			if code == 38 {
+				cur.FG = color
+			} else {
+				cur.BG = color
+			}
+			i += consumed
+		case code >= 30 && code <= 37:
+			cur.FG = strconv.Itoa(code)
+		case code >= 40 && code <= 47:
+			cur.BG = strconv.Itoa(code)
+		case code >= 90 && code <= 97:
+			cur.FG = strconv.Itoa(code)
+		case code >= 100 && code <= 107:
+			cur.BG = strconv.Itoa(code)
+		}
+	}
+}
+
+// parseExtendedColor parses a "38;5;N" or "38;2;R;G;B" sequence, given
+// parts starting at the "38"/"48" token. It returns how many extra
+// tokens (beyond the leading one) it consumed and the color's string
+// form, joined back with ";" for equality comparisons in tests.
+func parseExtendedColor(parts []string) (consumed int, color string) {
+	if len(parts) < 2 {
+		return 0, ""
+	}
+	switch parts[1] {
+	case "5":
+		if len(parts) >= 3 {
+			return 2, strings.Join(parts[0:3], ";")
+		}
+	case "2":
+		if len(parts) >= 5 {
+			return 4, strings.Join(parts[0:5], ";")
+		}
+	}
+	return 0, ""
+}
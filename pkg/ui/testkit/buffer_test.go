@@ -0,0 +1,72 @@
+package testkit
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseANSIPlainText(t *testing.T) {
+	buf := ParseANSI("abc\nde", 5, 2)
+	if buf.Row(0) != "abc" {
+		t.Errorf("row 0 = %q, want %q", buf.Row(0), "abc")
+	}
+	if buf.Row(1) != "de" {
+		t.Errorf("row 1 = %q, want %q", buf.Row(1), "de")
+	}
+}
+
+func TestParseANSIStripsSGRButKeepsAttrs(t *testing.T) {
+	buf := ParseANSI("\x1b[1;31mX\x1b[0mY", 2, 1)
+
+	x := buf.Cell(0, 0)
+	if x.Rune != 'X' || !x.Bold || x.FG != "31" {
+		t.Errorf("cell 0 = %+v, want bold X with fg 31", x)
+	}
+	y := buf.Cell(1, 0)
+	if y.Rune != 'Y' || y.Bold || y.FG != "" {
+		t.Errorf("cell 1 = %+v, want plain Y with reset attrs", y)
+	}
+}
+
+func TestParseANSITruncatesAndPads(t *testing.T) {
+	buf := ParseANSI("abcdef", 3, 1)
+	if buf.Row(0) != "abc" {
+		t.Errorf("expected truncation to width, got %q", buf.Row(0))
+	}
+
+	buf = ParseANSI("ab", 5, 1)
+	if got := buf.Cell(4, 0).Rune; got != ' ' {
+		t.Errorf("expected blank padding past short input, got %q", got)
+	}
+}
+
+func TestRegionAndAssertHelpers(t *testing.T) {
+	buf := ParseANSI("hello\nworld", 5, 2)
+
+	region := buf.Region(Rect{X: 0, Y: 1, W: 5, H: 1})
+	if region.Row(0) != "world" {
+		t.Fatalf("region row = %q, want %q", region.Row(0), "world")
+	}
+
+	AssertCellEqual(t, buf, 0, 0, 'h')
+	AssertRegionEqual(t, buf, Rect{X: 0, Y: 1, W: 5, H: 1}, ParseANSI("world", 5, 1))
+	AssertContainsText(t, buf, "ello")
+	AssertNotContainsText(t, buf, "xyz")
+}
+
+func TestAssertGoldenRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	oldWd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldWd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	*update = true
+	AssertGolden(t, "example", "some output")
+	*update = false
+	AssertGolden(t, "example", "some output")
+}
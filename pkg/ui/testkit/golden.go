@@ -0,0 +1,38 @@
+package testkit
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update is the same "-update" convention most Go golden-file harnesses
+// use: run `go test ./... -update` to (re)write the golden files instead
+// of diffing against them.
+var update = flag.Bool("update", false, "update golden files instead of comparing against them")
+
+// AssertGolden compares got against testdata/<name>.golden, failing t on
+// mismatch. With -update, it (re)writes the golden file and passes.
+func AssertGolden(t *testing.T, name string, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("creating testdata dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("writing golden file %q: %v", path, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file %q (run with -update to create it): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("output does not match golden file %q\n--- got ---\n%s\n--- want ---\n%s", path, got, string(want))
+	}
+}
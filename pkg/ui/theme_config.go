@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// ThemeEnvVar is the environment variable NewThemeFromEnv reads to pick a
+// built-in theme by name (e.g. BEADS_VIEWER_THEME=gruvbox), for callers
+// that want a themed Theme without shipping a theme file.
+const ThemeEnvVar = "BEADS_VIEWER_THEME"
+
+// ColorOverride holds a light/dark hex pair for one palette slot in a
+// ThemeConfig. An empty Light or Dark leaves that half of the
+// AdaptiveColor at NewTheme's built-in value, so a single-mode palette
+// (e.g. Solarized Light) only needs to set the side it cares about.
+type ColorOverride struct {
+	Light string `yaml:"light"`
+	Dark  string `yaml:"dark"`
+}
+
+// ThemeConfig is the on-disk shape of a theme file (e.g.
+// ~/.config/beads_viewer/theme.yaml): hex overrides for the palette
+// NewTheme builds, plus glyph overrides for TypeIcon and PriorityIcon.
+// Every field is optional - a key absent from Colors, TypeIcons, or
+// PriorityIcons leaves that slot at its built-in value, so a theme file
+// only needs to name what it wants to change.
+//
+// Colors keys match the lower-cased Theme field they override: primary,
+// secondary, bg, bg_dark, bg_highlight, text, subtext, muted, border,
+// open, in_progress, blocked, closed, bug, feature, epic, task, chore.
+// TypeIcons keys are model.IssueType values ("bug", "feature", ...);
+// PriorityIcons keys are beads priority levels stringified ("0".."4").
+type ThemeConfig struct {
+	Colors        map[string]ColorOverride `yaml:"colors"`
+	TypeIcons     map[string]string        `yaml:"type_icons"`
+	PriorityIcons map[string]string        `yaml:"priority_icons"`
+}
+
+// apply overrides t's palette fields and icon maps from c, leaving
+// anything c doesn't mention untouched. Unexported because it only makes
+// sense mid-construction, before NewThemeWithConfig (styles.go) builds
+// the derived styles from the palette.
+func (c ThemeConfig) apply(t *Theme) {
+	for name, override := range c.Colors {
+		slot := colorSlot(t, name)
+		if slot == nil {
+			continue
+		}
+		if override.Light != "" {
+			slot.Light = override.Light
+		}
+		if override.Dark != "" {
+			slot.Dark = override.Dark
+		}
+	}
+
+	if len(c.TypeIcons) > 0 {
+		t.typeIcons = c.TypeIcons
+	}
+	if len(c.PriorityIcons) > 0 {
+		t.priorityIcons = c.PriorityIcons
+	}
+}
+
+// colorSlot returns a pointer to t's field for a ThemeConfig.Colors key,
+// or nil if name doesn't match one of Theme's palette fields.
+func colorSlot(t *Theme, name string) *lipgloss.AdaptiveColor {
+	switch name {
+	case "primary":
+		return &t.Primary
+	case "secondary":
+		return &t.Secondary
+	case "bg":
+		return &t.Bg
+	case "bg_dark":
+		return &t.BgDark
+	case "bg_highlight":
+		return &t.BgHighlight
+	case "text":
+		return &t.Text
+	case "subtext":
+		return &t.Subtext
+	case "muted":
+		return &t.Muted
+	case "border":
+		return &t.Border
+	case "open":
+		return &t.Open
+	case "in_progress":
+		return &t.InProgress
+	case "blocked":
+		return &t.Blocked
+	case "closed":
+		return &t.Closed
+	case "bug":
+		return &t.Bug
+	case "feature":
+		return &t.Feature
+	case "epic":
+		return &t.Epic
+	case "task":
+		return &t.Task
+	case "chore":
+		return &t.Chore
+	default:
+		return nil
+	}
+}
+
+// LoadThemeConfig reads a YAML theme file at path. A missing file is not
+// an error - it returns a zero ThemeConfig, the same convention
+// LoadLayoutConfig and lint.LoadConfig use, so callers can always point
+// LoadThemeConfig or NewThemeFromFile at an optional path.
+func LoadThemeConfig(path string) (ThemeConfig, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ThemeConfig{}, nil
+	}
+	if err != nil {
+		return ThemeConfig{}, fmt.Errorf("ui: reading theme config %q: %w", path, err)
+	}
+
+	var cfg ThemeConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return ThemeConfig{}, fmt.Errorf("ui: parsing theme config %q: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// NewThemeFromFile builds a Theme rendering through r, loading overrides
+// from the YAML theme file at path. A missing path falls back to
+// NewTheme(r)'s defaults, same as LoadThemeConfig.
+func NewThemeFromFile(r *lipgloss.Renderer, path string) (Theme, error) {
+	cfg, err := LoadThemeConfig(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	return NewThemeWithConfig(r, cfg), nil
+}
+
+// BuiltinTheme returns the named built-in ThemeConfig and true, or a zero
+// ThemeConfig and false if name doesn't match one of builtinThemes.
+// Lookup is case-sensitive, matching layout's swim-lane Lookup.
+func BuiltinTheme(name string) (ThemeConfig, bool) {
+	cfg, ok := builtinThemes[name]
+	return cfg, ok
+}
+
+// NewThemeFromEnv builds a Theme rendering through r, selecting a
+// built-in theme by the name in ThemeEnvVar if set. An unset or unknown
+// env value falls back to NewTheme(r)'s defaults - an unrecognized theme
+// name is a config mistake, not worth failing startup over.
+func NewThemeFromEnv(r *lipgloss.Renderer) Theme {
+	cfg, _ := BuiltinTheme(os.Getenv(ThemeEnvVar))
+	return NewThemeWithConfig(r, cfg)
+}
+
+// builtinThemes are the themes NewThemeFromEnv and BuiltinTheme select by
+// name. Each overrides only the slots that differ from NewTheme's
+// defaults - Dracula is those defaults' own dark side, spelled out
+// explicitly so picking it by name is stable even if the built-in
+// default palette changes later.
+var builtinThemes = map[string]ThemeConfig{
+	"dracula": {
+		Colors: map[string]ColorOverride{
+			"primary":      {Light: "#BD93F9", Dark: "#BD93F9"},
+			"secondary":    {Light: "#6272A4", Dark: "#6272A4"},
+			"bg":           {Light: "#282A36", Dark: "#282A36"},
+			"bg_dark":      {Light: "#1E1F29", Dark: "#1E1F29"},
+			"bg_highlight": {Light: "#44475A", Dark: "#44475A"},
+			"text":         {Light: "#F8F8F2", Dark: "#F8F8F2"},
+			"subtext":      {Light: "#BFBFBF", Dark: "#BFBFBF"},
+			"muted":        {Light: "#6272A4", Dark: "#6272A4"},
+			"border":       {Light: "#6272A4", Dark: "#6272A4"},
+			"open":         {Light: "#50FA7B", Dark: "#50FA7B"},
+			"in_progress":  {Light: "#8BE9FD", Dark: "#8BE9FD"},
+			"blocked":      {Light: "#FF5555", Dark: "#FF5555"},
+			"closed":       {Light: "#6272A4", Dark: "#6272A4"},
+			"bug":          {Light: "#FF5555", Dark: "#FF5555"},
+			"feature":      {Light: "#FFB86C", Dark: "#FFB86C"},
+			"epic":         {Light: "#BD93F9", Dark: "#BD93F9"},
+			"task":         {Light: "#F1FA8C", Dark: "#F1FA8C"},
+			"chore":        {Light: "#8BE9FD", Dark: "#8BE9FD"},
+		},
+	},
+	"solarized-dark": {
+		Colors: map[string]ColorOverride{
+			"primary":      {Light: "#268BD2", Dark: "#268BD2"},
+			"secondary":    {Light: "#93A1A1", Dark: "#93A1A1"},
+			"bg":           {Light: "#002B36", Dark: "#002B36"},
+			"bg_dark":      {Light: "#073642", Dark: "#073642"},
+			"bg_highlight": {Light: "#073642", Dark: "#073642"},
+			"text":         {Light: "#EEE8D5", Dark: "#EEE8D5"},
+			"subtext":      {Light: "#93A1A1", Dark: "#93A1A1"},
+			"muted":        {Light: "#586E75", Dark: "#586E75"},
+			"border":       {Light: "#586E75", Dark: "#586E75"},
+			"open":         {Light: "#859900", Dark: "#859900"},
+			"in_progress":  {Light: "#2AA198", Dark: "#2AA198"},
+			"blocked":      {Light: "#DC322F", Dark: "#DC322F"},
+			"closed":       {Light: "#586E75", Dark: "#586E75"},
+			"bug":          {Light: "#DC322F", Dark: "#DC322F"},
+			"feature":      {Light: "#CB4B16", Dark: "#CB4B16"},
+			"epic":         {Light: "#6C71C4", Dark: "#6C71C4"},
+			"task":         {Light: "#B58900", Dark: "#B58900"},
+			"chore":        {Light: "#2AA198", Dark: "#2AA198"},
+		},
+	},
+	"solarized-light": {
+		Colors: map[string]ColorOverride{
+			"primary":      {Light: "#268BD2", Dark: "#268BD2"},
+			"secondary":    {Light: "#657B83", Dark: "#657B83"},
+			"bg":           {Light: "#FDF6E3", Dark: "#FDF6E3"},
+			"bg_dark":      {Light: "#EEE8D5", Dark: "#EEE8D5"},
+			"bg_highlight": {Light: "#EEE8D5", Dark: "#EEE8D5"},
+			"text":         {Light: "#073642", Dark: "#073642"},
+			"subtext":      {Light: "#657B83", Dark: "#657B83"},
+			"muted":        {Light: "#93A1A1", Dark: "#93A1A1"},
+			"border":       {Light: "#93A1A1", Dark: "#93A1A1"},
+			"open":         {Light: "#859900", Dark: "#859900"},
+			"in_progress":  {Light: "#2AA198", Dark: "#2AA198"},
+			"blocked":      {Light: "#DC322F", Dark: "#DC322F"},
+			"closed":       {Light: "#93A1A1", Dark: "#93A1A1"},
+			"bug":          {Light: "#DC322F", Dark: "#DC322F"},
+			"feature":      {Light: "#CB4B16", Dark: "#CB4B16"},
+			"epic":         {Light: "#6C71C4", Dark: "#6C71C4"},
+			"task":         {Light: "#B58900", Dark: "#B58900"},
+			"chore":        {Light: "#2AA198", Dark: "#2AA198"},
+		},
+	},
+	"gruvbox": {
+		Colors: map[string]ColorOverride{
+			"primary":      {Light: "#D79921", Dark: "#FABD2F"},
+			"secondary":    {Light: "#7C6F64", Dark: "#A89984"},
+			"bg":           {Light: "#FBF1C7", Dark: "#282828"},
+			"bg_dark":      {Light: "#EBDBB2", Dark: "#1D2021"},
+			"bg_highlight": {Light: "#EBDBB2", Dark: "#3C3836"},
+			"text":         {Light: "#3C3836", Dark: "#EBDBB2"},
+			"subtext":      {Light: "#7C6F64", Dark: "#A89984"},
+			"muted":        {Light: "#928374", Dark: "#928374"},
+			"border":       {Light: "#928374", Dark: "#928374"},
+			"open":         {Light: "#98971A", Dark: "#B8BB26"},
+			"in_progress":  {Light: "#458588", Dark: "#83A598"},
+			"blocked":      {Light: "#CC241D", Dark: "#FB4934"},
+			"closed":       {Light: "#928374", Dark: "#928374"},
+			"bug":          {Light: "#CC241D", Dark: "#FB4934"},
+			"feature":      {Light: "#D65D0E", Dark: "#FE8019"},
+			"epic":         {Light: "#B16286", Dark: "#D3869B"},
+			"task":         {Light: "#D79921", Dark: "#FABD2F"},
+			"chore":        {Light: "#458588", Dark: "#83A598"},
+		},
+	},
+	"ascii-safe": {
+		TypeIcons: map[string]string{
+			"bug":     "X",
+			"feature": "*",
+			"task":    "-",
+			"epic":    "^",
+			"chore":   "~",
+		},
+		PriorityIcons: map[string]string{
+			"0": "!!",
+			"1": "!",
+			"2": "o",
+			"3": ".",
+			"4": " ",
+		},
+	},
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLoadThemeConfigMissingFileReturnsZeroValue(t *testing.T) {
+	cfg, err := LoadThemeConfig("/nonexistent/theme.yaml")
+	if err != nil {
+		t.Fatalf("LoadThemeConfig of a missing file should not error, got: %v", err)
+	}
+	if len(cfg.Colors) != 0 || len(cfg.TypeIcons) != 0 || len(cfg.PriorityIcons) != 0 {
+		t.Errorf("expected a zero ThemeConfig for a missing file, got %+v", cfg)
+	}
+}
+
+func TestLoadThemeConfigOverridesDefaults(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "theme.yaml")
+	yaml := `
+colors:
+  primary:
+    light: "#112233"
+    dark: "#445566"
+type_icons:
+  bug: "X"
+priority_icons:
+  "0": "!!"
+`
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadThemeConfig(path)
+	if err != nil {
+		t.Fatalf("LoadThemeConfig returned error: %v", err)
+	}
+	if cfg.Colors["primary"].Light != "#112233" || cfg.Colors["primary"].Dark != "#445566" {
+		t.Errorf("unexpected primary override: %+v", cfg.Colors["primary"])
+	}
+	if cfg.TypeIcons["bug"] != "X" {
+		t.Errorf("unexpected bug icon override: %q", cfg.TypeIcons["bug"])
+	}
+	if cfg.PriorityIcons["0"] != "!!" {
+		t.Errorf("unexpected priority 0 icon override: %q", cfg.PriorityIcons["0"])
+	}
+}
+
+func TestNewThemeWithConfigAppliesColorAndIconOverrides(t *testing.T) {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	cfg := ThemeConfig{
+		Colors: map[string]ColorOverride{
+			"bug": {Light: "#FF0000", Dark: "#FF0000"},
+		},
+		TypeIcons:     map[string]string{"bug": "X"},
+		PriorityIcons: map[string]string{"0": "!!"},
+	}
+	theme := NewThemeWithConfig(renderer, cfg)
+
+	if theme.Bug.Light != "#FF0000" || theme.Bug.Dark != "#FF0000" {
+		t.Errorf("expected overridden Bug color, got %+v", theme.Bug)
+	}
+	if icon, _ := theme.TypeIcon("bug"); icon != "X" {
+		t.Errorf("TypeIcon(bug) = %q, want \"X\"", icon)
+	}
+	if icon, _ := theme.TypeIcon("feature"); icon != "✨" {
+		t.Errorf("unrelated TypeIcon(feature) should stay default, got %q", icon)
+	}
+	if got := theme.PriorityIcon(0); got != "!!" {
+		t.Errorf("PriorityIcon(0) = %q, want \"!!\"", got)
+	}
+	if got := theme.PriorityIcon(1); got != GetPriorityIcon(1) {
+		t.Errorf("unrelated PriorityIcon(1) should stay default, got %q", got)
+	}
+}
+
+func TestNewThemeWithConfigZeroValueMatchesNewTheme(t *testing.T) {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	a := NewTheme(renderer)
+	b := NewThemeWithConfig(renderer, ThemeConfig{})
+
+	if a.Primary != b.Primary || a.Bug != b.Bug || a.Open != b.Open {
+		t.Errorf("NewThemeWithConfig with a zero ThemeConfig should match NewTheme, got %+v vs %+v", a, b)
+	}
+}
+
+func TestBuiltinTheme(t *testing.T) {
+	if _, ok := BuiltinTheme("not-a-real-theme"); ok {
+		t.Error("expected unknown theme name to return ok=false")
+	}
+	for _, name := range []string{"dracula", "solarized-dark", "solarized-light", "gruvbox", "ascii-safe"} {
+		if _, ok := BuiltinTheme(name); !ok {
+			t.Errorf("expected built-in theme %q to be registered", name)
+		}
+	}
+}
+
+func TestNewThemeFromEnvSelectsBuiltinTheme(t *testing.T) {
+	t.Setenv(ThemeEnvVar, "ascii-safe")
+	renderer := lipgloss.NewRenderer(io.Discard)
+	theme := NewThemeFromEnv(renderer)
+
+	if icon, _ := theme.TypeIcon("bug"); icon != "X" {
+		t.Errorf("TypeIcon(bug) under ascii-safe theme = %q, want \"X\"", icon)
+	}
+}
+
+func TestNewThemeFromEnvUnknownNameFallsBackToDefault(t *testing.T) {
+	t.Setenv(ThemeEnvVar, "not-a-real-theme")
+	renderer := lipgloss.NewRenderer(io.Discard)
+	a := NewTheme(renderer)
+	b := NewThemeFromEnv(renderer)
+
+	if a.Bug != b.Bug {
+		t.Errorf("unknown %s should fall back to NewTheme defaults, got %+v", ThemeEnvVar, b.Bug)
+	}
+}
+
+func TestNewThemeFromFileMissingPathMatchesDefault(t *testing.T) {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	a := NewTheme(renderer)
+	b, err := NewThemeFromFile(renderer, "/nonexistent/theme.yaml")
+	if err != nil {
+		t.Fatalf("NewThemeFromFile returned error: %v", err)
+	}
+	if a.Bug != b.Bug {
+		t.Errorf("missing theme file should match NewTheme defaults, got %+v", b.Bug)
+	}
+}
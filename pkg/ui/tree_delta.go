@@ -0,0 +1,100 @@
+package ui
+
+import "github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+// IssueDelta describes a set of issue changes to feed into a future
+// TreeModel.Apply, so a live beads database update can re-parent only
+// the touched nodes instead of forcing a full Build (which today also
+// resets Expanded and the cursor).
+type IssueDelta struct {
+	Added   []model.Issue
+	Updated []model.Issue
+	Removed []string
+}
+
+// IsEmpty reports whether d has no changes at all.
+func (d IssueDelta) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Updated) == 0 && len(d.Removed) == 0
+}
+
+// Touched returns the set of issue IDs d directly names: every added,
+// updated, or removed ID. Apply would compare len(Touched()) against the
+// total issue count to decide whether an incremental rewire is cheaper
+// than falling back to a full Build.
+func (d IssueDelta) Touched() map[string]bool {
+	touched := make(map[string]bool, len(d.Added)+len(d.Updated)+len(d.Removed))
+	for _, iss := range d.Added {
+		touched[iss.ID] = true
+	}
+	for _, iss := range d.Updated {
+		touched[iss.ID] = true
+	}
+	for _, id := range d.Removed {
+		touched[id] = true
+	}
+	return touched
+}
+
+// ExceedsThreshold reports whether d touches more than fraction of
+// totalIssues issues - the signal Apply would use to fall back to a full
+// Build rather than pay for an incremental rewire that would end up
+// touching most of the tree anyway. A totalIssues of 0 (an empty tree)
+// always exceeds the threshold, since there's nothing incremental to
+// preserve.
+func (d IssueDelta) ExceedsThreshold(totalIssues int, fraction float64) bool {
+	if totalIssues <= 0 {
+		return true
+	}
+	return float64(len(d.Touched()))/float64(totalIssues) > fraction
+}
+
+// ParentID returns the ID of the parent issue declares via a
+// DepParentChild dependency, and false if issue has no such dependency
+// (i.e. it belongs at the root, or would become an orphaned root if its
+// declared parent turns out not to exist in issueMap).
+func ParentID(issue model.Issue) (string, bool) {
+	for _, dep := range issue.Dependencies {
+		if dep.Type == model.DepParentChild && dep.IssueID == issue.ID {
+			return dep.DependsOnID, true
+		}
+	}
+	return "", false
+}
+
+// WouldIntroduceCycle reports whether re-parenting child under parentID
+// would create a cycle. It walks parent pointers from parentID back
+// toward the root via parentOf, which should resolve the *current*
+// (pre-update) parent of a given ID from issueMap; if child's own ID is
+// reached before running out of ancestors, applying the re-parent would
+// corrupt the tree and must be rejected rather than performed.
+func WouldIntroduceCycle(child, parentID string, parentOf func(id string) (string, bool)) bool {
+	visited := map[string]bool{child: true}
+	current := parentID
+	for {
+		if visited[current] {
+			return true
+		}
+		visited[current] = true
+
+		next, ok := parentOf(current)
+		if !ok {
+			return false
+		}
+		current = next
+	}
+}
+
+// NOTE: this checkout is missing the TreeModel/IssueTreeNode subsystem
+// (see tree_path.go's NOTE), so there is no Apply here.
+// Apply(delta IssueDelta) should, once TreeModel exists:
+// reject the delta via WouldIntroduceCycle before mutating anything;
+// fall back to Build(allIssues) when delta.ExceedsThreshold(len(issueMap), N)
+// trips; otherwise, for each touched ID, use ParentID plus the existing
+// issueMap to walk from the old and new parent up to re-splice Children
+// slices and re-sort only the affected sibling lists (see the ordering
+// TestTreeBuildChildSorting already covers), removing a node whose
+// declared parent is gone by re-adding it as a root exactly as
+// TestTreeBuildOrphanParent expects from Build today. Because
+// IssueTreeNode pointers for untouched issues are reused as-is, Expanded
+// and any in-flight TreeSnapshot/TreeHistory state survive Apply
+// automatically.
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func parentChildDep(child, parent string) []*model.Dependency {
+	return []*model.Dependency{{IssueID: child, DependsOnID: parent, Type: model.DepParentChild}}
+}
+
+func TestIssueDeltaIsEmpty(t *testing.T) {
+	if !(IssueDelta{}).IsEmpty() {
+		t.Fatal("expected a zero-value IssueDelta to be empty")
+	}
+	if (IssueDelta{Removed: []string{"a"}}).IsEmpty() {
+		t.Fatal("expected a delta with a removal to not be empty")
+	}
+}
+
+func TestIssueDeltaTouched(t *testing.T) {
+	d := IssueDelta{
+		Added:   []model.Issue{{ID: "a"}},
+		Updated: []model.Issue{{ID: "b"}},
+		Removed: []string{"c"},
+	}
+	touched := d.Touched()
+	for _, id := range []string{"a", "b", "c"} {
+		if !touched[id] {
+			t.Errorf("expected %q to be touched", id)
+		}
+	}
+	if len(touched) != 3 {
+		t.Errorf("expected exactly 3 touched IDs, got %d", len(touched))
+	}
+}
+
+func TestIssueDeltaExceedsThreshold(t *testing.T) {
+	d := IssueDelta{Updated: []model.Issue{{ID: "a"}, {ID: "b"}}}
+
+	if d.ExceedsThreshold(10, 0.5) {
+		t.Error("expected a 2/10 delta to stay under a 50% threshold")
+	}
+	if !d.ExceedsThreshold(3, 0.5) {
+		t.Error("expected a 2/3 delta to exceed a 50% threshold")
+	}
+	if !d.ExceedsThreshold(0, 0.5) {
+		t.Error("expected an empty tree to always exceed the threshold")
+	}
+}
+
+func TestParentID(t *testing.T) {
+	child := model.Issue{ID: "child", Dependencies: parentChildDep("child", "parent")}
+	if got, ok := ParentID(child); !ok || got != "parent" {
+		t.Errorf("expected parent \"parent\", got %q (ok=%v)", got, ok)
+	}
+
+	root := model.Issue{ID: "root"}
+	if _, ok := ParentID(root); ok {
+		t.Error("expected an issue with no DepParentChild dependency to have no parent")
+	}
+
+	blockedOnly := model.Issue{
+		ID:           "blocked",
+		Dependencies: []*model.Dependency{{IssueID: "blocked", DependsOnID: "blocker", Type: model.DepBlocks}},
+	}
+	if _, ok := ParentID(blockedOnly); ok {
+		t.Error("expected a DepBlocks dependency to not be treated as a parent")
+	}
+}
+
+func TestWouldIntroduceCycleDirect(t *testing.T) {
+	// "a" has no parent yet; re-parenting "a" under "b", where "b" is
+	// already a descendant-to-be of "a", must be rejected.
+	parentOf := func(id string) (string, bool) {
+		if id == "b" {
+			return "a", true
+		}
+		return "", false
+	}
+
+	if !WouldIntroduceCycle("a", "b", parentOf) {
+		t.Error("expected re-parenting \"a\" under its own descendant \"b\" to be flagged as a cycle")
+	}
+}
+
+func TestWouldIntroduceCycleIndirect(t *testing.T) {
+	// chain: c -> b -> a (b's parent is a, c's parent is b). Re-parenting
+	// "a" under "c" closes the loop a -> c -> b -> a.
+	parentOf := func(id string) (string, bool) {
+		switch id {
+		case "c":
+			return "b", true
+		case "b":
+			return "a", true
+		default:
+			return "", false
+		}
+	}
+
+	if !WouldIntroduceCycle("a", "c", parentOf) {
+		t.Error("expected re-parenting \"a\" under \"c\" to be flagged as an indirect cycle")
+	}
+}
+
+func TestWouldIntroduceCycleNoCycle(t *testing.T) {
+	parentOf := func(id string) (string, bool) {
+		if id == "b" {
+			return "root", true
+		}
+		return "", false
+	}
+
+	if WouldIntroduceCycle("a", "b", parentOf) {
+		t.Error("expected re-parenting \"a\" under unrelated \"b\" to not be flagged as a cycle")
+	}
+}
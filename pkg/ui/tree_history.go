@@ -0,0 +1,108 @@
+package ui
+
+// TreeSnapshot captures the parts of a tree's view state that are cheap
+// to save and restore independently of its node structure: which paths
+// are expanded, where the cursor sits, and how far the view is
+// scrolled. It deliberately excludes the node structure itself - when
+// TreeModel exists (see the NOTE below), the structure is unchanged by
+// expand/collapse, so a snapshot only needs to copy its own small
+// Expanded set, not the whole tree.
+type TreeSnapshot struct {
+	// Expanded holds the set of TreePath.String() values that are open.
+	// It is the snapshot's own copy so later mutation of the live
+	// expanded set can't retroactively change a saved snapshot.
+	Expanded map[string]bool
+	// CursorPath addresses the selected node at the time of the
+	// snapshot.
+	CursorPath TreePath
+	// ScrollOffset is the first visible row's index into the flattened
+	// view.
+	ScrollOffset int
+}
+
+// cloneExpanded returns an independent copy of expanded, so a TreeSnapshot
+// is never aliased to the live set it was taken from.
+func cloneExpanded(expanded map[string]bool) map[string]bool {
+	clone := make(map[string]bool, len(expanded))
+	for k, v := range expanded {
+		clone[k] = v
+	}
+	return clone
+}
+
+// NewTreeSnapshot builds a TreeSnapshot from a live expanded set, cursor
+// path, and scroll offset, copying expanded so the snapshot is immutable
+// from that point on.
+func NewTreeSnapshot(expanded map[string]bool, cursorPath TreePath, scrollOffset int) TreeSnapshot {
+	return TreeSnapshot{
+		Expanded:     cloneExpanded(expanded),
+		CursorPath:   cursorPath,
+		ScrollOffset: scrollOffset,
+	}
+}
+
+// TreeHistory is a bounded undo/redo stack of TreeSnapshots. Push records
+// a new snapshot and discards any redo-able snapshots ahead of it,
+// mirroring standard editor undo/redo semantics. Once the stack holds
+// Capacity snapshots, the oldest is dropped to make room rather than
+// growing without bound.
+type TreeHistory struct {
+	snapshots []TreeSnapshot
+	current   int // index into snapshots of the "present" snapshot; -1 if empty
+	capacity  int
+}
+
+// NewTreeHistory returns an empty TreeHistory that retains at most
+// capacity snapshots. A non-positive capacity is treated as 1.
+func NewTreeHistory(capacity int) *TreeHistory {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &TreeHistory{capacity: capacity, current: -1}
+}
+
+// Push records snapshot as the new present state. Any snapshots after
+// the current position (i.e. the redo history left by a prior Undo) are
+// discarded, and the oldest snapshot is evicted once Capacity is
+// exceeded.
+func (h *TreeHistory) Push(snapshot TreeSnapshot) {
+	h.snapshots = append(h.snapshots[:h.current+1], snapshot)
+	h.current++
+	if len(h.snapshots) > h.capacity {
+		evict := len(h.snapshots) - h.capacity
+		h.snapshots = h.snapshots[evict:]
+		h.current -= evict
+	}
+}
+
+// Undo moves one snapshot back and returns it, or returns false if
+// already at the oldest recorded snapshot (or the history is empty).
+func (h *TreeHistory) Undo() (TreeSnapshot, bool) {
+	if h.current <= 0 {
+		return TreeSnapshot{}, false
+	}
+	h.current--
+	return h.snapshots[h.current], true
+}
+
+// Redo moves one snapshot forward and returns it, or returns false if
+// already at the newest recorded snapshot (or the history is empty).
+func (h *TreeHistory) Redo() (TreeSnapshot, bool) {
+	if h.current < 0 || h.current >= len(h.snapshots)-1 {
+		return TreeSnapshot{}, false
+	}
+	h.current++
+	return h.snapshots[h.current], true
+}
+
+// NOTE: this checkout is missing the TreeModel/IssueTreeNode subsystem
+// (see tree_path.go's NOTE for the same gap). Once it is restored,
+// TreeModel should grow an unexported *TreeHistory field pushed to on
+// every ExpandAll/CollapseAll/ToggleExpand/filter-driven rebuild, plus
+// exported Snapshot() TreeSnapshot, Restore(TreeSnapshot), Undo(), and
+// Redo() methods that read/write tree.expanded, tree.cursor (converted
+// to/from a TreePath via the PathOf helper left for that restoration),
+// and tree.scrollOffset. Because Build's IssueTreeNode pointers don't
+// change across expand/collapse, Restore only needs to replace the
+// expanded set and re-run flatten/clampCursor - it never needs to
+// rebuild nodes.
@@ -0,0 +1,102 @@
+package ui
+
+import "testing"
+
+func TestTreeSnapshotCopiesExpandedSet(t *testing.T) {
+	live := map[string]bool{"bd-1": true}
+	snap := NewTreeSnapshot(live, TreePath{"bd-1"}, 0)
+
+	live["bd-1"] = false
+	live["bd-2"] = true
+
+	if !snap.Expanded["bd-1"] {
+		t.Fatal("expected snapshot's copy of bd-1 to stay expanded after the live set changed")
+	}
+	if snap.Expanded["bd-2"] {
+		t.Fatal("expected snapshot to not see entries added to the live set after it was taken")
+	}
+}
+
+func TestTreeHistoryUndoRedo(t *testing.T) {
+	h := NewTreeHistory(10)
+
+	s1 := NewTreeSnapshot(map[string]bool{"a": true}, TreePath{"a"}, 0)
+	s2 := NewTreeSnapshot(map[string]bool{"a": true, "b": true}, TreePath{"a", "b"}, 1)
+	h.Push(s1)
+	h.Push(s2)
+
+	got, ok := h.Undo()
+	if !ok || !got.CursorPath.Equal(s1.CursorPath) {
+		t.Fatalf("expected Undo to return s1, got %+v (ok=%v)", got, ok)
+	}
+
+	if _, ok := h.Undo(); ok {
+		t.Fatal("expected a second Undo past the oldest snapshot to fail")
+	}
+
+	got, ok = h.Redo()
+	if !ok || !got.CursorPath.Equal(s2.CursorPath) {
+		t.Fatalf("expected Redo to return s2, got %+v (ok=%v)", got, ok)
+	}
+
+	if _, ok := h.Redo(); ok {
+		t.Fatal("expected Redo past the newest snapshot to fail")
+	}
+}
+
+func TestTreeHistoryPushAfterUndoTruncatesRedo(t *testing.T) {
+	h := NewTreeHistory(10)
+
+	s1 := NewTreeSnapshot(nil, TreePath{"a"}, 0)
+	s2 := NewTreeSnapshot(nil, TreePath{"b"}, 0)
+	s3 := NewTreeSnapshot(nil, TreePath{"c"}, 0)
+
+	h.Push(s1)
+	h.Push(s2)
+	if _, ok := h.Undo(); !ok {
+		t.Fatal("expected Undo to succeed")
+	}
+
+	// Pushing a new snapshot while s2 is redo-able should discard s2.
+	h.Push(s3)
+
+	if _, ok := h.Redo(); ok {
+		t.Fatal("expected Redo to be unavailable after a new Push truncated forward history")
+	}
+	got, ok := h.Undo()
+	if !ok || !got.CursorPath.Equal(s1.CursorPath) {
+		t.Fatalf("expected Undo to return s1, got %+v (ok=%v)", got, ok)
+	}
+}
+
+func TestTreeHistoryEvictsOldestBeyondCapacity(t *testing.T) {
+	h := NewTreeHistory(2)
+
+	s1 := NewTreeSnapshot(nil, TreePath{"a"}, 0)
+	s2 := NewTreeSnapshot(nil, TreePath{"b"}, 0)
+	s3 := NewTreeSnapshot(nil, TreePath{"c"}, 0)
+
+	h.Push(s1)
+	h.Push(s2)
+	h.Push(s3)
+
+	// s1 should have been evicted, so only one Undo (to s2) is possible.
+	got, ok := h.Undo()
+	if !ok || !got.CursorPath.Equal(s2.CursorPath) {
+		t.Fatalf("expected Undo to return s2, got %+v (ok=%v)", got, ok)
+	}
+	if _, ok := h.Undo(); ok {
+		t.Fatal("expected s1 to have been evicted once capacity was exceeded")
+	}
+}
+
+func TestTreeHistoryUndoRedoOnEmptyHistory(t *testing.T) {
+	h := NewTreeHistory(4)
+
+	if _, ok := h.Undo(); ok {
+		t.Fatal("expected Undo on an empty history to fail")
+	}
+	if _, ok := h.Redo(); ok {
+		t.Fatal("expected Redo on an empty history to fail")
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import "strings"
+
+// TreePath addresses one occurrence of an issue in a dependency tree as
+// a stable path of issue IDs from the root down to that node. Because
+// dependencies are stored as *model.Dependency edges (a DAG, not a
+// tree), the same issue ID can appear under more than one parent - a
+// bare ID is ambiguous about which occurrence is meant, but a TreePath
+// identifies exactly one.
+type TreePath []string
+
+// Equal reports whether p and other address the same occurrence.
+func (p TreePath) Equal(other TreePath) bool {
+	if len(p) != len(other) {
+		return false
+	}
+	for i := range p {
+		if p[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Child returns a new path one level deeper than p, addressing the
+// child occurrence id under it. p itself is left unmodified.
+func (p TreePath) Child(id string) TreePath {
+	child := make(TreePath, len(p)+1)
+	copy(child, p)
+	child[len(p)] = id
+	return child
+}
+
+// Parent returns p with its last element removed, and false if p is
+// already the root path.
+func (p TreePath) Parent() (TreePath, bool) {
+	if len(p) == 0 {
+		return nil, false
+	}
+	return p[:len(p)-1], true
+}
+
+// Leaf returns the ID of the occurrence p addresses, or "" for the root
+// path.
+func (p TreePath) Leaf() string {
+	if len(p) == 0 {
+		return ""
+	}
+	return p[len(p)-1]
+}
+
+// String renders p as a "/"-joined path, e.g. "bd-1/bd-4/bd-9", for
+// logging or as a map key.
+func (p TreePath) String() string {
+	return strings.Join([]string(p), "/")
+}
+
+// NOTE: this checkout is missing the TreeModel/IssueTreeNode subsystem
+// (tree.go, the "bv-gllx feature" referenced in tutorial_components.go)
+// that buildNodeRecursive, flatten, SelectByID, JumpToParent, and
+// ExpandOrMoveToChild belong to - only its 682-line test file,
+// tree_test.go, survives in this tree (see the gap noted in
+// tutorial_tree_interactive.go's history for the same condition).
+// TreePath is added now, independent of TreeModel, so a future
+// TreeModel.NodeAt(path TreePath), PathOf(id string) TreePath, and
+// SelectByPath(path TreePath) bool have a ready-made path type - but
+// propagating TreePath through buildNodeRecursive and flatten, and
+// rewriting JumpToParent/ExpandOrMoveToChild to use it instead of
+// visible-order heuristics, is left for whoever restores that
+// subsystem.
@@ -0,0 +1,52 @@
+package ui
+
+import "testing"
+
+func TestTreePathChildAppendsWithoutMutatingParent(t *testing.T) {
+	root := TreePath{"bd-1"}
+	child := root.Child("bd-4")
+
+	if got := child.String(); got != "bd-1/bd-4" {
+		t.Fatalf("expected \"bd-1/bd-4\", got %q", got)
+	}
+	if got := root.String(); got != "bd-1" {
+		t.Fatalf("expected Child to leave the parent path unmodified, got %q", got)
+	}
+}
+
+func TestTreePathParent(t *testing.T) {
+	p := TreePath{"bd-1", "bd-4", "bd-9"}
+	parent, ok := p.Parent()
+	if !ok || parent.String() != "bd-1/bd-4" {
+		t.Fatalf("expected parent \"bd-1/bd-4\", got %q (ok=%v)", parent.String(), ok)
+	}
+
+	_, ok = TreePath{}.Parent()
+	if ok {
+		t.Fatal("expected the root path to have no parent")
+	}
+}
+
+func TestTreePathLeaf(t *testing.T) {
+	if got := (TreePath{"bd-1", "bd-4"}).Leaf(); got != "bd-4" {
+		t.Fatalf("expected leaf \"bd-4\", got %q", got)
+	}
+	if got := (TreePath{}).Leaf(); got != "" {
+		t.Fatalf("expected an empty leaf for the root path, got %q", got)
+	}
+}
+
+func TestTreePathEqual(t *testing.T) {
+	a := TreePath{"bd-1", "bd-4"}
+	b := TreePath{"bd-1", "bd-4"}
+	c := TreePath{"bd-1", "bd-9"}
+	if !a.Equal(b) {
+		t.Fatal("expected identical paths to be equal")
+	}
+	if a.Equal(c) {
+		t.Fatal("expected differing paths to not be equal")
+	}
+	if a.Equal(TreePath{"bd-1"}) {
+		t.Fatal("expected paths of differing length to not be equal")
+	}
+}
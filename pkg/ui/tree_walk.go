@@ -0,0 +1,77 @@
+package ui
+
+// WalkAction tells a Walk caller how to proceed after visiting a node.
+type WalkAction int
+
+const (
+	// WalkContinue visits the node's children, then its siblings.
+	WalkContinue WalkAction = iota
+	// WalkSkipChildren skips the node's children but continues with its
+	// siblings.
+	WalkSkipChildren
+	// WalkStop halts the walk entirely; no further nodes are visited.
+	WalkStop
+)
+
+// WalkNode is the shape a node must expose to be traversed by Walk. It is
+// satisfied by *IssueTreeNode once that subsystem exists (see the NOTE
+// below), and by anything else with the same depth-first tree shape.
+type WalkNode[N any] interface {
+	// WalkID returns the node's identity, appended to the path passed to
+	// visitor for that node's children.
+	WalkID() string
+	// WalkChildren returns the node's children in traversal order.
+	WalkChildren() []N
+}
+
+// Walk performs a deterministic, depth-first, pre-order traversal of
+// roots, calling visitor once per node with its 0-based depth and the
+// path of IDs from a root down to (and including) it. visited guards
+// against cycles: a node whose WalkID has already been seen on the
+// current root-to-node chain is skipped rather than re-descended into,
+// so a root-to-root cycle terminates instead of recursing forever.
+//
+// visitor's return value controls how the walk proceeds: WalkContinue
+// descends into the node's children, WalkSkipChildren moves on to its
+// next sibling without descending, and WalkStop halts the walk
+// immediately. Walk returns early (without error) on WalkStop.
+func Walk[N WalkNode[N]](roots []N, visitor func(node N, depth int, path []string) WalkAction) {
+	visited := make(map[string]bool)
+	walkNodes(roots, 0, nil, visited, visitor)
+}
+
+// walkNodes is the recursive helper behind Walk; it returns false once the
+// visitor has requested WalkStop, so every caller up the stack can unwind
+// without visiting further siblings.
+func walkNodes[N WalkNode[N]](nodes []N, depth int, path []string, visited map[string]bool, visitor func(node N, depth int, path []string) WalkAction) bool {
+	for _, node := range nodes {
+		id := node.WalkID()
+		if visited[id] {
+			continue
+		}
+
+		nodePath := append(append([]string(nil), path...), id)
+		switch visitor(node, depth, nodePath) {
+		case WalkStop:
+			return false
+		case WalkSkipChildren:
+			continue
+		}
+
+		visited[id] = true
+		if !walkNodes(node.WalkChildren(), depth+1, nodePath, visited, visitor) {
+			return false
+		}
+		delete(visited, id)
+	}
+	return true
+}
+
+// NOTE: this checkout is missing the TreeModel/IssueTreeNode subsystem
+// (see tree_path.go's NOTE for the same gap). Once it is restored,
+// *IssueTreeNode should grow WalkID (returning Issue.ID) and
+// WalkChildren (returning Children) methods so TreeModel.Walk can
+// delegate to Walk here, and Build, flatten, ExpandAll, CollapseAll, and
+// the cycle-detection traversal covered by TestTreeBuildCycleDetection
+// and TestTreeBuildChildSorting should be rewritten to share this one
+// implementation instead of each recursing independently.
@@ -0,0 +1,132 @@
+package ui
+
+import "testing"
+
+// walkTestNode is a minimal WalkNode used only to exercise Walk in
+// isolation from the (currently missing) IssueTreeNode.
+type walkTestNode struct {
+	id       string
+	children []walkTestNode
+}
+
+func (n walkTestNode) WalkID() string              { return n.id }
+func (n walkTestNode) WalkChildren() []walkTestNode { return n.children }
+
+func TestWalkVisitsDepthFirstPreOrder(t *testing.T) {
+	roots := []walkTestNode{
+		{id: "a", children: []walkTestNode{
+			{id: "a1"},
+			{id: "a2"},
+		}},
+		{id: "b"},
+	}
+
+	var order []string
+	Walk(roots, func(node walkTestNode, depth int, path []string) WalkAction {
+		order = append(order, node.id)
+		return WalkContinue
+	})
+
+	expected := []string{"a", "a1", "a2", "b"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i, id := range expected {
+		if order[i] != id {
+			t.Fatalf("expected %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestWalkSkipChildrenSkipsDescendants(t *testing.T) {
+	roots := []walkTestNode{
+		{id: "a", children: []walkTestNode{{id: "a1"}}},
+		{id: "b"},
+	}
+
+	var order []string
+	Walk(roots, func(node walkTestNode, depth int, path []string) WalkAction {
+		order = append(order, node.id)
+		if node.id == "a" {
+			return WalkSkipChildren
+		}
+		return WalkContinue
+	})
+
+	expected := []string{"a", "b"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i, id := range expected {
+		if order[i] != id {
+			t.Fatalf("expected %v, got %v", expected, order)
+		}
+	}
+}
+
+func TestWalkStopHaltsImmediately(t *testing.T) {
+	roots := []walkTestNode{
+		{id: "a", children: []walkTestNode{{id: "a1"}}},
+		{id: "b"},
+	}
+
+	var order []string
+	Walk(roots, func(node walkTestNode, depth int, path []string) WalkAction {
+		order = append(order, node.id)
+		if node.id == "a" {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+
+	if got := []string{"a"}; len(order) != len(got) || order[0] != got[0] {
+		t.Fatalf("expected walk to stop after visiting \"a\", got %v", order)
+	}
+}
+
+func TestWalkHandlesCycleWithoutHanging(t *testing.T) {
+	// A and B reference each other as children, forming a cycle.
+	a := walkTestNode{id: "cycle-a"}
+	b := walkTestNode{id: "cycle-b"}
+	a.children = []walkTestNode{b}
+	b.children = []walkTestNode{a}
+
+	var count int
+	Walk([]walkTestNode{a}, func(node walkTestNode, depth int, path []string) WalkAction {
+		count++
+		if count > 10 {
+			t.Fatal("Walk did not terminate on a cyclic graph")
+		}
+		return WalkContinue
+	})
+
+	if count != 2 {
+		t.Fatalf("expected the cycle to be visited exactly once per node, got %d visits", count)
+	}
+}
+
+func TestWalkPathIncludesAncestors(t *testing.T) {
+	roots := []walkTestNode{
+		{id: "root", children: []walkTestNode{
+			{id: "child", children: []walkTestNode{{id: "grandchild"}}},
+		}},
+	}
+
+	var gotPath []string
+	Walk(roots, func(node walkTestNode, depth int, path []string) WalkAction {
+		if node.id == "grandchild" {
+			gotPath = path
+		}
+		return WalkContinue
+	})
+
+	expected := []string{"root", "child", "grandchild"}
+	if len(gotPath) != len(expected) {
+		t.Fatalf("expected path %v, got %v", expected, gotPath)
+	}
+	for i, id := range expected {
+		if gotPath[i] != id {
+			t.Fatalf("expected path %v, got %v", expected, gotPath)
+		}
+	}
+}
@@ -133,6 +133,14 @@ func (t Tip) Render(theme Theme, width int) string {
 // StatusFlow renders a status flow diagram using lipgloss boxes with elegant arrows
 type StatusFlow struct {
 	Steps []FlowStep
+
+	// Gradient and GradientStops, if Gradient has at least two colors,
+	// override the default per-arrow blend between its two adjacent
+	// steps' colors: every arrow instead samples this one gradient at
+	// its position along the flow, e.g. a flat green -> amber -> red
+	// ramp showing overall severity regardless of each step's own color.
+	Gradient      []lipgloss.Color
+	GradientStops []float64
 }
 
 type FlowStep struct {
@@ -148,10 +156,7 @@ func (sf StatusFlow) Render(theme Theme, width int) string {
 		return ""
 	}
 
-	// Arrow style using proper arrow character
-	arrowStyle := r.NewStyle().
-		Foreground(theme.Muted).
-		Bold(true)
+	useGradient := len(sf.Gradient) >= 2 && useGradientProfile(r)
 
 	var boxes []string
 	for i, step := range sf.Steps {
@@ -166,6 +171,17 @@ func (sf StatusFlow) Render(theme Theme, width int) string {
 
 		// Add arrow between boxes (not after last) - use proper arrow character
 		if i < numSteps-1 {
+			var arrowColor lipgloss.Color
+			if useGradient {
+				span := numSteps - 2
+				if span < 1 {
+					span = 1
+				}
+				arrowColor = gradientColor(sf.Gradient, sf.GradientStops, float64(i)/float64(span))
+			} else {
+				arrowColor = lerpHSL(step.Color.Dark, sf.Steps[i+1].Color.Dark, 0.5)
+			}
+			arrowStyle := r.NewStyle().Foreground(arrowColor).Bold(true)
 			boxes = append(boxes, arrowStyle.Render(" → "))
 		}
 	}
@@ -451,6 +467,18 @@ type ProgressIndicator struct {
 	Current int
 	Total   int
 	Label   string
+
+	// Gradient and GradientStops, if Gradient has at least two colors,
+	// render the filled portion of the bar as a horizontal gradient
+	// instead of a solid theme.Open, e.g. a green -> amber -> red ramp
+	// indicating severity. Each cell's color is sampled at its position
+	// along the full bar width, so the ramp stays fixed as Current
+	// changes rather than stretching to fit however much is filled.
+	// GradientStops pairs 1:1 with Gradient (0..1 each); omit it to
+	// spread the colors evenly. Ignored on 16-color/uncolored renderers,
+	// where the bar falls back to its solid theme.Open fill.
+	Gradient      []lipgloss.Color
+	GradientStops []float64
 }
 
 func (pi ProgressIndicator) Render(theme Theme, width int) string {
@@ -479,15 +507,19 @@ func (pi ProgressIndicator) Render(theme Theme, width int) string {
 	filledWidth := int(float64(barWidth) * progress)
 	emptyWidth := barWidth - filledWidth
 
-	filledStyle := r.NewStyle().
-		Foreground(theme.Open).
-		Background(theme.Open)
-
 	emptyStyle := r.NewStyle().
 		Foreground(theme.Muted).
 		Background(lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#3D3D3D"})
 
-	filled := filledStyle.Render(strings.Repeat("█", filledWidth))
+	var filled string
+	if len(pi.Gradient) >= 2 && useGradientProfile(r) {
+		filled = renderGradientBar(r, pi.Gradient, pi.GradientStops, filledWidth, barWidth)
+	} else {
+		filledStyle := r.NewStyle().
+			Foreground(theme.Open).
+			Background(theme.Open)
+		filled = filledStyle.Render(strings.Repeat("█", filledWidth))
+	}
 	empty := emptyStyle.Render(strings.Repeat("░", emptyWidth))
 
 	// Percentage - format as right-aligned 3-digit number with %
@@ -0,0 +1,268 @@
+package ui
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// TutorialsFS embeds the markdown-authored tutorial pages under
+// ui/tutorials/ so contributors can add or edit tutorial content
+// without touching Go code or recompiling the TutorialElement schema.
+//
+//go:embed tutorials/*.md
+var TutorialsFS embed.FS
+
+// LoadTutorialPages parses every *.md file in TutorialsFS into its
+// TutorialElement sequence via ParseMarkdown, keyed by the file's base
+// name without extension ("tutorials/board.md" -> "board").
+func LoadTutorialPages() (map[string][]TutorialElement, error) {
+	entries, err := fs.ReadDir(TutorialsFS, "tutorials")
+	if err != nil {
+		return nil, err
+	}
+
+	pages := make(map[string][]TutorialElement, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		raw, err := TutorialsFS.ReadFile(path.Join("tutorials", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("ui: reading tutorial %s: %w", entry.Name(), err)
+		}
+		elements, err := ParseMarkdown(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("ui: parsing tutorial %s: %w", entry.Name(), err)
+		}
+		pages[strings.TrimSuffix(entry.Name(), ".md")] = elements
+	}
+	return pages, nil
+}
+
+// ParseMarkdown maps a small CommonMark subset onto the existing
+// TutorialElement types, so tutorial pages can be authored as plain
+// markdown instead of hand-assembled Go literals:
+//
+//   - ATX headings ("#", "##", ...)      -> Section
+//   - fenced ``` code blocks             -> Code
+//   - "- " list items, all one indent    -> Bullet
+//   - "- " list items with nested indent -> Tree (items nested under a
+//     less-indented sibling become its Children; Tree.Root is left
+//     empty since a markdown list has no single natural root)
+//   - pipe tables (header + |---| row)   -> StyledTable
+//   - blockquotes opening with "!TIP"/"!WARN"/"!NOTE" -> Tip/Warning/Note,
+//     any other blockquote falls back to Paragraph
+//   - anything else                      -> Paragraph
+func ParseMarkdown(src string) ([]TutorialElement, error) {
+	lines := strings.Split(src, "\n")
+	var elements []TutorialElement
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "#"):
+			elements = append(elements, Section{Title: strings.TrimSpace(strings.TrimLeft(trimmed, "#"))})
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			text, next, err := parseFencedCode(lines, i)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, Code{Text: text})
+			i = next
+
+		case strings.HasPrefix(trimmed, ">"):
+			elem, next := parseBlockquote(lines, i)
+			elements = append(elements, elem)
+			i = next
+
+		case isListItemLine(line):
+			elem, next := parseMarkdownList(lines, i)
+			elements = append(elements, elem)
+			i = next
+
+		case isTableRow(trimmed) && i+1 < len(lines) && isTableSeparator(lines[i+1]):
+			elements = append(elements, parseMarkdownTable(lines, i))
+			i += tableRowCount(lines, i)
+
+		default:
+			text, next := parseParagraph(lines, i)
+			elements = append(elements, Paragraph{Text: text})
+			i = next
+		}
+	}
+
+	return elements, nil
+}
+
+func parseFencedCode(lines []string, start int) (string, int, error) {
+	i := start + 1
+	var content []string
+	for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+		content = append(content, lines[i])
+		i++
+	}
+	if i >= len(lines) {
+		return "", 0, fmt.Errorf("ui: unterminated fenced code block starting at line %d", start+1)
+	}
+	return strings.Join(content, "\n"), i + 1, nil
+}
+
+func parseBlockquote(lines []string, start int) (TutorialElement, int) {
+	var content []string
+	i := start
+	for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+		content = append(content, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")))
+		i++
+	}
+	text := strings.TrimSpace(strings.Join(content, " "))
+
+	switch {
+	case strings.HasPrefix(text, "!TIP"):
+		return Tip{Text: strings.TrimSpace(strings.TrimPrefix(text, "!TIP"))}, i
+	case strings.HasPrefix(text, "!WARN"):
+		return Warning{Text: strings.TrimSpace(strings.TrimPrefix(text, "!WARN"))}, i
+	case strings.HasPrefix(text, "!NOTE"):
+		return Note{Text: strings.TrimSpace(strings.TrimPrefix(text, "!NOTE"))}, i
+	default:
+		return Paragraph{Text: text}, i
+	}
+}
+
+func isListItemLine(line string) bool {
+	return strings.HasPrefix(strings.TrimLeft(line, " "), "- ")
+}
+
+type markdownListItem struct {
+	indent int
+	text   string
+}
+
+// parseMarkdownList consumes a contiguous run of "- " list-item lines
+// starting at start. A flat list (every item at the same indent)
+// becomes a Bullet; a list with any nested (more indented) item becomes
+// a Tree built from the items' relative indentation.
+func parseMarkdownList(lines []string, start int) (TutorialElement, int) {
+	var items []markdownListItem
+	i := start
+	for i < len(lines) && isListItemLine(lines[i]) {
+		indent := len(lines[i]) - len(strings.TrimLeft(lines[i], " "))
+		text := strings.TrimPrefix(strings.TrimLeft(lines[i], " "), "- ")
+		items = append(items, markdownListItem{indent: indent, text: strings.TrimSpace(text)})
+		i++
+	}
+
+	flat := true
+	for _, it := range items[1:] {
+		if it.indent != items[0].indent {
+			flat = false
+			break
+		}
+	}
+	if flat {
+		texts := make([]string, len(items))
+		for idx, it := range items {
+			texts[idx] = it.text
+		}
+		return Bullet{Items: texts}, i
+	}
+
+	children, _ := buildMarkdownTreeLevel(items, 0, items[0].indent)
+	return Tree{Children: children}, i
+}
+
+// buildMarkdownTreeLevel recursively groups items into TutorialTreeNodes:
+// siblings at indent share a parent, and a run of more deeply indented
+// items immediately following one of them becomes that item's Children.
+func buildMarkdownTreeLevel(items []markdownListItem, pos, indent int) ([]TutorialTreeNode, int) {
+	var nodes []TutorialTreeNode
+	for pos < len(items) && items[pos].indent == indent {
+		node := TutorialTreeNode{Label: items[pos].text}
+		pos++
+		if pos < len(items) && items[pos].indent > indent {
+			var children []TutorialTreeNode
+			children, pos = buildMarkdownTreeLevel(items, pos, items[pos].indent)
+			node.Children = children
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, pos
+}
+
+func isTableRow(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "|") && strings.Count(trimmed, "|") >= 2
+}
+
+func isTableSeparator(line string) bool {
+	t := strings.TrimSpace(line)
+	if !strings.Contains(t, "-") {
+		return false
+	}
+	for _, r := range t {
+		switch r {
+		case '|', '-', ':', ' ':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func splitTableRow(line string) []string {
+	t := strings.TrimSpace(line)
+	t = strings.TrimPrefix(t, "|")
+	t = strings.TrimSuffix(t, "|")
+	cells := strings.Split(t, "|")
+	for i, c := range cells {
+		cells[i] = strings.TrimSpace(c)
+	}
+	return cells
+}
+
+func parseMarkdownTable(lines []string, start int) TutorialElement {
+	headers := splitTableRow(lines[start])
+	var rows [][]string
+	for i := start + 2; i < len(lines) && isTableRow(strings.TrimSpace(lines[i])); i++ {
+		rows = append(rows, splitTableRow(lines[i]))
+	}
+	return StyledTable{Headers: headers, Rows: rows}
+}
+
+// tableRowCount returns how many lines parseMarkdownTable at start
+// consumes (header + separator + data rows), so ParseMarkdown's main
+// loop can advance past them.
+func tableRowCount(lines []string, start int) int {
+	count := 2 // header + separator
+	for i := start + 2; i < len(lines) && isTableRow(strings.TrimSpace(lines[i])); i++ {
+		count++
+	}
+	return count
+}
+
+func parseParagraph(lines []string, start int) (string, int) {
+	var buf []string
+	i := start
+	for i < len(lines) {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" ||
+			strings.HasPrefix(trimmed, "#") ||
+			strings.HasPrefix(trimmed, "```") ||
+			strings.HasPrefix(trimmed, ">") ||
+			isListItemLine(lines[i]) ||
+			(isTableRow(trimmed) && i+1 < len(lines) && isTableSeparator(lines[i+1])) {
+			break
+		}
+		buf = append(buf, trimmed)
+		i++
+	}
+	return strings.Join(buf, " "), i
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMarkdownHeadingAndParagraph(t *testing.T) {
+	src := "# Title\n\nSome text that\nwraps onto a second line.\n"
+	elements, err := ParseMarkdown(src)
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if len(elements) != 2 {
+		t.Fatalf("expected 2 elements, got %d", len(elements))
+	}
+	section, ok := elements[0].(Section)
+	if !ok || section.Title != "Title" {
+		t.Fatalf("expected Section{Title: Title}, got %#v", elements[0])
+	}
+	para, ok := elements[1].(Paragraph)
+	if !ok || para.Text != "Some text that wraps onto a second line." {
+		t.Fatalf("expected joined Paragraph, got %#v", elements[1])
+	}
+}
+
+func TestParseMarkdownFencedCode(t *testing.T) {
+	src := "```\nbv --recipe ready\necho done\n```\n"
+	elements, err := ParseMarkdown(src)
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if len(elements) != 1 {
+		t.Fatalf("expected 1 element, got %d", len(elements))
+	}
+	code, ok := elements[0].(Code)
+	if !ok || code.Text != "bv --recipe ready\necho done" {
+		t.Fatalf("unexpected Code element: %#v", elements[0])
+	}
+}
+
+func TestParseMarkdownUnterminatedFenceErrors(t *testing.T) {
+	if _, err := ParseMarkdown("```\nunterminated\n"); err == nil {
+		t.Fatal("expected an error for an unterminated fenced code block")
+	}
+}
+
+func TestParseMarkdownFlatBulletList(t *testing.T) {
+	src := "- first\n- second\n- third\n"
+	elements, err := ParseMarkdown(src)
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if len(elements) != 1 {
+		t.Fatalf("expected 1 element, got %d", len(elements))
+	}
+	bullet, ok := elements[0].(Bullet)
+	if !ok {
+		t.Fatalf("expected Bullet, got %#v", elements[0])
+	}
+	want := []string{"first", "second", "third"}
+	if !reflect.DeepEqual(bullet.Items, want) {
+		t.Fatalf("expected items %v, got %v", want, bullet.Items)
+	}
+}
+
+func TestParseMarkdownNestedListBecomesTree(t *testing.T) {
+	src := "- Graph\n  - Blockers point left\n  - Dependents point right\n- Tree\n"
+	elements, err := ParseMarkdown(src)
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if len(elements) != 1 {
+		t.Fatalf("expected 1 element, got %d", len(elements))
+	}
+	tr, ok := elements[0].(Tree)
+	if !ok {
+		t.Fatalf("expected Tree, got %#v", elements[0])
+	}
+	if len(tr.Children) != 2 {
+		t.Fatalf("expected 2 top-level nodes, got %d", len(tr.Children))
+	}
+	if tr.Children[0].Label != "Graph" || len(tr.Children[0].Children) != 2 {
+		t.Fatalf("expected Graph with 2 children, got %#v", tr.Children[0])
+	}
+	if tr.Children[0].Children[0].Label != "Blockers point left" {
+		t.Fatalf("unexpected first child: %#v", tr.Children[0].Children[0])
+	}
+	if tr.Children[1].Label != "Tree" || len(tr.Children[1].Children) != 0 {
+		t.Fatalf("expected leaf Tree node, got %#v", tr.Children[1])
+	}
+}
+
+func TestParseMarkdownTable(t *testing.T) {
+	src := "| Key | Action |\n| --- | --- |\n| b | Focus board |\n| g | Focus graph |\n"
+	elements, err := ParseMarkdown(src)
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if len(elements) != 1 {
+		t.Fatalf("expected 1 element, got %d", len(elements))
+	}
+	table, ok := elements[0].(StyledTable)
+	if !ok {
+		t.Fatalf("expected StyledTable, got %#v", elements[0])
+	}
+	wantHeaders := []string{"Key", "Action"}
+	if !reflect.DeepEqual(table.Headers, wantHeaders) {
+		t.Fatalf("expected headers %v, got %v", wantHeaders, table.Headers)
+	}
+	if len(table.Rows) != 2 || table.Rows[0][0] != "b" || table.Rows[1][1] != "Focus graph" {
+		t.Fatalf("unexpected rows: %#v", table.Rows)
+	}
+}
+
+func TestParseMarkdownBlockquoteBoxes(t *testing.T) {
+	src := "> !TIP\n> Press ? for help.\n\n> !WARN\n> Be careful.\n\n> !NOTE\n> FYI.\n\n> Just a quote.\n"
+	elements, err := ParseMarkdown(src)
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if len(elements) != 4 {
+		t.Fatalf("expected 4 elements, got %d", len(elements))
+	}
+	if tip, ok := elements[0].(Tip); !ok || tip.Text != "Press ? for help." {
+		t.Fatalf("expected Tip, got %#v", elements[0])
+	}
+	if warn, ok := elements[1].(Warning); !ok || warn.Text != "Be careful." {
+		t.Fatalf("expected Warning, got %#v", elements[1])
+	}
+	if note, ok := elements[2].(Note); !ok || note.Text != "FYI." {
+		t.Fatalf("expected Note, got %#v", elements[2])
+	}
+	if para, ok := elements[3].(Paragraph); !ok || para.Text != "Just a quote." {
+		t.Fatalf("expected plain blockquote to fall back to Paragraph, got %#v", elements[3])
+	}
+}
+
+func TestLoadTutorialPagesParsesEmbeddedExample(t *testing.T) {
+	pages, err := LoadTutorialPages()
+	if err != nil {
+		t.Fatalf("LoadTutorialPages: %v", err)
+	}
+	elements, ok := pages["example"]
+	if !ok {
+		t.Fatal("expected an \"example\" tutorial page")
+	}
+	if len(elements) == 0 {
+		t.Fatal("expected the example page to parse into at least one element")
+	}
+}
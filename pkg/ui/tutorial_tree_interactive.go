@@ -0,0 +1,243 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/tree"
+)
+
+// Glyphs InteractiveTree prefixes onto a row to show whether it has
+// collapsed children (lipgloss/tree has no built-in open/closed concept,
+// so these are baked into the rendered label instead of a tree.Style).
+const (
+	treeOpenCharacter   = "▾"
+	treeClosedCharacter = "▸"
+	treeLeafCharacter   = " "
+)
+
+// interactiveTreeRow is one visible row of an InteractiveTree once the
+// current expanded set has been applied: path addresses the node (as
+// dot-joined child indices from the root, e.g. "0.2.1") for the
+// expanded/checked lookups, and node is the underlying data.
+type interactiveTreeRow struct {
+	path string
+	node TutorialTreeNode
+}
+
+// InteractiveTree extends the static Tree element with navigation state:
+// a cursor tracks the focused row, an expanded set tracks which subtrees
+// are open, and a checked set tracks which rows have had their checkbox
+// toggled. It has no Update method and binds no keys: the state changes
+// only through MoveUp, MoveDown, Expand, Collapse, ToggleExpand and
+// ToggleChecked, and no selection message is emitted (Selected reports
+// the node under the cursor). Tutorial pages and the dependency
+// graph panel can both browse the same TutorialTreeNode data through
+// this one widget instead of each hand-rolling cursor/scroll handling,
+// mirroring how ScrollableColumn centralizes that for board columns.
+type InteractiveTree struct {
+	Root     string
+	Children []TutorialTreeNode
+
+	cursor   int
+	expanded map[string]bool
+	checked  map[string]bool
+
+	rows []interactiveTreeRow
+}
+
+// NewInteractiveTree returns an InteractiveTree rooted at root with
+// children, every node initially collapsed.
+func NewInteractiveTree(root string, children []TutorialTreeNode) *InteractiveTree {
+	t := &InteractiveTree{
+		Root:     root,
+		Children: children,
+		expanded: map[string]bool{},
+		checked:  map[string]bool{},
+	}
+	t.rebuildRows()
+	return t
+}
+
+// NewInteractiveTreeFromTree adapts a static Tree element (e.g. one
+// ParseMarkdown produced from a nested markdown list) into an
+// InteractiveTree with the same content, so a tutorial page's tree can
+// be browsed with InteractiveTree's cursor and expand methods.
+func NewInteractiveTreeFromTree(t Tree) *InteractiveTree {
+	return NewInteractiveTree(t.Root, t.Children)
+}
+
+// rebuildRows recomputes the flattened, depth-first list of visible rows
+// from Children and the current expanded set, then clamps the cursor to
+// the new bounds.
+func (t *InteractiveTree) rebuildRows() {
+	t.rows = t.rows[:0]
+	for i, child := range t.Children {
+		t.appendRows(child, fmt.Sprintf("%d", i))
+	}
+	if t.cursor >= len(t.rows) {
+		t.cursor = len(t.rows) - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+}
+
+func (t *InteractiveTree) appendRows(node TutorialTreeNode, path string) {
+	t.rows = append(t.rows, interactiveTreeRow{path: path, node: node})
+	if !t.expanded[path] {
+		return
+	}
+	for i, child := range node.Children {
+		t.appendRows(child, fmt.Sprintf("%s.%d", path, i))
+	}
+}
+
+// MoveDown moves the cursor to the next visible row.
+func (t *InteractiveTree) MoveDown() {
+	if t.cursor < len(t.rows)-1 {
+		t.cursor++
+	}
+}
+
+// MoveUp moves the cursor to the previous visible row.
+func (t *InteractiveTree) MoveUp() {
+	if t.cursor > 0 {
+		t.cursor--
+	}
+}
+
+// Expand opens the cursor's row, revealing its children. A no-op on a
+// leaf row or one that's already open.
+func (t *InteractiveTree) Expand() {
+	row, ok := t.cursorRow()
+	if !ok || len(row.node.Children) == 0 || t.expanded[row.path] {
+		return
+	}
+	t.expanded[row.path] = true
+	t.rebuildRows()
+}
+
+// Collapse closes the cursor's row, hiding its children. A no-op on a
+// row that's already closed.
+func (t *InteractiveTree) Collapse() {
+	row, ok := t.cursorRow()
+	if !ok || !t.expanded[row.path] {
+		return
+	}
+	delete(t.expanded, row.path)
+	t.rebuildRows()
+}
+
+// ToggleExpand opens the cursor's row if it's collapsed, or closes it if
+// it's open.
+func (t *InteractiveTree) ToggleExpand() {
+	row, ok := t.cursorRow()
+	if !ok {
+		return
+	}
+	if t.expanded[row.path] {
+		t.Collapse()
+	} else {
+		t.Expand()
+	}
+}
+
+// ToggleChecked flips the checkbox marker on the cursor's row.
+func (t *InteractiveTree) ToggleChecked() {
+	row, ok := t.cursorRow()
+	if !ok {
+		return
+	}
+	t.checked[row.path] = !t.checked[row.path]
+}
+
+// Selected returns the node under the cursor, or false if the tree has
+// no rows.
+func (t *InteractiveTree) Selected() (TutorialTreeNode, bool) {
+	row, ok := t.cursorRow()
+	return row.node, ok
+}
+
+func (t *InteractiveTree) cursorRow() (interactiveTreeRow, bool) {
+	if t.cursor < 0 || t.cursor >= len(t.rows) {
+		return interactiveTreeRow{}, false
+	}
+	return t.rows[t.cursor], true
+}
+
+// Render draws the tree via lipgloss/tree: each row is prefixed with a
+// checkbox marker and a treeOpenCharacter/treeClosedCharacter glyph showing
+// whether it has collapsed children, collapsed rows omit their children
+// entirely, and the row at the cursor gets a background highlight.
+func (t *InteractiveTree) Render(theme Theme, width int) string {
+	r := theme.Renderer
+
+	rootStyle := r.NewStyle().Foreground(theme.Primary).Bold(true)
+	enumStyle := r.NewStyle().Foreground(theme.Muted)
+	itemStyle := r.NewStyle().Foreground(theme.Base.GetForeground())
+	cursorStyle := itemStyle.Background(theme.BgHighlight)
+
+	cursorPath := ""
+	if row, ok := t.cursorRow(); ok {
+		cursorPath = row.path
+	}
+
+	tr := tree.Root(rootStyle.Render(t.Root)).
+		EnumeratorStyle(enumStyle).
+		ItemStyleFunc(rowStyleFunc(itemStyle, cursorStyle, cursorPath, ""))
+
+	for i, child := range t.Children {
+		path := fmt.Sprintf("%d", i)
+		tr = tr.Child(t.buildInteractiveNode(child, path, itemStyle, enumStyle, cursorStyle, cursorPath))
+	}
+
+	return tr.String()
+}
+
+func (t *InteractiveTree) buildInteractiveNode(node TutorialTreeNode, path string, itemStyle, enumStyle, cursorStyle lipgloss.Style, cursorPath string) *tree.Tree {
+	sub := tree.Root(t.rowLabel(node, path)).
+		EnumeratorStyle(enumStyle).
+		ItemStyleFunc(rowStyleFunc(itemStyle, cursorStyle, cursorPath, path))
+
+	if t.expanded[path] {
+		for i, child := range node.Children {
+			childPath := fmt.Sprintf("%s.%d", path, i)
+			sub = sub.Child(t.buildInteractiveNode(child, childPath, itemStyle, enumStyle, cursorStyle, cursorPath))
+		}
+	}
+	return sub
+}
+
+// rowLabel formats a node's checkbox marker and open/closed glyph ahead
+// of its text.
+func (t *InteractiveTree) rowLabel(node TutorialTreeNode, path string) string {
+	checkbox := "[ ]"
+	if t.checked[path] {
+		checkbox = "[x]"
+	}
+	glyph := treeLeafCharacter
+	if len(node.Children) > 0 {
+		glyph = treeClosedCharacter
+		if t.expanded[path] {
+			glyph = treeOpenCharacter
+		}
+	}
+	return checkbox + " " + glyph + " " + node.Label
+}
+
+// rowStyleFunc returns an ItemStyleFunc for the tree node at parentPath:
+// its i-th child is highlighted with cursorStyle if that child's path is
+// cursorPath, otherwise it renders with itemStyle.
+func rowStyleFunc(itemStyle, cursorStyle lipgloss.Style, cursorPath, parentPath string) func(tree.Children, int) lipgloss.Style {
+	return func(_ tree.Children, i int) lipgloss.Style {
+		childPath := fmt.Sprintf("%d", i)
+		if parentPath != "" {
+			childPath = fmt.Sprintf("%s.%d", parentPath, i)
+		}
+		if childPath == cursorPath {
+			return cursorStyle
+		}
+		return itemStyle
+	}
+}
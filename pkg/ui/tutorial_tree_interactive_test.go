@@ -0,0 +1,111 @@
+package ui
+
+import "testing"
+
+func sampleInteractiveTree() *InteractiveTree {
+	return NewInteractiveTree("Views", []TutorialTreeNode{
+		{Label: "Graph", Children: []TutorialTreeNode{
+			{Label: "Blockers point left"},
+			{Label: "Dependents point right"},
+		}},
+		{Label: "Tree"},
+	})
+}
+
+func TestNewInteractiveTreeStartsCollapsedAtFirstRow(t *testing.T) {
+	tr := sampleInteractiveTree()
+	if len(tr.rows) != 2 {
+		t.Fatalf("expected 2 visible rows while collapsed, got %d", len(tr.rows))
+	}
+	selected, ok := tr.Selected()
+	if !ok || selected.Label != "Graph" {
+		t.Fatalf("expected cursor on Graph, got %#v (ok=%v)", selected, ok)
+	}
+}
+
+func TestInteractiveTreeExpandRevealsChildren(t *testing.T) {
+	tr := sampleInteractiveTree()
+	tr.Expand()
+	if len(tr.rows) != 4 {
+		t.Fatalf("expected 4 visible rows after expanding Graph, got %d", len(tr.rows))
+	}
+	tr.MoveDown()
+	selected, ok := tr.Selected()
+	if !ok || selected.Label != "Blockers point left" {
+		t.Fatalf("expected cursor on first child, got %#v (ok=%v)", selected, ok)
+	}
+}
+
+func TestInteractiveTreeCollapseHidesChildrenAndKeepsCursorInBounds(t *testing.T) {
+	tr := sampleInteractiveTree()
+	tr.Expand()
+	tr.MoveDown()
+	tr.MoveDown() // cursor on "Dependents point right"
+	tr.MoveUp()
+	tr.MoveUp()
+	tr.Collapse() // cursor back on "Graph", now collapsed
+	if len(tr.rows) != 2 {
+		t.Fatalf("expected 2 visible rows after collapsing, got %d", len(tr.rows))
+	}
+	selected, ok := tr.Selected()
+	if !ok || selected.Label != "Graph" {
+		t.Fatalf("expected cursor clamped onto Graph, got %#v (ok=%v)", selected, ok)
+	}
+}
+
+func TestInteractiveTreeToggleExpandOpensAndCloses(t *testing.T) {
+	tr := sampleInteractiveTree()
+	tr.ToggleExpand()
+	if len(tr.rows) != 4 {
+		t.Fatalf("expected expand via toggle, got %d rows", len(tr.rows))
+	}
+	tr.ToggleExpand()
+	if len(tr.rows) != 2 {
+		t.Fatalf("expected collapse via toggle, got %d rows", len(tr.rows))
+	}
+}
+
+func TestInteractiveTreeExpandOnLeafIsNoop(t *testing.T) {
+	tr := sampleInteractiveTree()
+	tr.MoveDown() // cursor on leaf "Tree"
+	tr.Expand()
+	if len(tr.rows) != 2 {
+		t.Fatalf("expected Expand on a leaf to be a no-op, got %d rows", len(tr.rows))
+	}
+}
+
+func TestInteractiveTreeMoveUpDownClampsAtBounds(t *testing.T) {
+	tr := sampleInteractiveTree()
+	tr.MoveUp()
+	if selected, ok := tr.Selected(); !ok || selected.Label != "Graph" {
+		t.Fatalf("expected MoveUp at top to stay put, got %#v (ok=%v)", selected, ok)
+	}
+	tr.MoveDown()
+	tr.MoveDown()
+	if selected, ok := tr.Selected(); !ok || selected.Label != "Tree" {
+		t.Fatalf("expected MoveDown at bottom to stay put, got %#v (ok=%v)", selected, ok)
+	}
+}
+
+func TestInteractiveTreeToggleChecked(t *testing.T) {
+	tr := sampleInteractiveTree()
+	if tr.checked["0"] {
+		t.Fatal("expected row to start unchecked")
+	}
+	tr.ToggleChecked()
+	if !tr.checked["0"] {
+		t.Fatal("expected ToggleChecked to check the cursor row")
+	}
+	tr.ToggleChecked()
+	if tr.checked["0"] {
+		t.Fatal("expected a second ToggleChecked to uncheck the cursor row")
+	}
+}
+
+func TestNewInteractiveTreeFromTreeCopiesContent(t *testing.T) {
+	static := Tree{Root: "Views", Children: []TutorialTreeNode{{Label: "Graph"}}}
+	tr := NewInteractiveTreeFromTree(static)
+	if tr.Root != "Views" || len(tr.Children) != 1 || tr.Children[0].Label != "Graph" {
+		t.Fatalf("expected adapted tree to copy static content, got %#v", tr)
+	}
+}
@@ -156,7 +156,7 @@ var RepoColors = []lipgloss.AdaptiveColor{
 func GetRepoColor(prefix string) lipgloss.AdaptiveColor {
 	if prefix == "" {
 		// Return a neutral muted color for empty prefix
-		return lipgloss.Color("#888888")
+		return lipgloss.AdaptiveColor{Light: "#888888", Dark: "#888888"}
 	}
 	// Simple hash based on prefix characters
 	hash := 0
@@ -169,9 +169,12 @@ func GetRepoColor(prefix string) lipgloss.AdaptiveColor {
 	return RepoColors[hash%len(RepoColors)]
 }
 
-// RenderRepoBadge creates a compact colored badge for a repository prefix
-// Example: "api" -> "[API]" with distinctive color
-func RenderRepoBadge(prefix string) string {
+// RenderRepoBadge creates a compact colored badge for a repository
+// prefix, e.g. "api" -> "[API]" with a distinctive color. Renders
+// through styles.Renderer (see Styles, DetectRenderer) rather than a
+// bare lipgloss.NewStyle(), so a NO_COLOR run or a redirected stdout
+// produces clean text instead of leaking ANSI escapes.
+func RenderRepoBadge(prefix string, styles Styles) string {
 	if prefix == "" {
 		return ""
 	}
@@ -182,8 +185,5 @@ func RenderRepoBadge(prefix string) string {
 	}
 
 	color := GetRepoColor(prefix)
-	return lipgloss.NewStyle().
-		Foreground(color).
-		Bold(true).
-		Render("[" + display + "]")
+	return styles.RepoBadgeStyle.Foreground(color).Render("[" + display + "]")
 }
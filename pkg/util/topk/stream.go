@@ -0,0 +1,260 @@
+package topk
+
+import "container/heap"
+
+// DefaultOverSampling is StreamCollector's default ratio of tracked
+// counters to K when NewStreamCollector is given an overSampling of 0.
+// A larger ratio trades memory for a tighter error bound on each
+// estimate.
+const DefaultOverSampling = 10
+
+// StreamEstimate pairs an item with its Space-Saving counter value and
+// the maximum amount by which that counter could overestimate the
+// item's true weight.
+type StreamEstimate[T any] struct {
+	Item     T
+	Estimate float64
+	Error    float64
+}
+
+// StreamCollector tracks approximate top-K heavy hitters over an
+// unbounded item universe using the Space-Saving algorithm of Metwally,
+// Agrawal & El Abbadi ("Efficient Computation of Frequent and Top-k
+// Elements in Data Streams", ICDT 2005).
+//
+// Unlike Collector, which heap-tracks every distinct item it has ever
+// seen, StreamCollector keeps a fixed m = k*overSampling counters: once
+// that budget is full, a new item evicts the current minimum counter and
+// inherits its count, so memory never grows with the size of the item
+// universe. Each retained item's counter is guaranteed to be within the
+// evicted minimum's value of its true count, and that bound travels with
+// the item as its Error.
+//
+// A zero-value StreamCollector is not usable; use NewStreamCollector.
+type StreamCollector[T any] struct {
+	k    int
+	m    int
+	id   func(T) string
+	h    *streamMinHeap[T]
+	slot map[string]*streamCounter[T] // key -> its live counter, wherever the heap has moved it
+}
+
+type streamCounter[T any] struct {
+	key       string
+	item      T
+	count     float64
+	err       float64
+	heapIndex int
+}
+
+// NewStreamCollector creates a StreamCollector for the top k items,
+// tracking k*overSampling counters (DefaultOverSampling if overSampling
+// <= 0). id must return a stable, unique identity string for each
+// distinct logical item; it is used to find an item's existing counter
+// in O(1).
+//
+// If k <= 0, the collector will not collect any items.
+func NewStreamCollector[T any](k, overSampling int, id func(T) string) *StreamCollector[T] {
+	if k < 0 {
+		k = 0
+	}
+	if overSampling <= 0 {
+		overSampling = DefaultOverSampling
+	}
+	m := k * overSampling
+	return &StreamCollector[T]{
+		k:    k,
+		m:    m,
+		id:   id,
+		h:    &streamMinHeap[T]{},
+		slot: make(map[string]*streamCounter[T], m),
+	}
+}
+
+// Add records a weight for item, identified by id(item). If the item
+// already holds a counter, the weight is added to it. Otherwise, if
+// fewer than m counters are in use, item starts a fresh counter at
+// weight. Otherwise item evicts the current minimum counter: it inherits
+// that counter's value plus weight, and records the evicted value as its
+// Error, the maximum amount its estimate could be overcounting.
+//
+// Time complexity: O(log m).
+func (c *StreamCollector[T]) Add(item T, weight float64) {
+	if c.k <= 0 || c.m <= 0 {
+		return
+	}
+	key := c.id(item)
+
+	if ctr, ok := c.slot[key]; ok {
+		ctr.count += weight
+		heap.Fix(c.h, ctr.heapIndex)
+		return
+	}
+
+	if c.h.Len() < c.m {
+		ctr := &streamCounter[T]{key: key, item: item, count: weight}
+		heap.Push(c.h, ctr)
+		c.slot[key] = ctr
+		return
+	}
+
+	min := c.h.items[0]
+	delete(c.slot, min.key)
+	min.key = key
+	min.item = item
+	min.err = min.count
+	min.count += weight
+	c.slot[key] = min
+	heap.Fix(c.h, min.heapIndex)
+}
+
+// Len returns the number of distinct items currently tracked.
+func (c *StreamCollector[T]) Len() int {
+	return c.h.Len()
+}
+
+// Results returns up to k items in descending estimated-weight order,
+// each paired with its estimate and error bound.
+//
+// Time complexity: O(m^2) worst case, from sortCounters' insertion sort.
+func (c *StreamCollector[T]) Results() []StreamEstimate[T] {
+	sorted := c.sortedCounters()
+	if len(sorted) > c.k {
+		sorted = sorted[:c.k]
+	}
+	out := make([]StreamEstimate[T], len(sorted))
+	for i, ctr := range sorted {
+		out[i] = StreamEstimate[T]{Item: ctr.item, Estimate: ctr.count, Error: ctr.err}
+	}
+	return out
+}
+
+// Guaranteed returns the subset of Results whose estimate minus error
+// still exceeds the (k+1)-th counter's value, i.e. items that are
+// provably members of the true top-K regardless of how the Space-Saving
+// approximation broke on the rest of the stream. It may return fewer
+// than k items, including none, when the stream hasn't separated enough
+// for any counter to clear that bar.
+func (c *StreamCollector[T]) Guaranteed() []StreamEstimate[T] {
+	sorted := c.sortedCounters()
+	if len(sorted) <= c.k {
+		out := make([]StreamEstimate[T], len(sorted))
+		for i, ctr := range sorted {
+			out[i] = StreamEstimate[T]{Item: ctr.item, Estimate: ctr.count, Error: ctr.err}
+		}
+		return out
+	}
+
+	threshold := sorted[c.k].count
+	top := sorted[:c.k]
+	out := make([]StreamEstimate[T], 0, c.k)
+	for _, ctr := range top {
+		if ctr.count-ctr.err > threshold {
+			out = append(out, StreamEstimate[T]{Item: ctr.item, Estimate: ctr.count, Error: ctr.err})
+		}
+	}
+	return out
+}
+
+// Merge folds other's counters into c, so parallel shards can each
+// accumulate their own StreamCollector and combine the results. An item
+// both track gets the sum of their counts and the sum of their Errors.
+// An item only other tracks takes a free counter if c has one; otherwise
+// it replaces c's minimum counter, adding that counter's value to its
+// Error, unless its count is no larger than that minimum, in which case
+// it is dropped.
+func (c *StreamCollector[T]) Merge(other *StreamCollector[T]) {
+	if other == nil {
+		return
+	}
+	for _, ctr := range other.h.items {
+		if existing, ok := c.slot[ctr.key]; ok {
+			existing.count += ctr.count
+			existing.err += ctr.err
+			heap.Fix(c.h, existing.heapIndex)
+			continue
+		}
+
+		if c.h.Len() < c.m {
+			fresh := &streamCounter[T]{key: ctr.key, item: ctr.item, count: ctr.count, err: ctr.err}
+			heap.Push(c.h, fresh)
+			c.slot[ctr.key] = fresh
+			continue
+		}
+
+		min := c.h.items[0]
+		if ctr.count <= min.count {
+			// Merging in an item no bigger than our current floor can't
+			// change the tracked set; folding it in would only inflate
+			// our floor's error for no benefit.
+			continue
+		}
+		delete(c.slot, min.key)
+		min.key = ctr.key
+		min.item = ctr.item
+		min.err = min.count + ctr.err
+		min.count += ctr.count
+		c.slot[ctr.key] = min
+		heap.Fix(c.h, min.heapIndex)
+	}
+}
+
+// sortedCounters returns every tracked counter in descending count
+// order, breaking ties by key for determinism.
+func (c *StreamCollector[T]) sortedCounters() []*streamCounter[T] {
+	sorted := make([]*streamCounter[T], len(c.h.items))
+	copy(sorted, c.h.items)
+	sortCounters(sorted)
+	return sorted
+}
+
+func sortCounters[T any](items []*streamCounter[T]) {
+	// Insertion sort keeps this allocation-free for the small (k*overSampling)
+	// counter sets StreamCollector is sized for; swap for sort.Slice if m grows large.
+	for i := 1; i < len(items); i++ {
+		for j := i; j > 0 && less(items[j], items[j-1]); j-- {
+			items[j], items[j-1] = items[j-1], items[j]
+		}
+	}
+}
+
+func less[T any](a, b *streamCounter[T]) bool {
+	if a.count != b.count {
+		return a.count > b.count
+	}
+	return a.key < b.key
+}
+
+// streamMinHeap implements heap.Interface for a min-heap of
+// streamCounters, indexed by count so StreamCollector can find and
+// replace the current minimum in O(log m).
+type streamMinHeap[T any] struct {
+	items []*streamCounter[T]
+}
+
+func (h *streamMinHeap[T]) Len() int { return len(h.items) }
+
+func (h *streamMinHeap[T]) Less(i, j int) bool {
+	return h.items[i].count < h.items[j].count
+}
+
+func (h *streamMinHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].heapIndex = i
+	h.items[j].heapIndex = j
+}
+
+func (h *streamMinHeap[T]) Push(x any) {
+	ctr := x.(*streamCounter[T])
+	ctr.heapIndex = len(h.items)
+	h.items = append(h.items, ctr)
+}
+
+func (h *streamMinHeap[T]) Pop() any {
+	old := h.items
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	h.items = old[:n-1]
+	return x
+}
@@ -0,0 +1,80 @@
+package topk
+
+import "testing"
+
+func TestStreamCollectorTracksExactCountsUnderBudget(t *testing.T) {
+	c := NewStreamCollector[string](3, 10, func(s string) string { return s })
+	for _, item := range []string{"a", "a", "a", "b", "b", "c"} {
+		c.Add(item, 1)
+	}
+
+	results := c.Results()
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if results[0].Item != "a" || results[0].Estimate != 3 {
+		t.Errorf("expected a:3 first, got %+v", results[0])
+	}
+	for _, r := range results {
+		if r.Error != 0 {
+			t.Errorf("expected zero error while under budget, got %+v", r)
+		}
+	}
+}
+
+func TestStreamCollectorEvictsAndBoundsError(t *testing.T) {
+	c := NewStreamCollector[string](1, 2, func(s string) string { return s })
+	c.Add("a", 5)
+	c.Add("b", 1)
+	// Budget (m=2) is full; "c" evicts the current minimum ("b", count 1)
+	// and inherits count 1 plus its own weight.
+	c.Add("c", 1)
+
+	results := c.Results()
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Item != "a" {
+		t.Errorf("expected heaviest item a to survive, got %+v", results[0])
+	}
+}
+
+func TestStreamCollectorGuaranteedRequiresSeparation(t *testing.T) {
+	c := NewStreamCollector[string](1, 10, func(s string) string { return s })
+	for i := 0; i < 100; i++ {
+		c.Add("heavy", 1)
+	}
+	c.Add("light", 1)
+
+	guaranteed := c.Guaranteed()
+	if len(guaranteed) != 1 || guaranteed[0].Item != "heavy" {
+		t.Errorf("expected heavy to be guaranteed top-1, got %+v", guaranteed)
+	}
+}
+
+func TestStreamCollectorMergeCombinesShardCounts(t *testing.T) {
+	a := NewStreamCollector[string](2, 10, func(s string) string { return s })
+	a.Add("x", 3)
+	a.Add("y", 1)
+
+	b := NewStreamCollector[string](2, 10, func(s string) string { return s })
+	b.Add("x", 2)
+	b.Add("z", 4)
+
+	a.Merge(b)
+
+	results := a.Results()
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	byItem := make(map[string]float64, len(results))
+	for _, r := range results {
+		byItem[r.Item] = r.Estimate
+	}
+	if byItem["x"] != 5 {
+		t.Errorf("expected merged x estimate 5, got %v", byItem["x"])
+	}
+	if byItem["z"] != 4 {
+		t.Errorf("expected z estimate 4, got %v", byItem["z"])
+	}
+}
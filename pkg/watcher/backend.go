@@ -0,0 +1,93 @@
+package watcher
+
+import (
+	"fmt"
+	"time"
+)
+
+// WatcherBackend selects the mechanism a watched directory uses to
+// detect changes.
+type WatcherBackend int
+
+const (
+	// BackendAuto lets SelectBackend choose based on the directory's
+	// detected FilesystemInfo.
+	BackendAuto WatcherBackend = iota
+	// BackendNative uses the platform's native change-notification API
+	// (inotify, FSEvents, ReadDirectoryChangesW).
+	BackendNative
+	// BackendPolling uses PollWatcher's mtime-based scanning instead,
+	// for mounts where native notifications are unreliable or
+	// unavailable.
+	BackendPolling
+)
+
+func (b WatcherBackend) String() string {
+	switch b {
+	case BackendNative:
+		return "native"
+	case BackendPolling:
+		return "polling"
+	default:
+		return "auto"
+	}
+}
+
+// DefaultPollInterval is the polling interval WatcherOptions falls back
+// to when PollInterval is unset.
+const DefaultPollInterval = 2 * time.Second
+
+// WatcherOptions configures how a directory's backend is chosen.
+type WatcherOptions struct {
+	// PollInterval is how often a BackendPolling directory is rescanned.
+	// A non-positive value falls back to DefaultPollInterval.
+	PollInterval time.Duration
+	// ForceBackend overrides the filesystem-type-based decision, so
+	// tests (and users working around a misdetected mount) can pin a
+	// specific backend regardless of what DetectFilesystemInfo reports.
+	ForceBackend WatcherBackend
+}
+
+// BackendDecision is SelectBackend's result: which backend to use for a
+// directory, at what polling interval, and a human-readable Reason
+// suitable for a watcher's startup log line.
+type BackendDecision struct {
+	Backend  WatcherBackend
+	Interval time.Duration
+	Reason   string
+}
+
+// SelectBackend decides which backend a newly-added directory at path
+// should use, given its detected FilesystemInfo and opts. A non-auto
+// opts.ForceBackend always wins; otherwise the decision follows
+// info.WatchPolicy().ForcePolling, which already accounts for both
+// info.Type (NFS, SMB/CIFS, FUSE, ...) and mount-option overrides like
+// NFS's "cache=none".
+func SelectBackend(path string, info FilesystemInfo, opts WatcherOptions) BackendDecision {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	if opts.ForceBackend != BackendAuto {
+		return BackendDecision{
+			Backend:  opts.ForceBackend,
+			Interval: interval,
+			Reason:   fmt.Sprintf("%s: backend forced to %s", path, opts.ForceBackend),
+		}
+	}
+
+	if info.WatchPolicy().ForcePolling {
+		return BackendDecision{
+			Backend:  BackendPolling,
+			Interval: interval,
+			Reason:   fmt.Sprintf("%s: detected %s filesystem, switching to polling backend (interval=%s)", path, info.Type, interval),
+		}
+	}
+
+	return BackendDecision{
+		Backend:  BackendNative,
+		Interval: interval,
+		Reason:   fmt.Sprintf("%s: detected %s filesystem, using native change notifications", path, info.Type),
+	}
+}
@@ -0,0 +1,71 @@
+package watcher
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatcherBackendString(t *testing.T) {
+	cases := map[WatcherBackend]string{
+		BackendAuto:    "auto",
+		BackendNative:  "native",
+		BackendPolling: "polling",
+	}
+	for backend, want := range cases {
+		if got := backend.String(); got != want {
+			t.Errorf("%v.String() = %q, want %q", int(backend), got, want)
+		}
+	}
+}
+
+func TestSelectBackendForcedOverridesDetection(t *testing.T) {
+	info := FilesystemInfo{Type: FSTypeLocal}
+	decision := SelectBackend("/some/path", info, WatcherOptions{ForceBackend: BackendPolling})
+
+	if decision.Backend != BackendPolling {
+		t.Errorf("expected a forced BackendPolling, got %v", decision.Backend)
+	}
+	if !strings.Contains(decision.Reason, "forced") {
+		t.Errorf("expected Reason to mention the override, got %q", decision.Reason)
+	}
+}
+
+func TestSelectBackendRemoteFilesystemPolls(t *testing.T) {
+	info := FilesystemInfo{Type: FSTypeNFS, SupportsInotify: false}
+	decision := SelectBackend("/mnt/nfs", info, WatcherOptions{})
+
+	if decision.Backend != BackendPolling {
+		t.Errorf("expected NFS to select BackendPolling, got %v", decision.Backend)
+	}
+	if !strings.Contains(decision.Reason, "nfs") {
+		t.Errorf("expected Reason to name the detected filesystem, got %q", decision.Reason)
+	}
+}
+
+func TestSelectBackendLocalFilesystemUsesNative(t *testing.T) {
+	info := FilesystemInfo{Type: FSTypeExt, SupportsInotify: true}
+	decision := SelectBackend("/home/user", info, WatcherOptions{})
+
+	if decision.Backend != BackendNative {
+		t.Errorf("expected ext to select BackendNative, got %v", decision.Backend)
+	}
+}
+
+func TestSelectBackendDefaultsPollInterval(t *testing.T) {
+	info := FilesystemInfo{Type: FSTypeSMB, SupportsInotify: false}
+	decision := SelectBackend("/mnt/smb", info, WatcherOptions{})
+
+	if decision.Interval != DefaultPollInterval {
+		t.Errorf("expected default poll interval %v, got %v", DefaultPollInterval, decision.Interval)
+	}
+}
+
+func TestSelectBackendHonorsConfiguredPollInterval(t *testing.T) {
+	info := FilesystemInfo{Type: FSTypeSMB, SupportsInotify: false}
+	decision := SelectBackend("/mnt/smb", info, WatcherOptions{PollInterval: 10 * time.Second})
+
+	if decision.Interval != 10*time.Second {
+		t.Errorf("expected configured poll interval 10s, got %v", decision.Interval)
+	}
+}
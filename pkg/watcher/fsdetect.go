@@ -3,6 +3,7 @@ package watcher
 import (
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // FilesystemType is a best-effort classification of a filesystem for watcher reliability.
@@ -17,6 +18,19 @@ const (
 	FSTypeSMB
 	FSTypeSSHFS
 	FSTypeFUSE
+	FSTypeCIFS
+	FSTypeCeph
+	FSTypeGluster
+	FSType9p
+	FSTypeOverlay
+	FSTypeVirtioFS
+	FSTypeAFS
+	FSTypeNCP
+	FSTypeProc
+	FSTypeTmpfs
+	FSTypeExt
+	FSTypeBtrfs
+	FSTypeZFS
 )
 
 func (t FilesystemType) String() string {
@@ -31,31 +45,99 @@ func (t FilesystemType) String() string {
 		return "sshfs"
 	case FSTypeFUSE:
 		return "fuse"
+	case FSTypeCIFS:
+		return "cifs"
+	case FSTypeCeph:
+		return "ceph"
+	case FSTypeGluster:
+		return "glusterfs"
+	case FSType9p:
+		return "9p"
+	case FSTypeOverlay:
+		return "overlay"
+	case FSTypeVirtioFS:
+		return "virtiofs"
+	case FSTypeAFS:
+		return "afs"
+	case FSTypeNCP:
+		return "ncpfs"
+	case FSTypeProc:
+		return "proc"
+	case FSTypeTmpfs:
+		return "tmpfs"
+	case FSTypeExt:
+		return "ext"
+	case FSTypeBtrfs:
+		return "btrfs"
+	case FSTypeZFS:
+		return "zfs"
 	default:
 		return "unknown"
 	}
 }
 
+// isRemoteFilesystem reports whether t is known to unreliably deliver
+// native change notifications (inotify on Linux, FSEvents on macOS,
+// ReadDirectoryChangesW on Windows), so the watcher should fall back to
+// polling rather than trusting the OS-level event stream.
 func isRemoteFilesystem(t FilesystemType) bool {
 	switch t {
-	case FSTypeNFS, FSTypeSMB, FSTypeSSHFS, FSTypeFUSE:
+	case FSTypeNFS, FSTypeSMB, FSTypeSSHFS, FSTypeFUSE,
+		FSTypeCIFS, FSTypeCeph, FSTypeGluster, FSType9p, FSTypeVirtioFS, FSTypeAFS, FSTypeNCP:
 		return true
 	default:
 		return false
 	}
 }
 
-var detectFilesystemTypeFunc = detectFilesystemType
+// FilesystemInfo is the full mount metadata the watcher uses to pick a
+// polling vs. native-event strategy, beyond the coarse FilesystemType
+// enum that DetectFilesystemType returns on its own. Fields beyond Type
+// are best-effort: a platform that can't determine MountPoint, Source,
+// or Options leaves them zero-valued rather than guessing.
+type FilesystemInfo struct {
+	// Type is the same coarse classification DetectFilesystemType
+	// returns, kept here so callers that only want the enum can use
+	// DetectFilesystemInfo(path).Type without a second detection pass.
+	Type FilesystemType
 
-// DetectFilesystemType best-effort detects the filesystem type for the given path.
-// If the path is a file, the containing directory is used.
-func DetectFilesystemType(path string) FilesystemType {
-	if path == "" {
-		return FSTypeUnknown
-	}
+	// MountPoint is the filesystem's mount point containing path.
+	MountPoint string
+	// Source is the device or remote endpoint backing the mount (e.g.
+	// "/dev/sda1", "server:/export", "\\\\server\\share").
+	Source string
+	// DeviceID is the platform's mount device identifier, where one
+	// exists - on Linux, the mountinfo "major:minor" pair. It is only
+	// reported; nothing in this package groups events by it. A bind mount
+	// reports the same DeviceID as the mount it was bound from, so
+	// DeviceID alone does not tell the two apart; MountPoint does.
+	DeviceID string
+	// Options is the mount's option set (e.g. "ro", "noatime",
+	// "actimeo=0"), keyed by option name. Value-less flag options map to
+	// the empty string.
+	Options map[string]string
 
-	// Statfs on the containing directory is generally more robust for our purposes,
-	// and also works when the target file doesn't exist yet.
+	// IsRemote reports whether Type is known to unreliably deliver
+	// native change notifications, mirroring isRemoteFilesystem(Type).
+	IsRemote bool
+	// IsCaseInsensitive and IsCaseSensitive report the mount's filename
+	// comparison behavior where the platform can determine it. Both may
+	// be false if the platform has no reliable way to tell.
+	IsCaseInsensitive bool
+	IsCaseSensitive   bool
+	// SupportsInotify reports whether the platform's native filesystem
+	// event mechanism (inotify, FSEvents, ReadDirectoryChangesW) is
+	// expected to work reliably for this mount. Every platform sets it to
+	// !IsRemote, so remote filesystems report false here.
+	SupportsInotify bool
+}
+
+var detectFilesystemInfoFunc = detectFilesystemInfo
+
+// resolveWatchTarget resolves path to the directory statfs should be
+// called on: path itself if it's already a directory, its containing
+// directory otherwise (this also works when path doesn't exist yet).
+func resolveWatchTarget(path string) string {
 	target := path
 	if info, err := os.Stat(path); err == nil {
 		if !info.IsDir() {
@@ -67,6 +149,107 @@ func DetectFilesystemType(path string) FilesystemType {
 			target = path
 		}
 	}
+	return target
+}
+
+// DetectFilesystemInfo best-effort detects the full FilesystemInfo for
+// the given path. If the path is a file, the containing directory is used.
+func DetectFilesystemInfo(path string) FilesystemInfo {
+	if path == "" {
+		return FilesystemInfo{Type: FSTypeUnknown}
+	}
+	return detectFilesystemInfoFunc(resolveWatchTarget(path))
+}
+
+// DetectFilesystemType best-effort detects the filesystem type for the given path.
+// If the path is a file, the containing directory is used.
+//
+// Deprecated: prefer DetectFilesystemInfo, which reports this same Type
+// alongside mount point, source, and watch-strategy hints. Kept for
+// callers that only need the coarse enum.
+func DetectFilesystemType(path string) FilesystemType {
+	return DetectFilesystemInfo(path).Type
+}
+
+const (
+	// defaultDebounceWindow is how long the watcher waits for related
+	// events to settle before acting on them, for mounts with no
+	// reason to distrust normal caching behavior.
+	defaultDebounceWindow = 500 * time.Millisecond
+	// lowDebounceWindow applies when the mount's own options say its
+	// attribute cache is already disabled or near-zero (NFS noac /
+	// actimeo=0): there's nothing left for our debounce to wait out, so
+	// a short window just adds latency.
+	lowDebounceWindow = 50 * time.Millisecond
+)
+
+// WatchPolicy is the set of watcher behavior decisions derived from a
+// FilesystemInfo's type and mount options, rather than from Type alone.
+type WatchPolicy struct {
+	// ForcePolling reports whether the watcher should use polling
+	// instead of native change notifications for this mount.
+	ForcePolling bool
+	// DisableStatCache reports whether the watcher should treat every
+	// stat as authoritative instead of trusting a short-lived cache,
+	// because the mount's own attribute cache is already disabled.
+	DisableStatCache bool
+	// DebounceWindow is how long to wait for related events to settle
+	// before acting on them.
+	DebounceWindow time.Duration
+	// SkipWriteLivenessCheck reports whether the watcher should skip
+	// probing liveness with a test write, because the mount is read-only.
+	SkipWriteLivenessCheck bool
+	// TrustAtime reports whether access-time updates are a reliable
+	// change-detection signal for this mount. relatime/noatime mounts
+	// update atime lazily or not at all, so callers should fall back to
+	// mtime/ctime-only heuristics when this is false.
+	TrustAtime bool
+}
+
+// WatchPolicy derives the watcher's behavior for this mount from its
+// Type and parsed Options, refining the coarse SupportsInotify signal
+// with option-level detail mountinfo (or the platform equivalent)
+// exposes.
+func (info FilesystemInfo) WatchPolicy() WatchPolicy {
+	policy := WatchPolicy{
+		ForcePolling:   !info.SupportsInotify,
+		DebounceWindow: defaultDebounceWindow,
+		TrustAtime:     true,
+	}
+
+	if _, ro := info.Options["ro"]; ro {
+		policy.SkipWriteLivenessCheck = true
+	}
+	if _, noatime := info.Options["noatime"]; noatime {
+		policy.TrustAtime = false
+	}
+	if _, relatime := info.Options["relatime"]; relatime {
+		policy.TrustAtime = false
+	}
+
+	switch info.Type {
+	case FSTypeNFS:
+		if hasDisabledAttrCache(info.Options) {
+			policy.DisableStatCache = true
+			policy.DebounceWindow = lowDebounceWindow
+		}
+	case FSTypeFUSE, FSTypeSSHFS, FSTypeVirtioFS, FSTypeGluster:
+		if cache, ok := info.Options["cache"]; ok && cache == "none" {
+			policy.ForcePolling = true
+		}
+	}
+
+	return policy
+}
 
-	return detectFilesystemTypeFunc(target)
+// hasDisabledAttrCache reports whether opts disable NFS's attribute
+// cache outright, via "noac" or "actimeo=0".
+func hasDisabledAttrCache(opts map[string]string) bool {
+	if _, ok := opts["noac"]; ok {
+		return true
+	}
+	if v, ok := opts["actimeo"]; ok && v == "0" {
+		return true
+	}
+	return false
 }
@@ -3,24 +3,60 @@
 package watcher
 
 import (
-	"bytes"
-
 	"golang.org/x/sys/unix"
 )
 
-func detectFilesystemType(path string) FilesystemType {
+// cstring converts a NUL-terminated int8 byte array (as Statfs_t's
+// char[] fields come through on darwin) into a Go string, trimmed at the
+// first NUL.
+func cstring(b []int8) string {
+	n := 0
+	for n < len(b) && b[n] != 0 {
+		n++
+	}
+	buf := make([]byte, n)
+	for i := 0; i < n; i++ {
+		buf[i] = byte(b[i])
+	}
+	return string(buf)
+}
+
+func detectFilesystemInfo(path string) FilesystemInfo {
 	var stat unix.Statfs_t
 	if err := unix.Statfs(path, &stat); err != nil {
-		return FSTypeUnknown
+		return FilesystemInfo{Type: FSTypeUnknown}
+	}
+
+	// On macOS, Statfs_t exposes the filesystem name, mount point, and
+	// mounted-from source directly - no need for a separate getfsstat
+	// pass or mount table parse.
+	fsType := cstring(stat.Fstypename[:])
+	info := FilesystemInfo{
+		Type:       classifyDarwinFSType(fsType),
+		MountPoint: cstring(stat.Mntonname[:]),
+		Source:     cstring(stat.Mntfromname[:]),
 	}
+	info.IsRemote = isRemoteFilesystem(info.Type)
+	// FSEvents is reliable on local APFS/HFS+ volumes; remote mounts (NFS,
+	// SMB, FUSE-backed) are the cases where it's known to miss or coalesce
+	// events, so prefer kqueue/polling there instead.
+	info.SupportsInotify = !info.IsRemote
+	// APFS and HFS+ are case-insensitive by default (a case-sensitive
+	// variant exists for both but statfs doesn't expose which one is in
+	// play), so default to case-insensitive for local volumes.
+	info.IsCaseInsensitive = !info.IsRemote
+	info.IsCaseSensitive = !info.IsCaseInsensitive
+	return info
+}
 
-	// On macOS, Statfs_t exposes the filesystem name directly.
-	fsType := string(bytes.TrimRight(stat.Fstypename[:], "\x00"))
+func classifyDarwinFSType(fsType string) FilesystemType {
 	switch fsType {
 	case "nfs":
 		return FSTypeNFS
-	case "smbfs", "cifs":
+	case "smbfs":
 		return FSTypeSMB
+	case "cifs":
+		return FSTypeCIFS
 	case "osxfuse", "macfuse", "fusefs":
 		return FSTypeFUSE
 	default:
@@ -0,0 +1,60 @@
+//go:build freebsd
+
+package watcher
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// cstring converts a NUL-terminated int8 byte array (as Statfs_t's
+// char[] fields come through on freebsd) into a Go string, trimmed at
+// the first NUL.
+func cstring(b []int8) string {
+	n := 0
+	for n < len(b) && b[n] != 0 {
+		n++
+	}
+	buf := make([]byte, n)
+	for i := 0; i < n; i++ {
+		buf[i] = byte(b[i])
+	}
+	return string(buf)
+}
+
+func detectFilesystemInfo(path string) FilesystemInfo {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return FilesystemInfo{Type: FSTypeUnknown}
+	}
+
+	// Like darwin, FreeBSD's Statfs_t carries the fstype name, mount
+	// point, and mounted-from source directly; Getfsstat is only needed
+	// to enumerate every mount at once, which we don't need here.
+	fsType := cstring(stat.Fstypename[:])
+	info := FilesystemInfo{
+		Type:       classifyFreeBSDFSType(fsType),
+		MountPoint: cstring(stat.Mntonname[:]),
+		Source:     cstring(stat.Mntfromname[:]),
+	}
+	info.IsRemote = isRemoteFilesystem(info.Type)
+	info.SupportsInotify = !info.IsRemote
+	// UFS and ZFS, FreeBSD's common local filesystems, are case-sensitive.
+	info.IsCaseSensitive = true
+	info.IsCaseInsensitive = !info.IsCaseSensitive
+	return info
+}
+
+func classifyFreeBSDFSType(fsType string) FilesystemType {
+	switch fsType {
+	case "nfs", "nfs4":
+		return FSTypeNFS
+	case "smbfs":
+		return FSTypeSMB
+	case "cifs":
+		return FSTypeCIFS
+	case "fusefs":
+		return FSTypeFUSE
+	default:
+		return FSTypeLocal
+	}
+}
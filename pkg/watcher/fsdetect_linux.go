@@ -12,47 +12,149 @@ import (
 )
 
 const (
-	nfsSuperMagic  int64 = 0x6969
-	cifsSuperMagic int64 = 0xFF534D42
-	fuseSuperMagic int64 = 0x65735546
+	nfsSuperMagic     int64 = 0x6969
+	cifsSuperMagic    int64 = 0xFF534D42
+	fuseSuperMagic    int64 = 0x65735546
+	cephSuperMagic    int64 = 0x00C36400
+	v9fsSuperMagic    int64 = 0x01021997
+	overlaySuperMagic int64 = 0x794C7630
+	virtioFSMagic     int64 = 0x6A656A62
+	afsSuperMagic     int64 = 0x5346414F
+	ncpSuperMagic     int64 = 0x564C
+
+	// The types below are all reliably-local (or pseudo-) filesystems.
+	// They're classified explicitly rather than falling through to the
+	// FSTypeLocal default so WatchPolicy can special-case them later
+	// (e.g. proc's mtimes aren't meaningful change signals) without
+	// another round of magic-number archaeology.
+	procSuperMagic   int64 = 0x9fa0
+	tmpfsSuperMagic  int64 = 0x01021994
+	ext234SuperMagic int64 = 0xef53
+	btrfsSuperMagic  int64 = 0x9123683e
+	zfsSuperMagic    int64 = 0x2fc12fc1
 )
 
-func detectFilesystemType(path string) FilesystemType {
+func detectFilesystemInfo(path string) FilesystemInfo {
 	var stat unix.Statfs_t
 	if err := unix.Statfs(path, &stat); err != nil {
-		return FSTypeUnknown
+		return FilesystemInfo{Type: FSTypeUnknown}
 	}
 
-	switch int64(stat.Type) {
+	t := classifyLinuxMagic(int64(stat.Type))
+
+	entry, ok := lookupLinuxMount(path)
+	if t == FSTypeFUSE && ok {
+		t = classifyLinuxFUSE(entry.fsType)
+	}
+
+	info := FilesystemInfo{Type: t}
+	if ok {
+		info.MountPoint = entry.mountPoint
+		info.Source = entry.source
+		info.DeviceID = entry.majorMinor
+		info.Options = entry.options
+	}
+	info.IsRemote = isRemoteFilesystem(t)
+	// Linux-native and the vast majority of mounted filesystem types
+	// (ext4, btrfs, xfs, overlay, nfs, ...) are case-sensitive; vfat/exfat
+	// are the common exceptions but aren't distinguishable from the magic
+	// number or mountinfo fstype alone, so we don't special-case them.
+	info.IsCaseSensitive = true
+	info.IsCaseInsensitive = !info.IsCaseSensitive
+	// virtiofs and sshfs both sit on top of FUSE, which coalesces and
+	// sometimes drops inotify events under guest/host or network
+	// round-trips; force polling for every remote type rather than
+	// trusting inotify across the boundary.
+	info.SupportsInotify = !info.IsRemote
+	return info
+}
+
+func classifyLinuxMagic(magic int64) FilesystemType {
+	switch magic {
 	case nfsSuperMagic:
 		return FSTypeNFS
 	case cifsSuperMagic:
-		return FSTypeSMB
+		return FSTypeCIFS
+	case cephSuperMagic:
+		return FSTypeCeph
+	case v9fsSuperMagic:
+		return FSType9p
+	case overlaySuperMagic:
+		return FSTypeOverlay
+	case virtioFSMagic:
+		return FSTypeVirtioFS
+	case afsSuperMagic:
+		return FSTypeAFS
+	case ncpSuperMagic:
+		return FSTypeNCP
 	case fuseSuperMagic:
-		if isLinuxSSHFS(path) {
-			return FSTypeSSHFS
-		}
 		return FSTypeFUSE
+	case procSuperMagic:
+		return FSTypeProc
+	case tmpfsSuperMagic:
+		return FSTypeTmpfs
+	case ext234SuperMagic:
+		return FSTypeExt
+	case btrfsSuperMagic:
+		return FSTypeBtrfs
+	case zfsSuperMagic:
+		return FSTypeZFS
 	default:
 		return FSTypeLocal
 	}
 }
 
-func isLinuxSSHFS(path string) bool {
+// classifyLinuxFUSE disambiguates a generic FUSE statfs magic into a more
+// specific type using the FUSE subtype mountinfo reports (e.g.
+// "fuse.sshfs", "fuse.rclone", "fuse.glusterfs"), which statfs alone
+// cannot see.
+func classifyLinuxFUSE(fsType string) FilesystemType {
+	if fsType == "" {
+		return FSTypeFUSE
+	}
+
+	switch {
+	case strings.Contains(fsType, "sshfs"):
+		return FSTypeSSHFS
+	case strings.Contains(fsType, "glusterfs"):
+		return FSTypeGluster
+	case strings.Contains(fsType, "virtiofs"):
+		return FSTypeVirtioFS
+	default:
+		// Includes rclone, juicefs, and any other FUSE subtype we don't
+		// special-case yet - still remote, so callers fall back to polling.
+		return FSTypeFUSE
+	}
+}
+
+// linuxMountEntry is the subset of a /proc/self/mountinfo line we surface
+// through FilesystemInfo.
+type linuxMountEntry struct {
+	mountPoint string
+	fsType     string
+	source     string
+	majorMinor string
+	options    map[string]string
+}
+
+// mountinfoPath is a var so tests can point lookupLinuxMount at a
+// synthetic mountinfo file instead of the real /proc/self/mountinfo.
+var mountinfoPath = "/proc/self/mountinfo"
+
+// lookupLinuxMount returns the most specific mountinfo entry containing
+// path, or ok=false if mountinfo can't be read or parsed.
+func lookupLinuxMount(path string) (entry linuxMountEntry, ok bool) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		absPath = path
 	}
 
-	contents, err := os.ReadFile("/proc/self/mountinfo")
+	contents, err := os.ReadFile(mountinfoPath)
 	if err != nil {
-		// Fall back to generic FUSE.
-		return false
+		return linuxMountEntry{}, false
 	}
 
-	// Find the most specific mountpoint containing absPath and inspect fstype.
-	bestMount := ""
-	bestFSType := ""
+	bestLen := -1
 	lines := bytes.Split(contents, []byte{'\n'})
 	for _, line := range lines {
 		if len(line) == 0 {
@@ -67,36 +169,44 @@ func isLinuxSSHFS(path string) bool {
 		// mountinfo fields before " - ":
 		// id parent major:minor root mount_point options optional_fields...
 		fields := bytes.Fields(parts[0])
-		if len(fields) < 5 {
+		if len(fields) < 6 {
 			continue
 		}
 
 		mountPoint := unescapeMountField(string(fields[4]))
-		if mountPoint == "" || mountPoint == "/" {
-			// Root is fine but likely not the best match unless nothing else.
-		}
-
 		if !pathWithinMount(absPath, mountPoint) {
 			continue
 		}
+		if len(mountPoint) <= bestLen {
+			continue
+		}
 
+		// fields after " - ": fstype source super_options
 		afterFields := bytes.Fields(parts[1])
-		if len(afterFields) < 1 {
+		if len(afterFields) < 2 {
 			continue
 		}
-		fsType := string(afterFields[0])
-		if len(mountPoint) > len(bestMount) {
-			bestMount = mountPoint
-			bestFSType = fsType
+
+		opts := make(map[string]string)
+		addMountOptions(opts, string(fields[5]))
+		if len(afterFields) >= 3 {
+			addMountOptions(opts, string(afterFields[2]))
 		}
-	}
 
-	if bestFSType == "" {
-		return false
+		bestLen = len(mountPoint)
+		entry = linuxMountEntry{
+			mountPoint: mountPoint,
+			fsType:     string(afterFields[0]),
+			source:     unescapeMountField(string(afterFields[1])),
+			majorMinor: string(fields[2]),
+			options:    opts,
+		}
 	}
 
-	// Common sshfs types: "fuse.sshfs" (mountinfo) and sometimes "sshfs".
-	return strings.Contains(bestFSType, "sshfs")
+	if bestLen < 0 {
+		return linuxMountEntry{}, false
+	}
+	return entry, true
 }
 
 func pathWithinMount(path string, mountPoint string) bool {
@@ -116,6 +226,22 @@ func pathWithinMount(path string, mountPoint string) bool {
 	return strings.HasPrefix(path, mountWithSep)
 }
 
+// addMountOptions splits a comma-separated mount option list (e.g.
+// "rw,noatime,actimeo=0") into dst, keyed by option name with value-less
+// flags mapped to "".
+func addMountOptions(dst map[string]string, raw string) {
+	for _, opt := range strings.Split(raw, ",") {
+		if opt == "" {
+			continue
+		}
+		if k, v, ok := strings.Cut(opt, "="); ok {
+			dst[k] = v
+		} else {
+			dst[opt] = ""
+		}
+	}
+}
+
 func unescapeMountField(s string) string {
 	// /proc mount escapes: \040 (space), \011 (tab), \012 (newline), \134 (backslash)
 	// We only implement the common escapes we might encounter in mountpoints.
@@ -0,0 +1,304 @@
+//go:build linux
+
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClassifyLinuxMagic(t *testing.T) {
+	tests := []struct {
+		name  string
+		magic int64
+		want  FilesystemType
+	}{
+		{"nfs", nfsSuperMagic, FSTypeNFS},
+		{"cifs", cifsSuperMagic, FSTypeCIFS},
+		{"ceph", cephSuperMagic, FSTypeCeph},
+		{"9p", v9fsSuperMagic, FSType9p},
+		{"overlay", overlaySuperMagic, FSTypeOverlay},
+		{"virtiofs", virtioFSMagic, FSTypeVirtioFS},
+		{"afs", afsSuperMagic, FSTypeAFS},
+		{"ncpfs", ncpSuperMagic, FSTypeNCP},
+		{"fuse", fuseSuperMagic, FSTypeFUSE},
+		{"proc", procSuperMagic, FSTypeProc},
+		{"tmpfs", tmpfsSuperMagic, FSTypeTmpfs},
+		{"ext", ext234SuperMagic, FSTypeExt},
+		{"btrfs", btrfsSuperMagic, FSTypeBtrfs},
+		{"zfs", zfsSuperMagic, FSTypeZFS},
+		{"unrecognized", 0x1234, FSTypeLocal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyLinuxMagic(tt.magic); got != tt.want {
+				t.Errorf("classifyLinuxMagic(%#x) = %v, want %v", tt.magic, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyLinuxFUSE(t *testing.T) {
+	tests := []struct {
+		fsType string
+		want   FilesystemType
+	}{
+		{"", FSTypeFUSE},
+		{"fuse.sshfs", FSTypeSSHFS},
+		{"fuse.glusterfs", FSTypeGluster},
+		{"fuse.virtiofs", FSTypeVirtioFS},
+		{"fuse.rclone", FSTypeFUSE},
+		{"fuse", FSTypeFUSE},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fsType, func(t *testing.T) {
+			if got := classifyLinuxFUSE(tt.fsType); got != tt.want {
+				t.Errorf("classifyLinuxFUSE(%q) = %v, want %v", tt.fsType, got, tt.want)
+			}
+		})
+	}
+}
+
+// writeSyntheticMountinfo writes lines (already in /proc/self/mountinfo
+// format) to a temp file and points mountinfoPath at it for the duration
+// of the test.
+func writeSyntheticMountinfo(t *testing.T, lines []string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mountinfo")
+	content := ""
+	for _, l := range lines {
+		content += l + "\n"
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing synthetic mountinfo: %v", err)
+	}
+
+	old := mountinfoPath
+	mountinfoPath = path
+	t.Cleanup(func() { mountinfoPath = old })
+}
+
+func TestLookupLinuxMountPicksMostSpecificMatch(t *testing.T) {
+	writeSyntheticMountinfo(t, []string{
+		`36 35 98:0 / / rw,relatime shared:1 - ext4 /dev/sda1 rw,errors=remount-ro`,
+		`37 36 0:30 / /mnt/nfs rw,relatime shared:2 - nfs4 server:/export rw,vers=4.2`,
+		`38 36 0:31 / /mnt/nfs/sub rw,relatime shared:3 - fuse.sshfs user@host:/path rw,user_id=1000`,
+	})
+
+	entry, ok := lookupLinuxMount("/mnt/nfs/sub/file.txt")
+	if !ok {
+		t.Fatal("expected a mount match")
+	}
+	if entry.mountPoint != "/mnt/nfs/sub" {
+		t.Errorf("expected most specific mount point /mnt/nfs/sub, got %q", entry.mountPoint)
+	}
+	if entry.fsType != "fuse.sshfs" {
+		t.Errorf("expected fstype fuse.sshfs, got %q", entry.fsType)
+	}
+	if entry.source != "user@host:/path" {
+		t.Errorf("expected source user@host:/path, got %q", entry.source)
+	}
+	if entry.majorMinor != "0:31" {
+		t.Errorf("expected majorMinor 0:31, got %q", entry.majorMinor)
+	}
+	if v, ok := entry.options["user_id"]; !ok || v != "1000" {
+		t.Errorf("expected options to include user_id=1000, got %v", entry.options)
+	}
+}
+
+func TestLookupLinuxMountFallsBackToParent(t *testing.T) {
+	writeSyntheticMountinfo(t, []string{
+		`36 35 98:0 / / rw,relatime shared:1 - ext4 /dev/sda1 rw`,
+		`37 36 0:30 / /mnt/nfs rw,relatime shared:2 - nfs server:/export rw`,
+	})
+
+	entry, ok := lookupLinuxMount("/mnt/nfs/unrelated/deep/path")
+	if !ok {
+		t.Fatal("expected a mount match")
+	}
+	if entry.mountPoint != "/mnt/nfs" {
+		t.Errorf("expected fallback to /mnt/nfs, got %q", entry.mountPoint)
+	}
+}
+
+func TestLookupLinuxMountNoMatch(t *testing.T) {
+	writeSyntheticMountinfo(t, []string{})
+
+	if _, ok := lookupLinuxMount("/anything"); ok {
+		t.Error("expected no match against an empty mountinfo")
+	}
+}
+
+func TestUnescapeMountField(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`/mnt/my\040share`, "/mnt/my share"},
+		{`/mnt/tab\011here`, "/mnt/tab\there"},
+		{`/mnt/back\134slash`, `/mnt/back\slash`},
+		{"/mnt/plain", "/mnt/plain"},
+	}
+	for _, tt := range tests {
+		if got := unescapeMountField(tt.in); got != tt.want {
+			t.Errorf("unescapeMountField(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAddMountOptions(t *testing.T) {
+	opts := make(map[string]string)
+	addMountOptions(opts, "rw,noatime,actimeo=0,vers=4.2")
+
+	want := map[string]string{"rw": "", "noatime": "", "actimeo": "0", "vers": "4.2"}
+	if len(opts) != len(want) {
+		t.Fatalf("expected %d options, got %d (%v)", len(want), len(opts), opts)
+	}
+	for k, v := range want {
+		if opts[k] != v {
+			t.Errorf("option %q = %q, want %q", k, opts[k], v)
+		}
+	}
+}
+
+func TestLookupLinuxMountBindMountGetsOwnDeviceID(t *testing.T) {
+	writeSyntheticMountinfo(t, []string{
+		`36 35 98:0 / / rw,relatime shared:1 - ext4 /dev/sda1 rw`,
+		`40 36 98:0 /srv/data /mnt/bind rw,relatime shared:1 - ext4 /dev/sda1 rw`,
+	})
+
+	root, ok := lookupLinuxMount("/file")
+	if !ok {
+		t.Fatal("expected a root mount match")
+	}
+	bind, ok := lookupLinuxMount("/mnt/bind/file")
+	if !ok {
+		t.Fatal("expected a bind mount match")
+	}
+
+	// A bind mount shares its backing device with its source mount, so
+	// majorMinor alone can't distinguish them - that's exactly why the
+	// watcher also needs the mount point, not just DeviceID, to avoid
+	// coalescing events across a bind mount boundary.
+	if bind.majorMinor != root.majorMinor {
+		t.Errorf("expected bind mount to share majorMinor with its source mount, got %q vs %q", bind.majorMinor, root.majorMinor)
+	}
+	if bind.mountPoint != "/mnt/bind" {
+		t.Errorf("expected bind mount point /mnt/bind, got %q", bind.mountPoint)
+	}
+}
+
+func TestLookupLinuxMountOverlayfsLowerdir(t *testing.T) {
+	writeSyntheticMountinfo(t, []string{
+		`36 35 98:0 / / rw,relatime shared:1 - ext4 /dev/sda1 rw`,
+		`41 36 0:40 / /mnt/merged rw,relatime shared:2 - overlay overlay rw,lowerdir=/mnt/lower,upperdir=/mnt/upper,workdir=/mnt/work`,
+	})
+
+	entry, ok := lookupLinuxMount("/mnt/merged/file")
+	if !ok {
+		t.Fatal("expected an overlay mount match")
+	}
+	if entry.fsType != "overlay" {
+		t.Errorf("expected fstype overlay, got %q", entry.fsType)
+	}
+	if got := entry.options["lowerdir"]; got != "/mnt/lower" {
+		t.Errorf("expected lowerdir option /mnt/lower, got %q", got)
+	}
+	if got := entry.options["upperdir"]; got != "/mnt/upper" {
+		t.Errorf("expected upperdir option /mnt/upper, got %q", got)
+	}
+}
+
+func TestDetectFilesystemInfoSSHFSCachingOptionsForcePolling(t *testing.T) {
+	writeSyntheticMountinfo(t, []string{
+		`36 35 98:0 / / rw,relatime shared:1 - ext4 /dev/sda1 rw`,
+		`42 36 0:41 / /mnt/remote rw,relatime shared:3 - fuse.sshfs user@host:/path rw,cache=none`,
+	})
+
+	entry, ok := lookupLinuxMount("/mnt/remote/file")
+	if !ok {
+		t.Fatal("expected an sshfs mount match")
+	}
+	classified := classifyLinuxFUSE(entry.fsType)
+	if classified != FSTypeSSHFS {
+		t.Fatalf("expected FSTypeSSHFS, got %v", classified)
+	}
+
+	info := FilesystemInfo{Type: classified, Options: entry.options}
+	info.IsRemote = isRemoteFilesystem(classified)
+	info.SupportsInotify = !info.IsRemote
+
+	policy := info.WatchPolicy()
+	if !policy.ForcePolling {
+		t.Error("expected cache=none sshfs mount to force polling")
+	}
+}
+
+func TestWatchPolicyNFSNoacLowersDebounceAndDisablesStatCache(t *testing.T) {
+	info := FilesystemInfo{
+		Type:    FSTypeNFS,
+		Options: map[string]string{"noac": "", "rw": ""},
+	}
+	info.IsRemote = isRemoteFilesystem(info.Type)
+	info.SupportsInotify = !info.IsRemote
+
+	policy := info.WatchPolicy()
+	if !policy.DisableStatCache {
+		t.Error("expected noac to disable stat-cache assumptions")
+	}
+	if policy.DebounceWindow != lowDebounceWindow {
+		t.Errorf("expected lowered debounce window, got %v", policy.DebounceWindow)
+	}
+}
+
+func TestWatchPolicyReadOnlySkipsWriteLivenessCheck(t *testing.T) {
+	info := FilesystemInfo{
+		Type:    FSTypeLocal,
+		Options: map[string]string{"ro": ""},
+	}
+	info.SupportsInotify = true
+
+	if !info.WatchPolicy().SkipWriteLivenessCheck {
+		t.Error("expected ro mount to skip write-based liveness checks")
+	}
+}
+
+func TestWatchPolicyRelatimeNoatimeDistrustAtime(t *testing.T) {
+	for _, opt := range []string{"relatime", "noatime"} {
+		info := FilesystemInfo{
+			Type:    FSTypeLocal,
+			Options: map[string]string{opt: ""},
+		}
+		info.SupportsInotify = true
+
+		if info.WatchPolicy().TrustAtime {
+			t.Errorf("expected %s to mark atime as untrustworthy", opt)
+		}
+	}
+
+	info := FilesystemInfo{Type: FSTypeLocal, Options: map[string]string{}}
+	info.SupportsInotify = true
+	if !info.WatchPolicy().TrustAtime {
+		t.Error("expected a mount with neither option to trust atime by default")
+	}
+}
+
+func TestPathWithinMount(t *testing.T) {
+	tests := []struct {
+		path, mountPoint string
+		want             bool
+	}{
+		{"/mnt/nfs/file", "/mnt/nfs", true},
+		{"/mnt/nfs", "/mnt/nfs", true},
+		{"/mnt/nfsother/file", "/mnt/nfs", false},
+		{"/anything", "/", true},
+		{"/mnt/nfs/file", "", false},
+	}
+	for _, tt := range tests {
+		if got := pathWithinMount(tt.path, tt.mountPoint); got != tt.want {
+			t.Errorf("pathWithinMount(%q, %q) = %v, want %v", tt.path, tt.mountPoint, got, tt.want)
+		}
+	}
+}
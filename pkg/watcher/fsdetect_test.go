@@ -0,0 +1,50 @@
+package watcher
+
+import "testing"
+
+func TestFilesystemTypeString(t *testing.T) {
+	if got := FSTypeNCP.String(); got != "ncpfs" {
+		t.Errorf("FSTypeNCP.String() = %q, want %q", got, "ncpfs")
+	}
+	if got := FilesystemType(9999).String(); got != "unknown" {
+		t.Errorf("unrecognized FilesystemType.String() = %q, want %q", got, "unknown")
+	}
+}
+
+func TestIsRemoteFilesystem(t *testing.T) {
+	remote := []FilesystemType{
+		FSTypeNFS, FSTypeSMB, FSTypeSSHFS, FSTypeFUSE, FSTypeCIFS,
+		FSTypeCeph, FSTypeGluster, FSType9p, FSTypeVirtioFS, FSTypeAFS, FSTypeNCP,
+	}
+	for _, ft := range remote {
+		if !isRemoteFilesystem(ft) {
+			t.Errorf("expected %v to be remote", ft)
+		}
+	}
+	local := []FilesystemType{FSTypeUnknown, FSTypeLocal, FSTypeOverlay}
+	for _, ft := range local {
+		if isRemoteFilesystem(ft) {
+			t.Errorf("expected %v to not be remote", ft)
+		}
+	}
+}
+
+func TestDetectFilesystemInfoEmptyPath(t *testing.T) {
+	info := DetectFilesystemInfo("")
+	if info.Type != FSTypeUnknown {
+		t.Errorf("expected FSTypeUnknown for empty path, got %v", info.Type)
+	}
+}
+
+func TestDetectFilesystemTypeDelegatesToInfo(t *testing.T) {
+	old := detectFilesystemInfoFunc
+	defer func() { detectFilesystemInfoFunc = old }()
+
+	detectFilesystemInfoFunc = func(path string) FilesystemInfo {
+		return FilesystemInfo{Type: FSTypeGluster, MountPoint: path}
+	}
+
+	if got := DetectFilesystemType("/some/path"); got != FSTypeGluster {
+		t.Errorf("DetectFilesystemType() = %v, want %v", got, FSTypeGluster)
+	}
+}
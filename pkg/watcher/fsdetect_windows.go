@@ -5,15 +5,70 @@ package watcher
 import (
 	"path/filepath"
 	"strings"
+	"unsafe"
 
 	"golang.org/x/sys/windows"
 )
 
-func detectFilesystemType(path string) FilesystemType {
+var (
+	modmpr                    = windows.NewLazySystemDLL("mpr.dll")
+	procWNetGetUniversalNameW = modmpr.NewProc("WNetGetUniversalNameW")
+)
+
+// universalNameInfoLevel asks WNetGetUniversalName for a UNIVERSAL_NAME_INFO,
+// whose buffer starts with a single UTF-16 string pointer - the UNC form
+// of the path (e.g. "\\server\share\sub").
+const universalNameInfoLevel = 1
+
+type universalNameInfo struct {
+	lpUniversalName *uint16
+}
+
+// universalNameForDrive resolves a mapped network drive root (e.g.
+// "Z:\") to its UNC source path, the same lookup Explorer uses to show a
+// drive's real network location. Returns "" if root isn't a mapped drive
+// or the call fails.
+func universalNameForDrive(root string) string {
+	ptr, err := windows.UTF16PtrFromString(root)
+	if err != nil {
+		return ""
+	}
+
+	bufLen := uint32(1024)
+	buf := make([]byte, bufLen)
+	ret, _, _ := procWNetGetUniversalNameW.Call(
+		uintptr(unsafe.Pointer(ptr)),
+		uintptr(universalNameInfoLevel),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(unsafe.Pointer(&bufLen)),
+	)
+	if ret != 0 { // non-zero is a Win32 error code, 0 is NO_ERROR
+		return ""
+	}
+
+	info := (*universalNameInfo)(unsafe.Pointer(&buf[0]))
+	if info.lpUniversalName == nil {
+		return ""
+	}
+	return windows.UTF16PtrToString(info.lpUniversalName)
+}
+
+func detectFilesystemInfo(path string) FilesystemInfo {
 	p := filepath.Clean(path)
+
+	// A UNC path (\\server\share\...) is always remote, regardless of what
+	// GetDriveType reports for a mapped drive letter pointing at it.
+	if strings.HasPrefix(p, `\\`) {
+		info := FilesystemInfo{Type: FSTypeSMB, MountPoint: p, Source: p}
+		info.IsRemote = true
+		info.IsCaseInsensitive = true
+		info.SupportsInotify = false
+		return info
+	}
+
 	vol := filepath.VolumeName(p)
 	if vol == "" {
-		return FSTypeUnknown
+		return FilesystemInfo{Type: FSTypeUnknown}
 	}
 
 	root := vol
@@ -25,14 +80,55 @@ func detectFilesystemType(path string) FilesystemType {
 
 	ptr, err := windows.UTF16PtrFromString(root)
 	if err != nil {
-		return FSTypeUnknown
+		return FilesystemInfo{Type: FSTypeUnknown}
 	}
 
+	info := FilesystemInfo{MountPoint: root}
 	switch windows.GetDriveType(ptr) {
 	case windows.DRIVE_REMOTE:
-		return FSTypeSMB
+		info.Type = FSTypeSMB
+		info.Source = universalNameForDrive(root)
 	case windows.DRIVE_UNKNOWN, windows.DRIVE_NO_ROOT_DIR:
-		return FSTypeUnknown
+		info.Type = FSTypeUnknown
+	case windows.DRIVE_FIXED, windows.DRIVE_REMOVABLE:
+		info.Type = classifyWindowsVolume(ptr)
+	default:
+		info.Type = FSTypeLocal
+	}
+
+	info.IsRemote = isRemoteFilesystem(info.Type)
+	// NTFS/ReFS preserve case but compare case-insensitively by default;
+	// there's no cheap API to detect a case-sensitive directory override,
+	// so we report the common default.
+	info.IsCaseInsensitive = true
+	info.IsCaseSensitive = !info.IsCaseInsensitive
+	// ReadDirectoryChangesW is unreliable across SMB and 9p/virtiofs
+	// shares, so remote and virtio-backed mounts fall back to polling.
+	info.SupportsInotify = !info.IsRemote
+	return info
+}
+
+// classifyWindowsVolume calls GetVolumeInformationW to read the volume's
+// filesystem name (e.g. "NTFS", "ReFS", "9P" for a Hyper-V/WSL virtiofs
+// share), falling back to FSTypeLocal for anything unrecognized.
+func classifyWindowsVolume(root *uint16) FilesystemType {
+	var fsNameBuf [windows.MAX_PATH + 1]uint16
+	err := windows.GetVolumeInformation(
+		root,
+		nil, 0,
+		nil, nil, nil,
+		&fsNameBuf[0], uint32(len(fsNameBuf)),
+	)
+	if err != nil {
+		return FSTypeLocal
+	}
+
+	fsName := strings.ToLower(windows.UTF16ToString(fsNameBuf[:]))
+	switch {
+	case strings.Contains(fsName, "9p"):
+		return FSType9p
+	case strings.Contains(fsName, "virtiofs"):
+		return FSTypeVirtioFS
 	default:
 		return FSTypeLocal
 	}
@@ -0,0 +1,163 @@
+package watcher
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PollChangeKind classifies one change PollWatcher.Scan detected
+// between two scans.
+type PollChangeKind int
+
+const (
+	PollCreated PollChangeKind = iota
+	PollModified
+	PollRemoved
+)
+
+func (k PollChangeKind) String() string {
+	switch k {
+	case PollCreated:
+		return "created"
+	case PollModified:
+		return "modified"
+	case PollRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// PollChange is one file or directory that changed since PollWatcher's
+// previous scan.
+type PollChange struct {
+	Path string
+	Kind PollChangeKind
+}
+
+// pollEntry is the mtime/size snapshot PollWatcher compares across
+// scans to notice a change - size is included alongside mtime because
+// some remote filesystems coarsen mtime resolution to a second,
+// collapsing back-to-back edits into the same timestamp.
+type pollEntry struct {
+	modTime time.Time
+	size    int64
+}
+
+// PollWatcher detects changes under a directory tree by periodically
+// re-statting every entry and diffing against the previous scan,
+// rather than relying on native change notifications. It exists for
+// mounts SelectBackend has routed to BackendPolling: NFS, SMB/CIFS,
+// FUSE, and other remote or virtual filesystems where inotify-style
+// events are coalesced, delayed, or simply never delivered across the
+// network or guest/host boundary.
+type PollWatcher struct {
+	root     string
+	interval time.Duration
+
+	mu      sync.Mutex
+	entries map[string]pollEntry
+}
+
+// NewPollWatcher returns a PollWatcher over root, scanning every
+// interval once Run is called. A non-positive interval falls back to
+// DefaultPollInterval.
+func NewPollWatcher(root string, interval time.Duration) *PollWatcher {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &PollWatcher{root: root, interval: interval}
+}
+
+// Scan walks root once and returns every PollChange since the previous
+// Scan, sorted by path. The first call only establishes the baseline
+// snapshot and never reports changes, since there is nothing yet to
+// diff against. Entries that fail to stat mid-walk (e.g. removed
+// between readdir and stat) are skipped rather than aborting the scan.
+// An error reading root itself fails the scan and leaves the previous
+// snapshot in place.
+func (p *PollWatcher) Scan() ([]PollChange, error) {
+	current := make(map[string]pollEntry)
+	err := filepath.WalkDir(p.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == p.root {
+				return err
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		current[path] = pollEntry{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	firstScan := p.entries == nil
+	var changes []PollChange
+	for path, entry := range current {
+		prev, existed := p.entries[path]
+		switch {
+		case !existed:
+			if !firstScan {
+				changes = append(changes, PollChange{Path: path, Kind: PollCreated})
+			}
+		case !entry.modTime.Equal(prev.modTime) || entry.size != prev.size:
+			changes = append(changes, PollChange{Path: path, Kind: PollModified})
+		}
+	}
+	for path := range p.entries {
+		if _, ok := current[path]; !ok {
+			changes = append(changes, PollChange{Path: path, Kind: PollRemoved})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	p.entries = current
+	return changes, nil
+}
+
+// Run scans every p.interval until ctx is done, sending each
+// non-empty batch of changes to out. The first scan (establishing the
+// baseline) happens immediately rather than waiting a full interval.
+// Run blocks, so callers typically invoke it in its own goroutine.
+func (p *PollWatcher) Run(ctx context.Context, out chan<- []PollChange) error {
+	if _, err := p.Scan(); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			changes, err := p.Scan()
+			if err != nil {
+				// Transient (e.g. root briefly missing mid-remount);
+				// keep polling rather than giving up the watch.
+				continue
+			}
+			if len(changes) == 0 {
+				continue
+			}
+			select {
+			case out <- changes:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPollWatcherFirstScanEstablishesBaseline(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	pw := NewPollWatcher(dir, time.Second)
+	changes, err := pw.Scan()
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected no changes on the first scan, got %v", changes)
+	}
+}
+
+func TestPollWatcherDetectsCreatedModifiedRemoved(t *testing.T) {
+	dir := t.TempDir()
+	staticPath := filepath.Join(dir, "static.txt")
+	modPath := filepath.Join(dir, "modified.txt")
+	removePath := filepath.Join(dir, "removed.txt")
+
+	write := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(staticPath, "static")
+	write(modPath, "v1")
+	write(removePath, "gone-soon")
+
+	pw := NewPollWatcher(dir, time.Second)
+	if _, err := pw.Scan(); err != nil {
+		t.Fatalf("baseline Scan: %v", err)
+	}
+
+	if err := os.Remove(removePath); err != nil {
+		t.Fatal(err)
+	}
+	// Push modPath's mtime an hour forward so the rewrite is guaranteed to
+	// register even on filesystems with coarse mtime resolution.
+	write(modPath, "v2-longer-content")
+	if err := os.Chtimes(modPath, time.Now().Add(time.Hour), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	createdPath := filepath.Join(dir, "created.txt")
+	write(createdPath, "new")
+
+	changes, err := pw.Scan()
+	if err != nil {
+		t.Fatalf("second Scan: %v", err)
+	}
+
+	byPath := make(map[string]PollChangeKind, len(changes))
+	for _, c := range changes {
+		byPath[c.Path] = c.Kind
+	}
+
+	if kind, ok := byPath[createdPath]; !ok || kind != PollCreated {
+		t.Errorf("expected %s to be reported as created, got %v (ok=%v)", createdPath, kind, ok)
+	}
+	if kind, ok := byPath[modPath]; !ok || kind != PollModified {
+		t.Errorf("expected %s to be reported as modified, got %v (ok=%v)", modPath, kind, ok)
+	}
+	if kind, ok := byPath[removePath]; !ok || kind != PollRemoved {
+		t.Errorf("expected %s to be reported as removed, got %v (ok=%v)", removePath, kind, ok)
+	}
+	if _, ok := byPath[staticPath]; ok {
+		t.Errorf("expected %s to not be reported as changed", staticPath)
+	}
+}
+
+func TestPollWatcherRunSendsChangesUntilCancelled(t *testing.T) {
+	dir := t.TempDir()
+
+	pw := NewPollWatcher(dir, 10*time.Millisecond)
+	out := make(chan []PollChange, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- pw.Run(ctx, out) }()
+
+	// Give Run time to complete its baseline scan before introducing a
+	// change, so the change isn't folded into the baseline.
+	time.Sleep(20 * time.Millisecond)
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	newPath := filepath.Join(dir, "new.txt")
+	select {
+	case changes := <-out:
+		found := false
+		for _, c := range changes {
+			if c.Path == newPath {
+				found = true
+				if c.Kind != PollCreated {
+					t.Errorf("expected %s to be reported as created, got %v", newPath, c.Kind)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected a change for %s, got %v", newPath, changes)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for Run to report the new file")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("expected Run to return context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for Run to exit after cancellation")
+	}
+}
+
+func TestPollChangeKindString(t *testing.T) {
+	cases := map[PollChangeKind]string{
+		PollCreated:        "created",
+		PollModified:       "modified",
+		PollRemoved:        "removed",
+		PollChangeKind(99): "unknown",
+	}
+	for kind, want := range cases {
+		if got := kind.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", int(kind), got, want)
+		}
+	}
+}